@@ -15,6 +15,7 @@ import (
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/guardian/rundmc"
+	"code.cloudfoundry.org/guardian/rundmc/dadoo"
 	"code.cloudfoundry.org/guardian/rundmc/goci"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/cloudfoundry/gunk/command_runner/linux_command_runner"
@@ -147,6 +148,31 @@ var _ = Describe("Dadoo", func() {
 			Expect(ioutil.ReadFile(filepath.Join(processDir, "exitcode"))).To(Equal([]byte("24")))
 		})
 
+		It("should record the exit status and resource usage in exit.json in the container dir", func() {
+			processSpec, err := json.Marshal(&specs.Process{
+				Args: []string{"/bin/sh", "-c", "exit 24"},
+				Cwd:  "/",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			cmd := exec.Command(dadooBinPath, "exec", "runc", processDir, filepath.Base(bundlePath))
+			cmd.Stdin = bytes.NewReader(processSpec)
+			cmd.ExtraFiles = []*os.File{mustOpen("/dev/null"), runcLogFile, mustOpen("/dev/null")}
+
+			sess, err := gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(24))
+
+			contents, err := ioutil.ReadFile(filepath.Join(processDir, "exit.json"))
+			Expect(err).NotTo(HaveOccurred())
+
+			var exit dadoo.ExitFile
+			Expect(json.Unmarshal(contents, &exit)).To(Succeed())
+			Expect(exit.ExitStatus).To(Equal(24))
+			Expect(exit.Usage.MaxRSSBytes).To(BeNumerically(">", 0))
+			Expect(exit.Usage.WallClock).To(BeNumerically(">", 0))
+		})
+
 		It("if the process is signalled the exitcode should be 128 + the signal number", func() {
 			processSpec, err := json.Marshal(&specs.Process{
 				Args: []string{"/bin/sh", "-c", "kill -9 $$"},
@@ -346,6 +372,52 @@ var _ = Describe("Dadoo", func() {
 
 				Consistently(process.ExitCode, time.Second, time.Millisecond*100).Should(Equal(-1), "expected process to stay alive")
 			})
+
+			Context("when the output is logged", func() {
+				It("does not wait for a client to read the named pipes, and logs everything for replay", func() {
+					spec := specs.Process{
+						Args: []string{"/bin/sh", "-c", "read detached; head -c 200000 /dev/zero | tr '\\0' a"},
+						Cwd:  "/",
+					}
+
+					encSpec, err := json.Marshal(spec)
+					Expect(err).NotTo(HaveOccurred())
+
+					cmd := exec.Command(dadooBinPath, "-output-log-segment-bytes", "1048576", "exec", "runc", processDir, filepath.Base(bundlePath))
+					cmd.ExtraFiles = []*os.File{mustOpen("/dev/null"), runcLogFile, mustOpen("/dev/null")}
+					cmd.Stdin = bytes.NewReader(encSpec)
+					process, err := gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					stdinP, err := os.OpenFile(stdinPipe, os.O_WRONLY, 0600)
+					Expect(err).NotTo(HaveOccurred())
+
+					stdoutP, err := os.Open(stdoutPipe)
+					Expect(err).NotTo(HaveOccurred())
+
+					stderrP, err := os.Open(stderrPipe)
+					Expect(err).NotTo(HaveOccurred())
+
+					// the client detaches before the process writes anything
+					Expect(stdoutP.Close()).To(Succeed())
+					Expect(stderrP.Close()).To(Succeed())
+					_, err = stdinP.WriteString("now\n")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(stdinP.Close()).To(Succeed())
+
+					Eventually(process).Should(gexec.Exit(0))
+
+					log := dadoo.OutputLog{Dir: processDir, Stream: "stdout"}
+					end, err := log.End()
+					Expect(err).NotTo(HaveOccurred())
+
+					replay := log.Reader(0, end)
+					defer replay.Close()
+					output, err := ioutil.ReadAll(replay)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(output).To(Equal(bytes.Repeat([]byte("a"), 200000)))
+				})
+			})
 		})
 
 		Context("requesting a TTY", func() {
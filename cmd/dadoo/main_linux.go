@@ -12,6 +12,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -30,6 +31,10 @@ const MaxSocketDirPathLength = 80
 var (
 	tty           = flag.Bool("tty", false, "tty requested")
 	socketDirPath = flag.String("socket-dir-path", "", "path to a dir in which to store console sockets")
+	runtimeArgs   stringSlice
+
+	outputLogSegmentBytes = flag.Int64("output-log-segment-bytes", 0, "size at which to rotate the logs of the process's output, or 0 not to log it")
+	outputLogSegments     = flag.Int("output-log-segments", 4, "number of rotated segments of the process's output logs to keep")
 
 	ioWg *sync.WaitGroup = &sync.WaitGroup{}
 )
@@ -39,6 +44,7 @@ func main() {
 }
 
 func run() int {
+	flag.Var(&runtimeArgs, "runtime-arg", "global argument to pass to the runtime, may be repeated")
 	flag.Parse()
 
 	runtime := flag.Args()[1] // e.g. runc
@@ -66,28 +72,54 @@ func run() int {
 		tryClose(stdoutR, stderrR)
 	}()
 
+	// when output is logged it is written to the logs as well as the fifos,
+	// so that clients which attach later can replay what they missed. Only
+	// the logs are waited for, since nothing reads the fifos while no client
+	// is attached.
+	limits := dadoo.OutputLogLimits{SegmentBytes: *outputLogSegmentBytes, Segments: *outputLogSegments}
+
 	var runcExecCmd *exec.Cmd
+	var logPipes []io.Closer
 	if *tty {
 		if len(*socketDirPath) > MaxSocketDirPathLength {
 			logAndExit(fmt.Sprintf("value for --socket-dir-path cannot exceed %d characters in length", MaxSocketDirPathLength))
 		}
-		ttySocketPath := setupTTYSocket(stdinR, stdoutW, winsz, pidFilePath, *socketDirPath)
-		runcExecCmd = exec.Command(runtime, "-debug", "-log", logFile, "exec", "-d", "-tty", "-console-socket", ttySocketPath, "-p", fmt.Sprintf("/proc/%d/fd/0", os.Getpid()), "-pid-file", pidFilePath, containerId)
+
+		var ttyOutput io.Writer = stdoutW
+		if limits.Enabled() {
+			ttyOutput = io.MultiWriter(newOutputLog(processStateDir, "stdout", limits), newFifoWriter(stdoutW))
+		}
+
+		ttySocketPath := setupTTYSocket(stdinR, ttyOutput, winsz, pidFilePath, *socketDirPath)
+		runcExecCmd = exec.Command(runtime, append(runtimeArgs, "-debug", "-log", logFile, "exec", "-d", "-tty", "-console-socket", ttySocketPath, "-p", fmt.Sprintf("/proc/%d/fd/0", os.Getpid()), "-pid-file", pidFilePath, containerId)...)
 	} else {
-		runcExecCmd = exec.Command(runtime, "-debug", "-log", logFile, "exec", "-p", fmt.Sprintf("/proc/%d/fd/0", os.Getpid()), "-d", "-pid-file", pidFilePath, containerId)
+		runcExecCmd = exec.Command(runtime, append(runtimeArgs, "-debug", "-log", logFile, "exec", "-p", fmt.Sprintf("/proc/%d/fd/0", os.Getpid()), "-d", "-pid-file", pidFilePath, containerId)...)
 		runcExecCmd.Stdin = stdinR
 		runcExecCmd.Stdout = stdoutW
 		runcExecCmd.Stderr = stderrW
+
+		if limits.Enabled() {
+			stdoutLogW := teeOutput(processStateDir, "stdout", stdoutW, limits)
+			stderrLogW := teeOutput(processStateDir, "stderr", stderrW, limits)
+			runcExecCmd.Stdout = stdoutLogW
+			runcExecCmd.Stderr = stderrLogW
+			logPipes = append(logPipes, stdoutLogW, stderrLogW)
+		}
 	}
 
 	// we need to be the subreaper so we can wait on the detached container process
 	system.SetSubreaper(os.Getpid())
 
+	startedAt := time.Now()
 	if err := runcExecCmd.Start(); err != nil {
 		runcExitCodePipe.Write([]byte{2})
 		return 2
 	}
 
+	// only the process should hold the write ends of the log pipes, so that
+	// the logs are finished when it exits
+	tryClose(logPipes...)
+
 	var status syscall.WaitStatus
 	var rusage syscall.Rusage
 	_, err := syscall.Wait4(runcExecCmd.Process.Pid, &status, 0, &rusage)
@@ -103,7 +135,7 @@ func run() int {
 	containerPid, err := parsePid(pidFilePath)
 	check(err)
 
-	return waitForContainerToExit(processStateDir, containerPid, signals)
+	return waitForContainerToExit(processStateDir, containerPid, startedAt, signals)
 }
 
 // If gdn server process dies, we need dadoo to keep stdout/err reader
@@ -115,7 +147,7 @@ func openStdioKeepAlivePipes(processStateDir string) (io.ReadCloser, io.ReadClos
 	return keepStdoutAlive, keepStderrAlive
 }
 
-func waitForContainerToExit(processStateDir string, containerPid int, signals chan os.Signal) (exitCode int) {
+func waitForContainerToExit(processStateDir string, containerPid int, startedAt time.Time, signals chan os.Signal) (exitCode int) {
 	for range signals {
 		for {
 			var status syscall.WaitStatus
@@ -131,8 +163,13 @@ func waitForContainerToExit(processStateDir string, containerPid int, signals ch
 					exitCode = 128 + int(status.Signal())
 				}
 
+				usage := dadoo.NewResourceUsage(rusage, time.Since(startedAt))
+
 				ioWg.Wait() // wait for full output to be collected
 
+				// written first, since guardian takes the exitcode file to mean
+				// that the process has finished
+				check(dadoo.WriteExitFile(processStateDir, dadoo.ExitFile{ExitStatus: exitCode, Usage: usage}))
 				check(ioutil.WriteFile(filepath.Join(processStateDir, "exitcode"), []byte(strconv.Itoa(exitCode)), 0700))
 				return exitCode
 			}
@@ -143,6 +180,71 @@ func waitForContainerToExit(processStateDir string, containerPid int, signals ch
 	return 0                         // unreachable
 }
 
+func newOutputLog(processStateDir, stream string, limits dadoo.OutputLogLimits) io.WriteCloser {
+	w, err := dadoo.OutputLog{Dir: processStateDir, Stream: stream}.NewWriter(limits)
+	check(err)
+	return w
+}
+
+// teeOutput returns the write end of a pipe whose contents are written to the
+// stream's log and then to its fifo. Writing to the log first means that
+// anything a client reads from the fifo has already been logged. The
+// process's exit is not reported until the copy finishes.
+func teeOutput(processStateDir, stream string, fifo io.Writer, limits dadoo.OutputLogLimits) *os.File {
+	r, w, err := os.Pipe()
+	check(err)
+
+	log := newOutputLog(processStateDir, stream, limits)
+	fifo = newFifoWriter(fifo)
+
+	ioWg.Add(1)
+	go func() {
+		defer ioWg.Done()
+		io.Copy(io.MultiWriter(log, fifo), r)
+		tryClose(log, r)
+	}()
+
+	return w
+}
+
+// fifoWriter writes to a fifo without waiting for it to be read. Whatever
+// does not fit in the fifo's buffer is dropped, which is safe because it has
+// already been logged, and clients replay the logs when they attach rather
+// than reading what was left in the fifo.
+type fifoWriter struct {
+	fifo *os.File // kept so that the fd is not closed underneath us
+	fd   int
+}
+
+func newFifoWriter(fifo io.Writer) io.Writer {
+	file, ok := fifo.(*os.File)
+	if !ok {
+		return fifo
+	}
+
+	// Fd puts the file into blocking mode, so it must be called first
+	fd := int(file.Fd())
+	check(syscall.SetNonblock(fd, true))
+	return fifoWriter{fifo: file, fd: fd}
+}
+
+func (w fifoWriter) Write(p []byte) (int, error) {
+	for written := 0; written < len(p); {
+		n, err := syscall.Write(w.fd, p[written:])
+		if err == syscall.EINTR {
+			continue
+		}
+
+		if err != nil || n <= 0 {
+			break // the fifo is full, so the rest is dropped
+		}
+
+		written += n
+	}
+
+	return len(p), nil
+}
+
 func openPipes(processStateDir string) (io.ReadCloser, io.WriteCloser, io.WriteCloser, io.ReadWriteCloser) {
 	stdin := openFifo(filepath.Join(processStateDir, "stdin"), os.O_RDONLY)
 	stdout := openFifo(filepath.Join(processStateDir, "stdout"), os.O_WRONLY|os.O_APPEND)
@@ -293,3 +395,14 @@ func tryClose(closers ...io.Closer) {
 		}
 	}
 }
+
+type stringSlice []string
+
+func (s *stringSlice) String() string {
+	return strings.Join(*s, " ")
+}
+
+func (s *stringSlice) Set(value string) error {
+	*s = append(*s, value)
+	return nil
+}
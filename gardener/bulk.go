@@ -0,0 +1,135 @@
+package gardener
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type bulkResult struct {
+	value interface{}
+	err   error
+}
+
+// bulk runs work for each of the handles on at most BulkParallelism workers.
+// Work which runs longer than BulkHandleTimeout gives its handle an error
+// result and frees up the worker, though the work itself carries on in the
+// background since there is no way to interrupt it. No more work is started
+// for that handle until it returns, so at most one piece of abandoned work
+// runs for each handle. Work runs once for a handle which is given more than
+// once.
+func (g *Gardener) bulk(handles []string, work func(handle string) (interface{}, error)) map[string]bulkResult {
+	handles = uniqueHandles(handles)
+
+	workers := g.BulkParallelism
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(handles) {
+		workers = len(handles)
+	}
+
+	jobs := make(chan string)
+	results := make(map[string]bulkResult, len(handles))
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			for handle := range jobs {
+				result := g.runWithTimeout(handle, work)
+
+				mu.Lock()
+				results[handle] = result
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, handle := range handles {
+		jobs <- handle
+	}
+	close(jobs)
+
+	wg.Wait()
+	return results
+}
+
+func uniqueHandles(handles []string) []string {
+	seen := make(map[string]struct{}, len(handles))
+	unique := make([]string, 0, len(handles))
+	for _, handle := range handles {
+		if _, ok := seen[handle]; ok {
+			continue
+		}
+
+		seen[handle] = struct{}{}
+		unique = append(unique, handle)
+	}
+
+	return unique
+}
+
+func (g *Gardener) runWithTimeout(handle string, work func(handle string) (interface{}, error)) bulkResult {
+	if g.BulkHandleTimeout <= 0 {
+		value, err := work(handle)
+		return bulkResult{value: value, err: err}
+	}
+
+	if !g.bulkBusy.claim(handle) {
+		return bulkResult{err: fmt.Errorf("still waiting for an earlier query, which timed out after %s", g.BulkHandleTimeout)}
+	}
+
+	done := make(chan bulkResult, 1)
+	go func() {
+		defer g.bulkBusy.release(handle)
+
+		value, err := work(handle)
+		done <- bulkResult{value: value, err: err}
+	}()
+
+	timer := time.NewTimer(g.BulkHandleTimeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-done:
+		return result
+	case <-timer.C:
+		return bulkResult{err: fmt.Errorf("timed out after %s", g.BulkHandleTimeout)}
+	}
+}
+
+// busyHandles are those which bulk work is running for
+type busyHandles struct {
+	mu      sync.Mutex
+	handles map[string]struct{}
+}
+
+func (b *busyHandles) claim(handle string) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.handles[handle]; ok {
+		return false
+	}
+
+	if b.handles == nil {
+		b.handles = make(map[string]struct{})
+	}
+
+	b.handles[handle] = struct{}{}
+	return true
+}
+
+func (b *busyHandles) release(handle string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.handles, handle)
+}
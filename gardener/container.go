@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/garden"
@@ -18,6 +19,10 @@ type container struct {
 	volumeCreator   VolumeCreator
 	networker       Networker
 	propertyManager PropertyManager
+	eventPublisher  EventPublisher
+	usageEmitter    ProcessUsageEmitter
+	exitWatchers    *exitWatchers
+	states          lifecycle
 }
 
 func (c *container) Handle() string {
@@ -25,15 +30,40 @@ func (c *container) Handle() string {
 }
 
 func (c *container) Run(spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
-	return c.containerizer.Run(c.logger, c.handle, spec, io)
+	process, err := c.containerizer.Run(c.logger, c.handle, spec, io)
+	if err != nil {
+		return nil, err
+	}
+
+	c.eventPublisher.Publish(Event{Type: EventProcessStarted, Handle: c.handle, ProcessID: process.ID()})
+	return c.exitWatchers.publish(process, c.handle, c.eventPublisher, c.usageEmitter), nil
 }
 
 func (c *container) Attach(processID string, io garden.ProcessIO) (garden.Process, error) {
-	return c.containerizer.Attach(c.logger, c.handle, processID, io)
+	process, err := c.containerizer.Attach(c.logger, c.handle, processID, io)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.exitWatchers.publish(process, c.handle, c.eventPublisher, c.usageEmitter), nil
 }
 
 func (c *container) Stop(kill bool) error {
-	return c.containerizer.Stop(c.logger, c.handle, kill)
+	if err := c.states.transition(c.handle, StateStopping); err != nil {
+		return err
+	}
+
+	if err := c.containerizer.Stop(c.logger, c.handle, kill); err != nil {
+		c.states.fail(c.handle)
+		return err
+	}
+
+	if err := c.states.transition(c.handle, StateCreated); err != nil {
+		return err
+	}
+
+	c.eventPublisher.Publish(Event{Type: EventStopped, Handle: c.handle})
+	return nil
 }
 
 func (c *container) Info() (garden.ContainerInfo, error) {
@@ -75,6 +105,10 @@ func (c *container) Info() (garden.ContainerInfo, error) {
 		state = "stopped"
 	}
 
+	if actualContainerSpec.Paused {
+		state = "paused"
+	}
+
 	json.Unmarshal([]byte(mappedPortsCfg), &mappedPorts)
 	return garden.ContainerInfo{
 		State:         state,
@@ -83,6 +117,7 @@ func (c *container) Info() (garden.ContainerInfo, error) {
 		ExternalIP:    externalIP,
 		ContainerPath: actualContainerSpec.BundlePath,
 		Events:        actualContainerSpec.Events,
+		ProcessIDs:    actualContainerSpec.ProcessIDs,
 		Properties:    properties,
 		MappedPorts:   mappedPorts,
 	}, nil
@@ -97,15 +132,15 @@ func (c *container) StreamOut(spec garden.StreamOutSpec) (io.ReadCloser, error)
 }
 
 func (c *container) LimitBandwidth(limits garden.BandwidthLimits) error {
-	return nil
+	return c.networker.LimitBandwidth(c.logger, c.handle, limits)
 }
 
 func (c *container) CurrentBandwidthLimits() (garden.BandwidthLimits, error) {
-	return garden.BandwidthLimits{}, nil
+	return c.networker.BandwidthLimits(c.logger, c.handle)
 }
 
 func (c *container) LimitCPU(limits garden.CPULimits) error {
-	return nil
+	return c.containerizer.LimitCPU(c.logger, c.handle, limits)
 }
 
 func (c *container) CurrentCPULimits() (garden.CPULimits, error) {
@@ -114,15 +149,71 @@ func (c *container) CurrentCPULimits() (garden.CPULimits, error) {
 }
 
 func (c *container) LimitDisk(limits garden.DiskLimits) error {
-	return nil
+	log := c.logger.Session("limit-disk", lager.Data{"handle": c.handle, "limits": limits})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	resizer, ok := c.volumeCreator.(VolumeResizer)
+	if !ok {
+		return ErrDiskResizeNotSupported
+	}
+
+	actualSpec, err := c.containerizer.Info(log, c.handle)
+	if err != nil {
+		return err
+	}
+
+	if err := resizer.Resize(log, c.handle, !actualSpec.Privileged, limits); err != nil {
+		log.Error("resize-failed", err)
+		return err
+	}
+
+	return saveDiskLimits(c.propertyManager, c.handle, limits)
 }
 
+// CurrentDiskLimits asks the volume creator for the quota in force, falling
+// back to the limits recorded when they were last set if it cannot say
 func (c *container) CurrentDiskLimits() (garden.DiskLimits, error) {
-	return garden.DiskLimits{}, nil
+	log := c.logger.Session("current-disk-limits", lager.Data{"handle": c.handle})
+
+	if resizer, ok := c.volumeCreator.(VolumeResizer); ok {
+		limits, err := c.volumeDiskLimits(log, resizer)
+		if err == nil {
+			return limits, nil
+		}
+
+		log.Error("query-volume-creator-failed-falling-back", err)
+	}
+
+	return c.recordedDiskLimits()
+}
+
+func (c *container) volumeDiskLimits(log lager.Logger, resizer VolumeResizer) (garden.DiskLimits, error) {
+	actualSpec, err := c.containerizer.Info(log, c.handle)
+	if err != nil {
+		return garden.DiskLimits{}, err
+	}
+
+	return resizer.DiskLimits(log, c.handle, !actualSpec.Privileged)
+}
+
+func (c *container) recordedDiskLimits() (garden.DiskLimits, error) {
+	limitsJson, ok := c.propertyManager.Get(c.handle, DiskLimitsKey)
+	if !ok {
+		return garden.DiskLimits{}, nil
+	}
+
+	var limits garden.DiskLimits
+	if err := json.Unmarshal([]byte(limitsJson), &limits); err != nil {
+		return garden.DiskLimits{}, fmt.Errorf("unmarshaling disk limits: %s", err)
+	}
+
+	return limits, nil
 }
 
 func (c *container) LimitMemory(limits garden.MemoryLimits) error {
-	return nil
+	return c.containerizer.LimitMemory(c.logger, c.handle, limits)
 }
 
 func (c *container) CurrentMemoryLimits() (garden.MemoryLimits, error) {
@@ -165,6 +256,21 @@ func (c *container) Metrics() (garden.Metrics, error) {
 	}, nil
 }
 
+// saveDiskLimits records the parts of the limits which are enforced by the
+// volume quota, i.e. the hard byte limit and its scope.
+func saveDiskLimits(propertyManager PropertyManager, handle string, limits garden.DiskLimits) error {
+	limitsJson, err := json.Marshal(garden.DiskLimits{
+		ByteHard: limits.ByteHard,
+		Scope:    limits.Scope,
+	})
+	if err != nil {
+		return err
+	}
+
+	propertyManager.Set(handle, DiskLimitsKey, string(limitsJson))
+	return nil
+}
+
 func (c *container) Properties() (garden.Properties, error) {
 	return c.propertyManager.All(c.handle)
 }
@@ -191,3 +297,90 @@ func (c *container) SetGraceTime(t time.Duration) error {
 	c.propertyManager.Set(c.handle, GraceTimeKey, fmt.Sprintf("%d", t))
 	return nil
 }
+
+//go:generate counterfeiter . ExitWatcher
+
+// ExitWatcher is implemented by processes which can report when they exit
+// without being waited for, since waiting for a process also cleans it up
+type ExitWatcher interface {
+	WaitForExit() (int, *ResourceUsage, error)
+}
+
+//go:generate counterfeiter . ProcessUsageEmitter
+
+// ProcessUsageEmitter is sent the resources used by each process which exits
+type ProcessUsageEmitter interface {
+	EmitProcessUsage(usage ResourceUsage)
+}
+
+// exitWatchers publishes the exit of each process in a container, and emits
+// the resources it used, once however many times it is run or attached to
+type exitWatchers struct {
+	mu       sync.Mutex
+	watching map[string]map[string]struct{}
+}
+
+// publish publishes the exit of processes which can be watched, and returns
+// the process unchanged so that callers still wait for it themselves
+func (w *exitWatchers) publish(process garden.Process, handle string, publisher EventPublisher, usageEmitter ProcessUsageEmitter) garden.Process {
+	watcher, ok := process.(ExitWatcher)
+	if !ok {
+		return process
+	}
+
+	processID := process.ID()
+	if !w.claim(handle, processID) {
+		return process
+	}
+
+	go func() {
+		exitStatus, usage, err := watcher.WaitForExit()
+		if err != nil {
+			w.release(handle, processID)
+			return
+		}
+
+		publisher.Publish(Event{Type: EventProcessExited, Handle: handle, ProcessID: processID, ExitStatus: &exitStatus, Usage: usage})
+		if usage != nil && usageEmitter != nil {
+			usageEmitter.EmitProcessUsage(*usage)
+		}
+	}()
+
+	return process
+}
+
+func (w *exitWatchers) claim(handle, processID string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if _, ok := w.watching[handle][processID]; ok {
+		return false
+	}
+
+	if w.watching == nil {
+		w.watching = make(map[string]map[string]struct{})
+	}
+
+	if w.watching[handle] == nil {
+		w.watching[handle] = make(map[string]struct{})
+	}
+
+	w.watching[handle][processID] = struct{}{}
+	return true
+}
+
+func (w *exitWatchers) release(handle, processID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.watching[handle], processID)
+}
+
+// forget forgets the processes of a destroyed container, whose IDs may be
+// used again by a new container with the same handle
+func (w *exitWatchers) forget(handle string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.watching, handle)
+}
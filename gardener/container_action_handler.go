@@ -0,0 +1,48 @@
+package gardener
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/garden"
+)
+
+// ContainerAction is an operation on a single container which the garden API
+// does not expose, such as Gardener.Checkpoint
+type ContainerAction func(handle string) error
+
+type containerActionHandler struct {
+	action ContainerAction
+}
+
+// NewContainerActionHandler runs the action on the container given by the
+// handle query parameter when it receives a POST. The status is 404 Not Found
+// when there is no such container.
+func NewContainerActionHandler(action ContainerAction) http.Handler {
+	return &containerActionHandler{action: action}
+}
+
+func (h *containerActionHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	handle := r.URL.Query().Get("handle")
+	if handle == "" {
+		http.Error(w, "handle is required", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.action(handle); err != nil {
+		status := http.StatusInternalServerError
+		if _, ok := err.(garden.ContainerNotFoundError); ok {
+			status = http.StatusNotFound
+		}
+
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
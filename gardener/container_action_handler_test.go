@@ -0,0 +1,73 @@
+package gardener_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ContainerActionHandler", func() {
+	var (
+		actedOn   []string
+		actionErr error
+		recorder  *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		actedOn = nil
+		actionErr = nil
+		recorder = httptest.NewRecorder()
+	})
+
+	serve := func(method, path string) {
+		req, err := http.NewRequest(method, path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		gardener.NewContainerActionHandler(func(handle string) error {
+			actedOn = append(actedOn, handle)
+			return actionErr
+		}).ServeHTTP(recorder, req)
+	}
+
+	It("runs the action on the container on a POST", func() {
+		serve("POST", "/debug/checkpoint?handle=some-handle")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(actedOn).To(Equal([]string{"some-handle"}))
+	})
+
+	Context("when the handle is missing", func() {
+		It("responds with 400", func() {
+			serve("POST", "/debug/checkpoint")
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			Expect(actedOn).To(BeEmpty())
+		})
+	})
+
+	Context("when the container does not exist", func() {
+		It("responds with 404", func() {
+			actionErr = garden.ContainerNotFoundError{Handle: "some-handle"}
+			serve("POST", "/debug/checkpoint?handle=some-handle")
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	Context("when the action fails", func() {
+		It("responds with 500 and the error", func() {
+			actionErr = errors.New("criu-failed")
+			serve("POST", "/debug/checkpoint?handle=some-handle")
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+			Expect(recorder.Body.String()).To(ContainSubstring("criu-failed"))
+		})
+	})
+
+	It("rejects other methods", func() {
+		serve("GET", "/debug/checkpoint?handle=some-handle")
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+		Expect(actedOn).To(BeEmpty())
+	})
+})
@@ -0,0 +1,141 @@
+package gardener
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter . Flusher
+//go:generate counterfeiter . Drainer
+
+// ErrDraining is returned by Create once the server has started draining
+var ErrDraining = errors.New("server is draining and not accepting new containers")
+
+// Flusher persists in-memory state, e.g. properties, once the server has
+// drained
+type Flusher interface {
+	Flush() error
+}
+
+type FlusherFunc func() error
+
+func (fn FlusherFunc) Flush() error {
+	return fn()
+}
+
+type Drainer interface {
+	Drain(timeout time.Duration) error
+	Draining() bool
+}
+
+// operations counts the creates and destroys which are in flight, so that
+// draining can wait for them to finish
+type operations struct {
+	mu       sync.Mutex
+	draining bool
+	count    int
+	idle     []chan struct{}
+}
+
+// beginCreate refuses new creates once draining has started
+func (o *operations) beginCreate() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.draining {
+		return ErrDraining
+	}
+
+	o.count++
+	return nil
+}
+
+func (o *operations) begin() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.count++
+}
+
+func (o *operations) end() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.count--
+	if o.count == 0 {
+		for _, idle := range o.idle {
+			close(idle)
+		}
+		o.idle = nil
+	}
+}
+
+func (o *operations) isDraining() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.draining
+}
+
+// drain stops new creates and waits for the operations in flight to finish,
+// giving up after the timeout unless it is zero
+func (o *operations) drain(timeout time.Duration) error {
+	o.mu.Lock()
+	o.draining = true
+	if o.count == 0 {
+		o.mu.Unlock()
+		return nil
+	}
+
+	idle := make(chan struct{})
+	o.idle = append(o.idle, idle)
+	inFlight := o.count
+	o.mu.Unlock()
+
+	if timeout <= 0 {
+		<-idle
+		return nil
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-idle:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("timed out after %s waiting for %d operations to finish", timeout, inFlight)
+	}
+}
+
+// Drain rejects new creates, waits for in-flight creates and destroys to
+// finish and then flushes state. The state is flushed even if waiting times
+// out, since the server is about to go away.
+func (g *Gardener) Drain(timeout time.Duration) error {
+	log := g.Logger.Session("drain", lager.Data{"timeout": timeout.String()})
+
+	log.Info("start")
+	defer log.Info("finished")
+
+	err := g.operations.drain(timeout)
+	if err != nil {
+		log.Error("wait-failed", err)
+	}
+
+	for _, flusher := range g.Flushers {
+		if err := flusher.Flush(); err != nil {
+			log.Error("flush-failed", err)
+		}
+	}
+
+	return err
+}
+
+// Draining reports whether the server has started draining
+func (g *Gardener) Draining() bool {
+	return g.operations.isDraining()
+}
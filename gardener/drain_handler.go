@@ -0,0 +1,57 @@
+package gardener
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type drainHandler struct {
+	drainer Drainer
+	timeout time.Duration
+}
+
+type drainStatus struct {
+	Draining bool   `json:"draining"`
+	Error    string `json:"error,omitempty"`
+}
+
+// NewDrainHandler reports whether the server is draining, and starts draining
+// it on a POST so that its containers can be evacuated before a deploy. The
+// timeout query parameter overrides how long a POST waits for in-flight
+// operations.
+func NewDrainHandler(drainer Drainer, timeout time.Duration) http.Handler {
+	return &drainHandler{drainer: drainer, timeout: timeout}
+}
+
+func (h *drainHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+		h.respond(w, http.StatusOK, drainStatus{Draining: h.drainer.Draining()})
+	case "POST":
+		timeout := h.timeout
+		if value := r.URL.Query().Get("timeout"); value != "" {
+			var err error
+			if timeout, err = time.ParseDuration(value); err != nil {
+				http.Error(w, "invalid timeout: "+value, http.StatusBadRequest)
+				return
+			}
+		}
+
+		if err := h.drainer.Drain(timeout); err != nil {
+			h.respond(w, http.StatusGatewayTimeout, drainStatus{Draining: true, Error: err.Error()})
+			return
+		}
+
+		h.respond(w, http.StatusOK, drainStatus{Draining: true})
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (h *drainHandler) respond(w http.ResponseWriter, status int, body drainStatus) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
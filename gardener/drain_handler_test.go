@@ -0,0 +1,84 @@
+package gardener_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DrainHandler", func() {
+	var (
+		drainer  *fakes.FakeDrainer
+		handler  http.Handler
+		recorder *httptest.ResponseRecorder
+	)
+
+	BeforeEach(func() {
+		drainer = new(fakes.FakeDrainer)
+		handler = gardener.NewDrainHandler(drainer, time.Minute)
+		recorder = httptest.NewRecorder()
+	})
+
+	serve := func(method, path string) map[string]interface{} {
+		req, err := http.NewRequest(method, path, nil)
+		Expect(err).NotTo(HaveOccurred())
+		handler.ServeHTTP(recorder, req)
+
+		var body map[string]interface{}
+		json.NewDecoder(recorder.Body).Decode(&body)
+		return body
+	}
+
+	It("reports whether the server is draining on a GET", func() {
+		drainer.DrainingReturns(true)
+
+		body := serve("GET", "/debug/drain")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("draining", true))
+		Expect(drainer.DrainCallCount()).To(Equal(0))
+	})
+
+	It("drains the server on a POST", func() {
+		body := serve("POST", "/debug/drain")
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(body).To(HaveKeyWithValue("draining", true))
+
+		Expect(drainer.DrainCallCount()).To(Equal(1))
+		Expect(drainer.DrainArgsForCall(0)).To(Equal(time.Minute))
+	})
+
+	It("uses the timeout from the request", func() {
+		serve("POST", "/debug/drain?timeout=5s")
+		Expect(drainer.DrainArgsForCall(0)).To(Equal(5 * time.Second))
+	})
+
+	Context("when the timeout is invalid", func() {
+		It("responds with 400", func() {
+			serve("POST", "/debug/drain?timeout=banana")
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			Expect(drainer.DrainCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when draining times out", func() {
+		It("responds with 504 and the error", func() {
+			drainer.DrainReturns(errors.New("timed out"))
+
+			body := serve("POST", "/debug/drain")
+			Expect(recorder.Code).To(Equal(http.StatusGatewayTimeout))
+			Expect(body).To(HaveKeyWithValue("error", "timed out"))
+		})
+	})
+
+	It("rejects other methods", func() {
+		serve("DELETE", "/debug/drain")
+		Expect(recorder.Code).To(Equal(http.StatusMethodNotAllowed))
+	})
+})
@@ -0,0 +1,131 @@
+package gardener_test
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Draining", func() {
+	var (
+		containerizer   *fakes.FakeContainerizer
+		propertyManager *fakes.FakePropertyManager
+		flusher         *fakes.FakeFlusher
+		gdnr            *gardener.Gardener
+	)
+
+	BeforeEach(func() {
+		containerizer = new(fakes.FakeContainerizer)
+		propertyManager = new(fakes.FakePropertyManager)
+		flusher = new(fakes.FakeFlusher)
+
+		propertyManager.GetReturns("", true)
+		containerizer.HandlesReturns([]string{}, nil)
+
+		gdnr = &gardener.Gardener{
+			Containerizer:   containerizer,
+			UidGenerator:    new(fakes.FakeUidGenerator),
+			Networker:       new(fakes.FakeNetworker),
+			VolumeCreator:   new(fakes.FakeVolumeCreator),
+			PropertyManager: propertyManager,
+			EventPublisher:  new(fakes.FakeEventPublisher),
+			Flushers:        []gardener.Flusher{flusher},
+			Logger:          lagertest.NewTestLogger("test"),
+		}
+	})
+
+	It("is not draining to begin with", func() {
+		Expect(gdnr.Draining()).To(BeFalse())
+	})
+
+	It("rejects new creates once drained", func() {
+		Expect(gdnr.Drain(time.Second)).To(Succeed())
+		Expect(gdnr.Draining()).To(BeTrue())
+
+		_, err := gdnr.Create(garden.ContainerSpec{Handle: "too-late"})
+		Expect(err).To(Equal(gardener.ErrDraining))
+		Expect(containerizer.CreateCallCount()).To(Equal(0))
+	})
+
+	It("still allows containers to be destroyed", func() {
+		containerizer.HandlesReturns([]string{"evacuee"}, nil)
+
+		Expect(gdnr.Drain(time.Second)).To(Succeed())
+		Expect(gdnr.Destroy("evacuee")).To(Succeed())
+	})
+
+	It("flushes state", func() {
+		Expect(gdnr.Drain(time.Second)).To(Succeed())
+		Expect(flusher.FlushCallCount()).To(Equal(1))
+	})
+
+	Context("when flushing fails", func() {
+		It("still succeeds, since there is nothing more to be done", func() {
+			flusher.FlushReturns(errors.New("disk full"))
+			Expect(gdnr.Drain(time.Second)).To(Succeed())
+		})
+	})
+
+	Context("when a create is in flight", func() {
+		var (
+			unblockCreate chan struct{}
+			createErr     chan error
+		)
+
+		BeforeEach(func() {
+			unblockCreate = make(chan struct{})
+			containerizer.CreateStub = func(_ lager.Logger, _ gardener.DesiredContainerSpec) error {
+				<-unblockCreate
+				return nil
+			}
+
+			createErr = make(chan error, 1)
+			go func() {
+				defer GinkgoRecover()
+				_, err := gdnr.Create(garden.ContainerSpec{Handle: "in-flight"})
+				createErr <- err
+			}()
+
+			Eventually(containerizer.CreateCallCount).Should(Equal(1))
+		})
+
+		It("waits for it to finish before flushing", func() {
+			drained := make(chan error, 1)
+			go func() {
+				drained <- gdnr.Drain(0)
+			}()
+
+			Consistently(drained).ShouldNot(Receive())
+			Expect(flusher.FlushCallCount()).To(Equal(0))
+
+			close(unblockCreate)
+			Eventually(drained).Should(Receive(BeNil()))
+			Expect(<-createErr).NotTo(HaveOccurred())
+			Expect(flusher.FlushCallCount()).To(Equal(1))
+		})
+
+		It("gives up waiting after the timeout, but still flushes", func() {
+			Expect(gdnr.Drain(50 * time.Millisecond)).To(MatchError(ContainSubstring("timed out")))
+			Expect(flusher.FlushCallCount()).To(Equal(1))
+
+			close(unblockCreate)
+			Expect(<-createErr).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("Stop", func() {
+		It("drains the server", func() {
+			gdnr.Stop()
+
+			Expect(gdnr.Draining()).To(BeTrue())
+			Expect(flusher.FlushCallCount()).To(Equal(1))
+		})
+	})
+})
@@ -0,0 +1,92 @@
+package gardener
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/garden"
+)
+
+type eventStreamHandler struct {
+	subscriber EventSubscriber
+}
+
+// NewEventStreamHandler streams lifecycle events to clients until they
+// disconnect. Events are written as newline-delimited JSON, or as server-sent
+// events when the client accepts text/event-stream. The handle and
+// property=name=value query parameters, which may be repeated, filter the
+// stream.
+func NewEventStreamHandler(subscriber EventSubscriber) http.Handler {
+	return &eventStreamHandler{subscriber: subscriber}
+}
+
+func (h *eventStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	filter := EventFilter{Handles: params["handle"]}
+	for _, property := range params["property"] {
+		kv := strings.SplitN(property, "=", 2)
+		if len(kv) != 2 {
+			http.Error(w, "invalid property filter: "+property, http.StatusBadRequest)
+			return
+		}
+
+		if filter.Properties == nil {
+			filter.Properties = garden.Properties{}
+		}
+		filter.Properties[kv[0]] = kv[1]
+	}
+
+	sse := strings.Contains(r.Header.Get("Accept"), "text/event-stream")
+	if sse {
+		w.Header().Set("Content-Type", "text/event-stream")
+	} else {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+	}
+	w.WriteHeader(http.StatusOK)
+
+	flusher, _ := w.(http.Flusher)
+	flush := func() {
+		if flusher != nil {
+			flusher.Flush()
+		}
+	}
+	flush()
+
+	var closed <-chan bool
+	if notifier, ok := w.(http.CloseNotifier); ok {
+		closed = notifier.CloseNotify()
+	}
+
+	events, unsubscribe := h.subscriber.Subscribe(filter)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			data, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+
+			if sse {
+				_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+			} else {
+				_, err = fmt.Fprintf(w, "%s\n", data)
+			}
+			if err != nil {
+				return
+			}
+
+			flush()
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package gardener_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EventStreamHandler", func() {
+	var (
+		subscriber *fakes.FakeEventSubscriber
+		events     chan gardener.Event
+		server     *httptest.Server
+	)
+
+	BeforeEach(func() {
+		events = make(chan gardener.Event, 10)
+		subscriber = new(fakes.FakeEventSubscriber)
+		subscriber.SubscribeReturns(events, func() {})
+
+		server = httptest.NewServer(gardener.NewEventStreamHandler(subscriber))
+	})
+
+	AfterEach(func() {
+		server.CloseClientConnections()
+		server.Close()
+	})
+
+	get := func(path, accept string) *http.Response {
+		req, err := http.NewRequest("GET", server.URL+path, nil)
+		Expect(err).NotTo(HaveOccurred())
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		Expect(err).NotTo(HaveOccurred())
+		return resp
+	}
+
+	It("streams events as newline-delimited JSON", func() {
+		resp := get("/", "")
+		defer resp.Body.Close()
+		Expect(resp.Header.Get("Content-Type")).To(Equal("application/x-ndjson"))
+
+		events <- gardener.Event{Type: gardener.EventCreated, Handle: "banana"}
+		events <- gardener.Event{Type: gardener.EventDestroyed, Handle: "banana"}
+
+		reader := bufio.NewReader(resp.Body)
+		for _, expectedType := range []string{gardener.EventCreated, gardener.EventDestroyed} {
+			line, err := reader.ReadBytes('\n')
+			Expect(err).NotTo(HaveOccurred())
+
+			var event gardener.Event
+			Expect(json.Unmarshal(line, &event)).To(Succeed())
+			Expect(event.Type).To(Equal(expectedType))
+			Expect(event.Handle).To(Equal("banana"))
+		}
+	})
+
+	It("streams server-sent events when the client accepts them", func() {
+		resp := get("/", "text/event-stream")
+		defer resp.Body.Close()
+		Expect(resp.Header.Get("Content-Type")).To(Equal("text/event-stream"))
+
+		events <- gardener.Event{Type: gardener.EventOOM, Handle: "banana"}
+
+		reader := bufio.NewReader(resp.Body)
+		line, err := reader.ReadString('\n')
+		Expect(err).NotTo(HaveOccurred())
+		Expect(line).To(Equal("event: oom\n"))
+
+		line, err = reader.ReadString('\n')
+		Expect(err).NotTo(HaveOccurred())
+		Expect(line).To(HavePrefix("data: {"))
+	})
+
+	It("subscribes with the handle and property filters from the query", func() {
+		resp := get("/?handle=banana&handle=apple&property=owner=me", "")
+		defer resp.Body.Close()
+
+		Eventually(subscriber.SubscribeCallCount).Should(Equal(1))
+		Expect(subscriber.SubscribeArgsForCall(0)).To(Equal(gardener.EventFilter{
+			Handles:    []string{"banana", "apple"},
+			Properties: garden.Properties{"owner": "me"},
+		}))
+	})
+
+	It("rejects invalid property filters", func() {
+		resp := get("/?property=owner", "")
+		defer resp.Body.Close()
+
+		Expect(resp.StatusCode).To(Equal(http.StatusBadRequest))
+		Expect(subscriber.SubscribeCallCount()).To(Equal(0))
+	})
+
+	It("ends the stream when the subscription is closed", func() {
+		resp := get("/", "")
+		defer resp.Body.Close()
+
+		close(events)
+
+		reader := bufio.NewReader(resp.Body)
+		_, err := reader.ReadBytes('\n')
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,145 @@
+package gardener
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+)
+
+//go:generate counterfeiter . EventPublisher
+//go:generate counterfeiter . EventSubscriber
+
+const (
+	EventCreated        = "created"
+	EventDestroyed      = "destroyed"
+	EventStopped        = "stopped"
+	EventOOM            = "oom"
+	EventProcessStarted = "process-started"
+	EventProcessExited  = "process-exited"
+)
+
+// subscriptionBufferSize is the number of events buffered for each
+// subscriber. Events are dropped for subscribers that fall further behind so
+// that a slow client cannot block container operations.
+const subscriptionBufferSize = 256
+
+type Event struct {
+	Type       string    `json:"type"`
+	Handle     string    `json:"handle"`
+	Time       time.Time `json:"time"`
+	ProcessID  string    `json:"process_id,omitempty"`
+	ExitStatus *int      `json:"exit_status,omitempty"`
+
+	// Usage is the resources used by an exited process, if they are known
+	Usage *ResourceUsage `json:"usage,omitempty"`
+
+	// properties is the snapshot of the container's properties that
+	// subscriptions are matched against
+	properties garden.Properties
+}
+
+type EventPublisher interface {
+	Publish(event Event)
+}
+
+type EventSubscriber interface {
+	Subscribe(filter EventFilter) (events <-chan Event, unsubscribe func())
+}
+
+// EventFilter selects the events delivered to a subscriber. An empty filter
+// matches every event.
+type EventFilter struct {
+	Handles    []string
+	Properties garden.Properties
+}
+
+func (f EventFilter) Matches(event Event) bool {
+	if len(f.Handles) > 0 && !contains(f.Handles, event.Handle) {
+		return false
+	}
+
+	for name, value := range f.Properties {
+		if actual, ok := event.properties[name]; !ok || actual != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+type subscription struct {
+	filter EventFilter
+	events chan Event
+}
+
+// EventBus fans container lifecycle events out to subscribers.
+type EventBus struct {
+	propertyManager PropertyManager
+
+	mu            sync.Mutex
+	subscriptions map[*subscription]struct{}
+}
+
+func NewEventBus(propertyManager PropertyManager) *EventBus {
+	return &EventBus{
+		propertyManager: propertyManager,
+		subscriptions:   make(map[*subscription]struct{}),
+	}
+}
+
+func (b *EventBus) Publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	if event.properties == nil {
+		event.properties, _ = b.propertyManager.All(event.Handle)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscriptions {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+
+		select {
+		case sub.events <- event:
+		default:
+		}
+	}
+}
+
+func (b *EventBus) Subscribe(filter EventFilter) (<-chan Event, func()) {
+	sub := &subscription{
+		filter: filter,
+		events: make(chan Event, subscriptionBufferSize),
+	}
+
+	b.mu.Lock()
+	b.subscriptions[sub] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return sub.events, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscriptions, sub)
+			b.mu.Unlock()
+
+			close(sub.events)
+		})
+	}
+}
+
+func contains(handles []string, handle string) bool {
+	for _, h := range handles {
+		if h == handle {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,109 @@
+package gardener_test
+
+import (
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EventBus", func() {
+	var (
+		propertyManager *fakes.FakePropertyManager
+		bus             *gardener.EventBus
+	)
+
+	BeforeEach(func() {
+		propertyManager = new(fakes.FakePropertyManager)
+		propertyManager.AllStub = func(handle string) (garden.Properties, error) {
+			return garden.Properties{"owner": handle + "-owner"}, nil
+		}
+
+		bus = gardener.NewEventBus(propertyManager)
+	})
+
+	It("delivers published events to subscribers", func() {
+		events, unsubscribe := bus.Subscribe(gardener.EventFilter{})
+		defer unsubscribe()
+
+		bus.Publish(gardener.Event{Type: gardener.EventCreated, Handle: "banana"})
+
+		var event gardener.Event
+		Eventually(events).Should(Receive(&event))
+		Expect(event.Type).To(Equal(gardener.EventCreated))
+		Expect(event.Handle).To(Equal("banana"))
+		Expect(event.Time.IsZero()).To(BeFalse())
+	})
+
+	It("delivers events to every subscriber", func() {
+		first, unsubscribeFirst := bus.Subscribe(gardener.EventFilter{})
+		defer unsubscribeFirst()
+		second, unsubscribeSecond := bus.Subscribe(gardener.EventFilter{})
+		defer unsubscribeSecond()
+
+		bus.Publish(gardener.Event{Type: gardener.EventCreated, Handle: "banana"})
+
+		Eventually(first).Should(Receive())
+		Eventually(second).Should(Receive())
+	})
+
+	It("only delivers events for the subscribed handles", func() {
+		events, unsubscribe := bus.Subscribe(gardener.EventFilter{Handles: []string{"banana"}})
+		defer unsubscribe()
+
+		bus.Publish(gardener.Event{Type: gardener.EventCreated, Handle: "apple"})
+		bus.Publish(gardener.Event{Type: gardener.EventCreated, Handle: "banana"})
+
+		var event gardener.Event
+		Eventually(events).Should(Receive(&event))
+		Expect(event.Handle).To(Equal("banana"))
+		Consistently(events).ShouldNot(Receive())
+	})
+
+	It("only delivers events for containers with the subscribed properties", func() {
+		events, unsubscribe := bus.Subscribe(gardener.EventFilter{
+			Properties: garden.Properties{"owner": "banana-owner"},
+		})
+		defer unsubscribe()
+
+		bus.Publish(gardener.Event{Type: gardener.EventCreated, Handle: "apple"})
+		bus.Publish(gardener.Event{Type: gardener.EventCreated, Handle: "banana"})
+
+		var event gardener.Event
+		Eventually(events).Should(Receive(&event))
+		Expect(event.Handle).To(Equal("banana"))
+		Consistently(events).ShouldNot(Receive())
+	})
+
+	It("does not block publishers when a subscriber is not reading", func() {
+		_, unsubscribe := bus.Subscribe(gardener.EventFilter{})
+		defer unsubscribe()
+
+		done := make(chan struct{})
+		go func() {
+			for i := 0; i < 1000; i++ {
+				bus.Publish(gardener.Event{Type: gardener.EventCreated, Handle: "banana"})
+			}
+			close(done)
+		}()
+
+		Eventually(done).Should(BeClosed())
+	})
+
+	Describe("unsubscribing", func() {
+		It("closes the events channel and stops delivering events", func() {
+			events, unsubscribe := bus.Subscribe(gardener.EventFilter{})
+			unsubscribe()
+
+			bus.Publish(gardener.Event{Type: gardener.EventCreated, Handle: "banana"})
+			Eventually(events).Should(BeClosed())
+		})
+
+		It("can be called more than once", func() {
+			_, unsubscribe := bus.Subscribe(gardener.EventFilter{})
+			unsubscribe()
+			Expect(unsubscribe).NotTo(Panic())
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/garden"
@@ -16,6 +17,7 @@ import (
 //go:generate counterfeiter . Containerizer
 //go:generate counterfeiter . Networker
 //go:generate counterfeiter . VolumeCreator
+//go:generate counterfeiter . VolumeResizer
 //go:generate counterfeiter . UidGenerator
 //go:generate counterfeiter . PropertyManager
 //go:generate counterfeiter . Restorer
@@ -27,6 +29,8 @@ const BridgeIPKey = "garden.network.host-ip"
 const ExternalIPKey = "garden.network.external-ip"
 const MappedPortsKey = "garden.network.mapped-ports"
 const GraceTimeKey = "garden.grace-time"
+const DiskLimitsKey = "garden.disk-limits"
+const RuntimeKey = "garden.runtime"
 
 const RawRootFSScheme = "raw"
 
@@ -45,11 +49,19 @@ type Containerizer interface {
 	Run(log lager.Logger, handle string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error)
 	Attach(log lager.Logger, handle string, processGUID string, io garden.ProcessIO) (garden.Process, error)
 	Stop(log lager.Logger, handle string, kill bool) error
+	Pause(log lager.Logger, handle string) error
+	Resume(log lager.Logger, handle string) error
+	Checkpoint(log lager.Logger, handle string) error
+	RestoreCheckpoint(log lager.Logger, handle string) error
+	WatchEvents(log lager.Logger, handle string)
 	Destroy(log lager.Logger, handle string) error
 	RemoveBundle(log lager.Logger, handle string) error
 
 	Info(log lager.Logger, handle string) (ActualContainerSpec, error)
 	Metrics(log lager.Logger, handle string) (ActualContainerMetrics, error)
+
+	LimitMemory(log lager.Logger, handle string, limits garden.MemoryLimits) error
+	LimitCPU(log lager.Logger, handle string, limits garden.CPULimits) error
 }
 
 type Networker interface {
@@ -59,6 +71,9 @@ type Networker interface {
 	NetIn(log lager.Logger, handle string, hostPort, containerPort uint32) (uint32, uint32, error)
 	BulkNetOut(log lager.Logger, handle string, rules []garden.NetOutRule) error
 	NetOut(log lager.Logger, handle string, rule garden.NetOutRule) error
+	LimitBandwidth(log lager.Logger, handle string, limits garden.BandwidthLimits) error
+	BandwidthLimits(log lager.Logger, handle string) (garden.BandwidthLimits, error)
+	Reconfigure(log lager.Logger, handle string, pid int) error
 	Restore(log lager.Logger, handle string) error
 }
 
@@ -69,6 +84,16 @@ type VolumeCreator interface {
 	GC(log lager.Logger) error
 }
 
+// VolumeResizer is implemented by VolumeCreators which are able to change the
+// disk quota of a volume they have already created, and report the quota in
+// force.
+type VolumeResizer interface {
+	Resize(log lager.Logger, handle string, namespaced bool, limits garden.DiskLimits) error
+	DiskLimits(log lager.Logger, handle string, namespaced bool) (garden.DiskLimits, error)
+}
+
+var ErrDiskResizeNotSupported = errors.New("disk limits cannot be changed by the volume creator")
+
 type UidGenerator interface {
 	Generate() string
 }
@@ -78,7 +103,7 @@ type PropertyManager interface {
 	Set(handle string, name string, value string)
 	Remove(handle string, name string) error
 	Get(handle string, name string) (string, bool)
-	MatchesAll(handle string, props garden.Properties) bool
+	Filter(handles []string, props garden.Properties) ([]string, error)
 	DestroyKeySpace(string) error
 }
 
@@ -118,6 +143,9 @@ type DesiredContainerSpec struct {
 	Limits garden.Limits
 
 	Env []string
+
+	// Name of the OCI runtime to run the container with, empty for the default
+	Runtime string
 }
 
 type ActualContainerSpec struct {
@@ -133,6 +161,9 @@ type ActualContainerSpec struct {
 	// Whether the container is stopped
 	Stopped bool
 
+	// Whether the container's processes are frozen
+	Paused bool
+
 	// Process IDs (not PIDs) of processes in the container
 	ProcessIDs []string
 
@@ -146,6 +177,43 @@ type ActualContainerSpec struct {
 	Privileged bool
 }
 
+// ProcessInfo describes a process which has been run in a container
+type ProcessInfo struct {
+	// Process ID (not PID) of the process
+	ID string `json:"id"`
+
+	Args []string `json:"args"`
+	User string   `json:"user"`
+	TTY  bool     `json:"tty"`
+
+	StartedAt time.Time `json:"started_at"`
+
+	// The PID of the process on the host, or 0 if it is not known yet
+	Pid int `json:"pid"`
+
+	// The exit status of the process, or nil while it is running
+	ExitStatus *int `json:"exit_status,omitempty"`
+
+	// The resources the process used, or nil until it has exited
+	Usage *ResourceUsage `json:"usage,omitempty"`
+}
+
+// ResourceUsage is what a process, and any children it waited for, used over
+// its lifetime
+type ResourceUsage struct {
+	MaxRSSBytes int64         `json:"max_rss_bytes"`
+	UserCPU     time.Duration `json:"user_cpu_ns"`
+	SystemCPU   time.Duration `json:"system_cpu_ns"`
+	WallClock   time.Duration `json:"wall_clock_ns"`
+}
+
+// OutputOffsets are the byte offsets into a process's logged output to
+// replay from when attaching
+type OutputOffsets struct {
+	Stdout int64 `json:"stdout"`
+	Stderr int64 `json:"stderr"`
+}
+
 type ActualContainerMetrics struct {
 	CPU    garden.ContainerCPUStat
 	Memory garden.ContainerMemoryStat
@@ -180,20 +248,63 @@ type Gardener struct {
 	MaxContainers uint64
 
 	Restorer Restorer
+
+	// EventPublisher publishes container lifecycle events
+	EventPublisher EventPublisher
+
+	// ProcessUsageEmitter, if set, is sent the resources used by each
+	// process once it exits
+	ProcessUsageEmitter ProcessUsageEmitter
+
+	// BulkParallelism limits how many containers are queried at once by
+	// BulkInfo and BulkMetrics, values below 1 query them one at a time
+	BulkParallelism int
+
+	// BulkHandleTimeout is how long BulkInfo and BulkMetrics wait for each
+	// container before reporting an error for it, zero waits indefinitely
+	BulkHandleTimeout time.Duration
+
+	// HealthChecker checks the server's dependencies when it is pinged
+	HealthChecker HealthChecker
+
+	// DrainTimeout bounds how long Stop waits for in-flight creates and
+	// destroys, zero waits indefinitely
+	DrainTimeout time.Duration
+
+	// Flushers persist in-memory state once the server has drained
+	Flushers []Flusher
+
+	reservations handleReservations
+	operations   operations
+	bulkBusy     busyHandles
+	exitWatchers exitWatchers
+	stateLocks   handleLocks
 }
 
 // Create creates a container by combining the results of networker.Network,
 // volumizer.Create and containzer.Create.
 func (g *Gardener) Create(spec garden.ContainerSpec) (ctr garden.Container, err error) {
-	if err := g.checkDuplicateHandle(spec.Handle); err != nil {
+	if err := g.operations.beginCreate(); err != nil {
 		return nil, err
 	}
+	defer g.operations.end()
 
 	if spec.Handle == "" {
 		spec.Handle = g.UidGenerator.Generate()
 	}
 
+	if err := g.reservations.reserve(spec.Handle); err != nil {
+		return nil, err
+	}
+
+	if err := g.checkDuplicateHandle(spec.Handle); err != nil {
+		g.reservations.release(spec.Handle)
+		return nil, err
+	}
+
 	log := g.Logger.Session("create", lager.Data{"handle": spec.Handle})
+	states := g.states()
+	states.begin(spec.Handle)
 
 	log.Info("start")
 	defer func() {
@@ -207,8 +318,11 @@ func (g *Gardener) Create(spec garden.ContainerSpec) (ctr garden.Container, err
 			err := g.destroy(log, spec.Handle)
 			if err != nil {
 				log.Error("destroy-failed", err)
+				states.fail(spec.Handle)
 			}
 
+			g.reservations.release(spec.Handle)
+
 			log.Info("cleanedup")
 		} else {
 			log.Info("created")
@@ -259,6 +373,7 @@ func (g *Gardener) Create(spec garden.ContainerSpec) (ctr garden.Container, err
 		BindMounts: spec.BindMounts,
 		Limits:     spec.Limits,
 		Env:        append(env, spec.Env...),
+		Runtime:    spec.Properties[RuntimeKey],
 	}); err != nil {
 		return nil, err
 	}
@@ -277,6 +392,12 @@ func (g *Gardener) Create(spec garden.ContainerSpec) (ctr garden.Container, err
 		return nil, err
 	}
 
+	if rootFSURL.Scheme != RawRootFSScheme && spec.Limits.Disk.ByteHard > 0 {
+		if err := saveDiskLimits(g.PropertyManager, spec.Handle, spec.Limits.Disk); err != nil {
+			return nil, err
+		}
+	}
+
 	if spec.GraceTime != 0 {
 		if err := container.SetGraceTime(spec.GraceTime); err != nil {
 			return nil, err
@@ -289,10 +410,12 @@ func (g *Gardener) Create(spec garden.ContainerSpec) (ctr garden.Container, err
 		}
 	}
 
-	if err := container.SetProperty("garden.state", "created"); err != nil {
+	if err := states.transition(spec.Handle, StateCreated); err != nil {
 		return nil, err
 	}
 
+	g.EventPublisher.Publish(Event{Type: EventCreated, Handle: spec.Handle})
+
 	return container, nil
 }
 
@@ -308,10 +431,21 @@ func (g *Gardener) lookup(handle string) garden.Container {
 		volumeCreator:   g.VolumeCreator,
 		networker:       g.Networker,
 		propertyManager: g.PropertyManager,
+		eventPublisher:  g.EventPublisher,
+		usageEmitter:    g.ProcessUsageEmitter,
+		exitWatchers:    &g.exitWatchers,
+		states:          g.states(),
 	}
 }
 
+func (g *Gardener) states() lifecycle {
+	return lifecycle{propertyManager: g.PropertyManager, locks: &g.stateLocks}
+}
+
 func (g *Gardener) Destroy(handle string) error {
+	g.operations.begin()
+	defer g.operations.end()
+
 	log := g.Logger.Session("destroy", lager.Data{"handle": handle})
 
 	log.Info("start")
@@ -326,7 +460,23 @@ func (g *Gardener) Destroy(handle string) error {
 		return garden.ContainerNotFoundError{Handle: handle}
 	}
 
-	return g.destroy(log, handle)
+	// the properties are gone once the container is destroyed, so keep them
+	// for matching the event against subscriptions
+	properties, _ := g.PropertyManager.All(handle)
+
+	states := g.states()
+	if err := states.transition(handle, StateDestroying); err != nil {
+		return err
+	}
+
+	if err := g.destroy(log, handle); err != nil {
+		states.fail(handle)
+		return err
+	}
+
+	g.reservations.release(handle)
+	g.EventPublisher.Publish(Event{Type: EventDestroyed, Handle: handle, properties: properties})
+	return nil
 }
 
 // destroy idempotently destroys any resources associated with the given handle
@@ -347,10 +497,104 @@ func (g *Gardener) destroy(log lager.Logger, handle string) error {
 		return err
 	}
 
+	g.exitWatchers.forget(handle)
 	return g.Containerizer.RemoveBundle(g.Logger, handle)
 }
 
-func (g *Gardener) Stop() {}
+// Pause freezes all of the processes in the container with the given handle
+func (g *Gardener) Pause(handle string) error {
+	log := g.Logger.Session("pause", lager.Data{"handle": handle})
+
+	log.Info("start")
+	defer log.Info("finished")
+
+	handles, err := g.Containerizer.Handles()
+	if err != nil {
+		return err
+	}
+
+	if !g.exists(handles, handle) {
+		return garden.ContainerNotFoundError{Handle: handle}
+	}
+
+	return g.Containerizer.Pause(log, handle)
+}
+
+// Resume thaws all of the processes in the paused container with the given handle
+func (g *Gardener) Resume(handle string) error {
+	log := g.Logger.Session("resume", lager.Data{"handle": handle})
+
+	log.Info("start")
+	defer log.Info("finished")
+
+	handles, err := g.Containerizer.Handles()
+	if err != nil {
+		return err
+	}
+
+	if !g.exists(handles, handle) {
+		return garden.ContainerNotFoundError{Handle: handle}
+	}
+
+	return g.Containerizer.Resume(log, handle)
+}
+
+// Checkpoint saves the state of the container with the given handle so that it
+// can later be restored on this host, which still has its depot entry and
+// properties. The container is stopped.
+func (g *Gardener) Checkpoint(handle string) error {
+	log := g.Logger.Session("checkpoint", lager.Data{"handle": handle})
+
+	log.Info("start")
+	defer log.Info("finished")
+
+	handles, err := g.Containerizer.Handles()
+	if err != nil {
+		return err
+	}
+
+	if !g.exists(handles, handle) {
+		return garden.ContainerNotFoundError{Handle: handle}
+	}
+
+	return g.Containerizer.Checkpoint(log, handle)
+}
+
+// RestoreCheckpoint restores the container with the given handle from its
+// last checkpoint and reconfigures its network
+func (g *Gardener) RestoreCheckpoint(handle string) error {
+	log := g.Logger.Session("restore-checkpoint", lager.Data{"handle": handle})
+
+	log.Info("start")
+	defer log.Info("finished")
+
+	handles, err := g.Containerizer.Handles()
+	if err != nil {
+		return err
+	}
+
+	if !g.exists(handles, handle) {
+		return garden.ContainerNotFoundError{Handle: handle}
+	}
+
+	if err := g.Containerizer.RestoreCheckpoint(log, handle); err != nil {
+		return err
+	}
+
+	actualSpec, err := g.Containerizer.Info(log, handle)
+	if err != nil {
+		return err
+	}
+
+	return g.Networker.Reconfigure(log, handle, actualSpec.Pid)
+}
+
+// Stop drains the server, see Drain. The garden server calls it once it has
+// stopped accepting connections, which makes it the only place that the
+// server drains on shutdown.
+func (g *Gardener) Stop() {
+	g.Drain(g.DrainTimeout)
+}
 
 func (g *Gardener) GraceTime(container garden.Container) time.Duration {
 	property, ok := g.PropertyManager.Get(container.Handle(), GraceTimeKey)
@@ -367,7 +611,15 @@ func (g *Gardener) GraceTime(container garden.Container) time.Duration {
 	return graceTime
 }
 
-func (g *Gardener) Ping() error { return nil }
+// Ping reports whether the server is healthy, according to the HealthChecker
+// if one is configured
+func (g *Gardener) Ping() error {
+	if g.HealthChecker == nil {
+		return nil
+	}
+
+	return g.HealthChecker.Check()
+}
 
 func (g *Gardener) Capacity() (garden.Capacity, error) {
 	mem, err := g.SysInfoProvider.TotalMemory()
@@ -404,54 +656,79 @@ func (g *Gardener) Containers(props garden.Properties) ([]garden.Container, erro
 		return []garden.Container{}, err
 	}
 
-	if props == nil {
-		props = garden.Properties{}
+	// containers still being created may not be in the depot yet
+	for _, handle := range g.reservations.list() {
+		if !g.exists(handles, handle) {
+			handles = append(handles, handle)
+		}
+	}
+
+	filter := garden.Properties{}
+	for name, value := range props {
+		filter[name] = value
+	}
+
+	// only fully created containers are listed unless other states are asked
+	// for, either as a query on the state or as a comma-separated list
+	if !hasQueryOn(filter, StateKey) {
+		filter[StateKey] = StateCreated
+	}
+	if states, ok := filter[StateKey]; ok && strings.Contains(states, ",") {
+		delete(filter, StateKey)
+		filter[StateKey+"[in]"] = states
+	}
+
+	matching, err := g.PropertyManager.Filter(handles, filter)
+	if err != nil {
+		log.Error("filter-failed", err)
+		return []garden.Container{}, err
 	}
-	props["garden.state"] = "created"
 
 	var containers []garden.Container
-	for _, handle := range handles {
-		matched := g.PropertyManager.MatchesAll(handle, props)
-		if matched {
-			containers = append(containers, g.lookup(handle))
-		}
+	for _, handle := range matching {
+		containers = append(containers, g.lookup(handle))
 	}
 
 	return containers, nil
 }
 
 func (g *Gardener) BulkInfo(handles []string) (map[string]garden.ContainerInfoEntry, error) {
-	result := make(map[string]garden.ContainerInfoEntry)
-	for _, handle := range handles {
-		container := g.lookup(handle)
+	results := g.bulk(handles, func(handle string) (interface{}, error) {
+		return g.lookup(handle).Info()
+	})
 
-		var infoErr *garden.Error = nil
-		info, err := container.Info()
-		if err != nil {
-			infoErr = garden.NewError(err.Error())
+	result := make(map[string]garden.ContainerInfoEntry)
+	for handle, r := range results {
+		entry := garden.ContainerInfoEntry{}
+		if r.err != nil {
+			entry.Err = garden.NewError(r.err.Error())
 		}
-		result[handle] = garden.ContainerInfoEntry{
-			Info: info,
-			Err:  infoErr,
+		if info, ok := r.value.(garden.ContainerInfo); ok {
+			entry.Info = info
 		}
+
+		result[handle] = entry
 	}
 
 	return result, nil
 }
 
 func (g *Gardener) BulkMetrics(handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	results := g.bulk(handles, func(handle string) (interface{}, error) {
+		return g.lookup(handle).Metrics()
+	})
+
 	result := make(map[string]garden.ContainerMetricsEntry)
-	for _, handle := range handles {
-		var e *garden.Error
-		m, err := g.lookup(handle).Metrics()
-		if err != nil {
-			e = garden.NewError(err.Error())
+	for handle, r := range results {
+		entry := garden.ContainerMetricsEntry{}
+		if r.err != nil {
+			entry.Err = garden.NewError(r.err.Error())
 		}
-
-		result[handle] = garden.ContainerMetricsEntry{
-			Err:     e,
-			Metrics: m,
+		if metrics, ok := r.value.(garden.Metrics); ok {
+			entry.Metrics = metrics
 		}
+
+		result[handle] = entry
 	}
 
 	return result, nil
@@ -480,6 +757,18 @@ func (g *Gardener) exists(handles []string, handle string) bool {
 	return false
 }
 
+// hasQueryOn reports whether the filter queries the property, with or without
+// an operator such as "name[in]"
+func hasQueryOn(filter garden.Properties, name string) bool {
+	for key := range filter {
+		if key == name || strings.HasPrefix(key, name+"[") {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (g *Gardener) Start() error {
 	log := g.Logger.Session("start")
 
@@ -495,7 +784,10 @@ func (g *Gardener) Start() error {
 		return err
 	}
 
+	destroyed := map[string]bool{}
 	for _, handle := range g.Restorer.Restore(log, handles) {
+		destroyed[handle] = true
+
 		destroyLog := log.Session("clean-up-container", lager.Data{"handle": handle})
 		destroyLog.Info("start")
 
@@ -507,5 +799,35 @@ func (g *Gardener) Start() error {
 		destroyLog.Info("cleaned-up")
 	}
 
+	states := g.states()
+	for _, handle := range handles {
+		if destroyed[handle] {
+			continue
+		}
+
+		if states.failInterrupted(handle) {
+			log.Info("failed-interrupted-create", lager.Data{"handle": handle})
+		}
+
+		g.watchExits(log, handle)
+	}
+
 	return nil
 }
+
+// watchExits watches the processes which were running in a container before
+// a restart for their exits, since no client may ever attach to them again
+func (g *Gardener) watchExits(log lager.Logger, handle string) {
+	info, err := g.Containerizer.Info(log, handle)
+	if err != nil {
+		log.Error("watch-exits-failed", err, lager.Data{"handle": handle})
+		return
+	}
+
+	container := g.lookup(handle)
+	for _, processID := range info.ProcessIDs {
+		if _, err := container.Attach(processID, garden.ProcessIO{}); err != nil {
+			log.Error("watch-exit-failed", err, lager.Data{"handle": handle, "process-id": processID})
+		}
+	}
+}
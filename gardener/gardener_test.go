@@ -1,13 +1,17 @@
 package gardener_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden-shed/rootfs_provider"
+	"code.cloudfoundry.org/garden/gardenfakes"
 	"code.cloudfoundry.org/guardian/gardener"
 	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
 	"code.cloudfoundry.org/lager"
@@ -27,6 +31,8 @@ var _ = Describe("Gardener", func() {
 		sysinfoProvider *fakes.FakeSysInfoProvider
 		propertyManager *fakes.FakePropertyManager
 		restorer        *fakes.FakeRestorer
+		eventPublisher  *fakes.FakeEventPublisher
+		usageEmitter    *fakes.FakeProcessUsageEmitter
 
 		logger lager.Logger
 
@@ -43,6 +49,8 @@ var _ = Describe("Gardener", func() {
 		sysinfoProvider = new(fakes.FakeSysInfoProvider)
 		propertyManager = new(fakes.FakePropertyManager)
 		restorer = new(fakes.FakeRestorer)
+		eventPublisher = new(fakes.FakeEventPublisher)
+		usageEmitter = new(fakes.FakeProcessUsageEmitter)
 
 		propertyManager.GetReturns("", true)
 		containerizer.HandlesReturns([]string{"some-handle"}, nil)
@@ -58,6 +66,9 @@ var _ = Describe("Gardener", func() {
 			Logger:          logger,
 			PropertyManager: propertyManager,
 			Restorer:        restorer,
+			EventPublisher:  eventPublisher,
+
+			ProcessUsageEmitter: usageEmitter,
 		}
 	})
 
@@ -261,6 +272,17 @@ var _ = Describe("Gardener", func() {
 			Expect(spec.Privileged).To(BeTrue())
 		})
 
+		It("passes the runtime property to the containerizer", func() {
+			_, err := gdnr.Create(garden.ContainerSpec{
+				Handle:     "bob",
+				Properties: garden.Properties{gardener.RuntimeKey: "crun"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, spec := containerizer.CreateArgsForCall(0)
+			Expect(spec.Runtime).To(Equal("crun"))
+		})
+
 		It("sets the handle as the container hostname", func() {
 			_, err := gdnr.Create(garden.ContainerSpec{Handle: "bob"})
 			Expect(err).NotTo(HaveOccurred())
@@ -307,6 +329,39 @@ var _ = Describe("Gardener", func() {
 				Expect(rpSpec.QuotaSize).To(BeEquivalentTo(spec.Limits.Disk.ByteHard))
 				Expect(rpSpec.QuotaScope).To(Equal(garden.DiskLimitScopeTotal))
 			})
+
+			It("records the limit in force via the property manager", func() {
+				spec.Limits.Disk.InodeHard = 42
+				_, err := gdnr.Create(spec)
+				Expect(err).NotTo(HaveOccurred())
+
+				var diskLimits string
+				for i := 0; i < propertyManager.SetCallCount(); i++ {
+					_, name, value := propertyManager.SetArgsForCall(i)
+					if name == gardener.DiskLimitsKey {
+						diskLimits = value
+					}
+				}
+				var limits garden.DiskLimits
+				Expect(json.Unmarshal([]byte(diskLimits), &limits)).To(Succeed())
+				Expect(limits).To(Equal(garden.DiskLimits{
+					ByteHard: 10 * 1024 * 1024,
+					Scope:    garden.DiskLimitScopeTotal,
+				}))
+			})
+
+			Context("and the rootfs is a raw rootfs", func() {
+				It("does not record the limit", func() {
+					spec.RootFSPath = "raw:///banana"
+					_, err := gdnr.Create(spec)
+					Expect(err).NotTo(HaveOccurred())
+
+					for i := 0; i < propertyManager.SetCallCount(); i++ {
+						_, name, _ := propertyManager.SetArgsForCall(i)
+						Expect(name).NotTo(Equal(gardener.DiskLimitsKey))
+					}
+				})
+			})
 		})
 
 		Context("when a pid limit is provided", func() {
@@ -369,7 +424,7 @@ var _ = Describe("Gardener", func() {
 				})
 				Expect(err).NotTo(HaveOccurred())
 
-				handle, name, value := propertyManager.SetArgsForCall(0)
+				handle, name, value := propertyManager.SetArgsForCall(1)
 				Expect(handle).To(Equal("something"))
 				Expect(name).To(Equal(gardener.GraceTimeKey))
 				Expect(value).To(Equal(fmt.Sprintf("%d", time.Minute)))
@@ -460,6 +515,67 @@ var _ = Describe("Gardener", func() {
 			})
 		})
 
+		Context("when another create with the same handle is in progress", func() {
+			var unblockCreate chan struct{}
+
+			BeforeEach(func() {
+				unblockCreate = make(chan struct{})
+				containerizer.CreateStub = func(_ lager.Logger, _ gardener.DesiredContainerSpec) error {
+					<-unblockCreate
+					return nil
+				}
+			})
+
+			It("returns a useful error without touching the winner's resources", func() {
+				winnerDone := make(chan error)
+				go func() {
+					defer GinkgoRecover()
+					_, err := gdnr.Create(garden.ContainerSpec{Handle: "racy-banana"})
+					winnerDone <- err
+				}()
+
+				Eventually(containerizer.CreateCallCount).Should(Equal(1))
+
+				_, err := gdnr.Create(garden.ContainerSpec{Handle: "racy-banana"})
+				Expect(err).To(MatchError("Handle 'racy-banana' already in use"))
+
+				close(unblockCreate)
+				Expect(<-winnerDone).To(Succeed())
+
+				Expect(containerizer.CreateCallCount()).To(Equal(1))
+				Expect(containerizer.DestroyCallCount()).To(Equal(0))
+				Expect(networker.DestroyCallCount()).To(Equal(0))
+				Expect(volumeCreator.DestroyCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when a create fails", func() {
+			It("releases the handle so that it can be used again", func() {
+				containerizer.CreateReturns(errors.New("banana"))
+				_, err := gdnr.Create(garden.ContainerSpec{Handle: "retried-banana"})
+				Expect(err).To(MatchError("banana"))
+
+				containerizer.CreateReturns(nil)
+				_, err = gdnr.Create(garden.ContainerSpec{Handle: "retried-banana"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		Context("when the container has been destroyed", func() {
+			It("releases the handle so that it can be used again", func() {
+				containerizer.HandlesReturns([]string{}, nil)
+				_, err := gdnr.Create(garden.ContainerSpec{Handle: "reused-banana"})
+				Expect(err).NotTo(HaveOccurred())
+
+				containerizer.HandlesReturns([]string{"reused-banana"}, nil)
+				Expect(gdnr.Destroy("reused-banana")).To(Succeed())
+
+				containerizer.HandlesReturns([]string{}, nil)
+				_, err = gdnr.Create(garden.ContainerSpec{Handle: "reused-banana"})
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
 		Context("when properties are specified", func() {
 			var startingProperties garden.Properties
 
@@ -478,7 +594,7 @@ var _ = Describe("Gardener", func() {
 				Expect(err).NotTo(HaveOccurred())
 
 				var allProps = make(map[string]string)
-				for i := 0; i < 2; i++ {
+				for i := 1; i < 3; i++ {
 					handle, name, value := propertyManager.SetArgsForCall(i)
 					Expect(handle).To(Equal("something"))
 					allProps[name] = value
@@ -491,19 +607,59 @@ var _ = Describe("Gardener", func() {
 			})
 		})
 
-		It("sets the container state to created", func() {
+		It("sets the container state to creating and then to created", func() {
 			_, err := gdnr.Create(garden.ContainerSpec{
 				Handle: "something",
 			})
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(propertyManager.SetCallCount()).To(Equal(1))
+			Expect(propertyManager.SetCallCount()).To(Equal(2))
 			handle, name, value := propertyManager.SetArgsForCall(0)
 			Expect(handle).To(Equal("something"))
 			Expect(name).To(Equal("garden.state"))
+			Expect(value).To(Equal("creating"))
+
+			handle, name, value = propertyManager.SetArgsForCall(1)
+			Expect(handle).To(Equal("something"))
+			Expect(name).To(Equal("garden.state"))
 			Expect(value).To(Equal("created"))
 		})
 
+		Context("when the create fails and the cleanup fails too", func() {
+			It("sets the container state to failed", func() {
+				containerizer.CreateReturns(errors.New("banana"))
+				containerizer.DestroyReturns(errors.New("cleanup-failed"))
+
+				_, err := gdnr.Create(garden.ContainerSpec{Handle: "something"})
+				Expect(err).To(MatchError("banana"))
+
+				handle, name, value := propertyManager.SetArgsForCall(propertyManager.SetCallCount() - 1)
+				Expect(handle).To(Equal("something"))
+				Expect(name).To(Equal(gardener.StateKey))
+				Expect(value).To(Equal(gardener.StateFailed))
+			})
+		})
+
+		It("publishes a created event", func() {
+			_, err := gdnr.Create(garden.ContainerSpec{
+				Handle: "something",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(eventPublisher.PublishCallCount()).To(Equal(1))
+			Expect(eventPublisher.PublishArgsForCall(0)).To(Equal(gardener.Event{Type: gardener.EventCreated, Handle: "something"}))
+		})
+
+		It("does not publish a created event when creating fails", func() {
+			containerizer.CreateReturns(errors.New("create-error"))
+
+			_, err := gdnr.Create(garden.ContainerSpec{
+				Handle: "something",
+			})
+			Expect(err).To(HaveOccurred())
+			Expect(eventPublisher.PublishCallCount()).To(Equal(0))
+		})
+
 		Context("when bind mounts are specified", func() {
 			It("generates a proper mount spec", func() {
 				bindMounts := []garden.BindMount{
@@ -544,6 +700,14 @@ var _ = Describe("Gardener", func() {
 		})
 
 		Describe("running a process in a container", func() {
+			var process *gardenfakes.FakeProcess
+
+			BeforeEach(func() {
+				process = new(gardenfakes.FakeProcess)
+				process.IDReturns("some-process")
+				containerizer.RunReturns(process, nil)
+			})
+
 			It("asks the containerizer to run the process", func() {
 				origSpec := garden.ProcessSpec{Path: "ripe"}
 				origIO := garden.ProcessIO{
@@ -568,6 +732,97 @@ var _ = Describe("Gardener", func() {
 					_, err := container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
 					Expect(err).To(MatchError("lost my banana"))
 				})
+
+				It("does not publish any events", func() {
+					container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
+					Expect(eventPublisher.PublishCallCount()).To(Equal(0))
+				})
+			})
+
+			It("publishes a process-started event", func() {
+				_, err := container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
+				Expect(err).NotTo(HaveOccurred())
+
+				event := eventPublisher.PublishArgsForCall(0)
+				Expect(event.Type).To(Equal(gardener.EventProcessStarted))
+				Expect(event.Handle).To(Equal("banana"))
+				Expect(event.ProcessID).To(Equal("some-process"))
+			})
+
+			Context("when the process can be watched for its exit", func() {
+				var exitWatcher *fakes.FakeExitWatcher
+
+				BeforeEach(func() {
+					exitWatcher = new(fakes.FakeExitWatcher)
+					exitWatcher.WaitForExitReturns(42, &gardener.ResourceUsage{MaxRSSBytes: 2048}, nil)
+					containerizer.RunReturns(watchableProcess{process, exitWatcher}, nil)
+				})
+
+				It("publishes a process-exited event with the exit status and resource usage", func() {
+					_, err := container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(eventPublisher.PublishCallCount).Should(Equal(2))
+					event := eventPublisher.PublishArgsForCall(1)
+					Expect(event.Type).To(Equal(gardener.EventProcessExited))
+					Expect(event.ProcessID).To(Equal("some-process"))
+					Expect(*event.ExitStatus).To(Equal(42))
+					Expect(event.Usage).To(Equal(&gardener.ResourceUsage{MaxRSSBytes: 2048}))
+				})
+
+				It("emits the resource usage once the process exits", func() {
+					_, err := container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(usageEmitter.EmitProcessUsageCallCount).Should(Equal(1))
+					Expect(usageEmitter.EmitProcessUsageArgsForCall(0)).To(Equal(gardener.ResourceUsage{MaxRSSBytes: 2048}))
+				})
+
+				It("emits the resource usage once however many times the process is attached to", func() {
+					containerizer.AttachReturns(watchableProcess{process, exitWatcher}, nil)
+					_, err := container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+					_, err = container.Attach("some-process", garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(usageEmitter.EmitProcessUsageCallCount).Should(Equal(1))
+					Consistently(usageEmitter.EmitProcessUsageCallCount).Should(Equal(1))
+				})
+
+				Context("when the resource usage was not recorded", func() {
+					BeforeEach(func() {
+						exitWatcher.WaitForExitReturns(42, nil, nil)
+					})
+
+					It("does not emit any", func() {
+						_, err := container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(eventPublisher.PublishCallCount).Should(Equal(2))
+						Consistently(usageEmitter.EmitProcessUsageCallCount).Should(Equal(0))
+					})
+				})
+
+				It("does not wait for the process, which would clean it up", func() {
+					_, err := container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(eventPublisher.PublishCallCount).Should(Equal(2))
+					Expect(process.WaitCallCount()).To(Equal(0))
+				})
+
+				Context("when watching for the exit fails", func() {
+					BeforeEach(func() {
+						exitWatcher.WaitForExitReturns(1, nil, errors.New("no exit code"))
+					})
+
+					It("does not publish an exit", func() {
+						_, err := container.Run(garden.ProcessSpec{}, garden.ProcessIO{})
+						Expect(err).NotTo(HaveOccurred())
+
+						Consistently(eventPublisher.PublishCallCount).Should(Equal(1))
+					})
+				})
 			})
 		})
 
@@ -596,6 +851,80 @@ var _ = Describe("Gardener", func() {
 					Expect(err).To(MatchError("lost my banana"))
 				})
 			})
+
+			Context("when the process can be watched for its exit", func() {
+				var (
+					process     *gardenfakes.FakeProcess
+					exitWatcher *fakes.FakeExitWatcher
+				)
+
+				BeforeEach(func() {
+					process = new(gardenfakes.FakeProcess)
+					process.IDReturns("123")
+					exitWatcher = new(fakes.FakeExitWatcher)
+					exitWatcher.WaitForExitReturns(42, nil, nil)
+					containerizer.AttachReturns(watchableProcess{process, exitWatcher}, nil)
+				})
+
+				It("publishes a process-exited event with the exit status", func() {
+					_, err := container.Attach("123", garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(eventPublisher.PublishCallCount).Should(Equal(1))
+					event := eventPublisher.PublishArgsForCall(0)
+					Expect(event.Type).To(Equal(gardener.EventProcessExited))
+					Expect(event.Handle).To(Equal("banana"))
+					Expect(event.ProcessID).To(Equal("123"))
+					Expect(*event.ExitStatus).To(Equal(42))
+				})
+
+				It("publishes the exit once however many times the process is attached to", func() {
+					_, err := container.Attach("123", garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+					_, err = container.Attach("123", garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(eventPublisher.PublishCallCount).Should(Equal(1))
+					Consistently(eventPublisher.PublishCallCount).Should(Equal(1))
+				})
+
+				It("does not publish the exit of a process it runs again when it is attached to", func() {
+					containerizer.RunReturns(watchableProcess{process, exitWatcher}, nil)
+					_, err := container.Run(garden.ProcessSpec{ID: "123"}, garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+					_, err = container.Attach("123", garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(eventPublisher.PublishCallCount).Should(Equal(2))
+					Consistently(eventPublisher.PublishCallCount).Should(Equal(2))
+					Expect(eventPublisher.PublishArgsForCall(0).Type).To(Equal(gardener.EventProcessStarted))
+					Expect(eventPublisher.PublishArgsForCall(1).Type).To(Equal(gardener.EventProcessExited))
+				})
+
+				Context("when watching for the exit fails", func() {
+					BeforeEach(func() {
+						var watches int32
+						exitWatcher.WaitForExitStub = func() (int, *gardener.ResourceUsage, error) {
+							if atomic.AddInt32(&watches, 1) == 1 {
+								return 1, nil, errors.New("no exit code")
+							}
+
+							return 42, nil, nil
+						}
+					})
+
+					It("watches for it again the next time the process is attached to", func() {
+						_, err := container.Attach("123", garden.ProcessIO{})
+						Expect(err).NotTo(HaveOccurred())
+						Eventually(exitWatcher.WaitForExitCallCount).Should(Equal(1))
+
+						Eventually(func() int {
+							container.Attach("123", garden.ProcessIO{})
+							return eventPublisher.PublishCallCount()
+						}).Should(Equal(1))
+					})
+				})
+			})
 		})
 
 		Describe("streaming files in to the container", func() {
@@ -754,6 +1083,71 @@ var _ = Describe("Gardener", func() {
 			Expect(handle).To(Equal("container2"))
 		})
 
+		Context("when a restored container was being created", func() {
+			BeforeEach(func() {
+				propertyManager.GetStub = func(handle, _ string) (string, bool) {
+					if handle == "container1" {
+						return gardener.StateCreating, true
+					}
+
+					return gardener.StateCreated, true
+				}
+			})
+
+			It("fails it, so that it can be destroyed", func() {
+				Expect(gdnr.Start()).To(Succeed())
+
+				Expect(propertyManager.SetCallCount()).To(Equal(1))
+				handle, name, value := propertyManager.SetArgsForCall(0)
+				Expect(handle).To(Equal("container1"))
+				Expect(name).To(Equal(gardener.StateKey))
+				Expect(value).To(Equal(gardener.StateFailed))
+			})
+		})
+
+		Context("when the restored containers have running processes", func() {
+			var exitWatcher *fakes.FakeExitWatcher
+
+			BeforeEach(func() {
+				containerizer.InfoReturns(gardener.ActualContainerSpec{ProcessIDs: []string{"some-process"}}, nil)
+
+				process := new(gardenfakes.FakeProcess)
+				process.IDReturns("some-process")
+				exitWatcher = new(fakes.FakeExitWatcher)
+				exitWatcher.WaitForExitReturns(42, &gardener.ResourceUsage{MaxRSSBytes: 2048}, nil)
+				containerizer.AttachReturns(watchableProcess{process, exitWatcher}, nil)
+			})
+
+			It("attaches to them without streaming their output", func() {
+				Expect(gdnr.Start()).To(Succeed())
+
+				Expect(containerizer.AttachCallCount()).To(Equal(2))
+				_, handle, processID, io := containerizer.AttachArgsForCall(0)
+				Expect(handle).To(Equal("container1"))
+				Expect(processID).To(Equal("some-process"))
+				Expect(io).To(Equal(garden.ProcessIO{}))
+			})
+
+			It("publishes their exits and emits their resource usage", func() {
+				Expect(gdnr.Start()).To(Succeed())
+
+				Eventually(eventPublisher.PublishCallCount).Should(Equal(2))
+				event := eventPublisher.PublishArgsForCall(0)
+				Expect(event.Type).To(Equal(gardener.EventProcessExited))
+				Expect(event.ProcessID).To(Equal("some-process"))
+				Eventually(usageEmitter.EmitProcessUsageCallCount).Should(Equal(2))
+			})
+
+			It("does not attach to the processes of containers which could not be restored", func() {
+				restorer.RestoreReturns([]string{"container2"})
+				Expect(gdnr.Start()).To(Succeed())
+
+				Expect(containerizer.AttachCallCount()).To(Equal(1))
+				_, handle, _, _ := containerizer.AttachArgsForCall(0)
+				Expect(handle).To(Equal("container1"))
+			})
+		})
+
 		It("should return the error when it failes to get a list of handles", func() {
 			containerizer.HandlesReturns([]string{}, errors.New("banana"))
 			Expect(gdnr.Start()).To(MatchError("banana"))
@@ -770,7 +1164,7 @@ var _ = Describe("Gardener", func() {
 				_, err := gdnr.Containers(props)
 				Expect(err).NotTo(HaveOccurred())
 
-				_, props := propertyManager.MatchesAllArgsForCall(0)
+				_, props := propertyManager.FilterArgsForCall(0)
 				Expect(props).To(HaveKeyWithValue("garden.state", "created"))
 			})
 		}
@@ -783,9 +1177,7 @@ var _ = Describe("Gardener", func() {
 			props := garden.Properties{"somename": "somevalue"}
 
 			It("only returns matching containers", func() {
-				propertyManager.MatchesAllStub = func(handle string, props garden.Properties) bool {
-					return handle != "banana"
-				}
+				propertyManager.FilterReturns([]string{"banana2", "cola"}, nil)
 
 				c, err := gdnr.Containers(props)
 				Expect(err).NotTo(HaveOccurred())
@@ -796,6 +1188,95 @@ var _ = Describe("Gardener", func() {
 
 			itOnlyMatchesFullyCreatedContainers(props)
 		})
+
+		Context("when a single state is asked for", func() {
+			It("matches containers in that state", func() {
+				_, err := gdnr.Containers(garden.Properties{gardener.StateKey: gardener.StateDestroying})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, props := propertyManager.FilterArgsForCall(0)
+				Expect(props).To(HaveKeyWithValue(gardener.StateKey, gardener.StateDestroying))
+			})
+		})
+
+		Context("when several states are asked for", func() {
+			It("queries for containers in any of them", func() {
+				_, err := gdnr.Containers(garden.Properties{gardener.StateKey: "creating,failed"})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, props := propertyManager.FilterArgsForCall(0)
+				Expect(props).NotTo(HaveKey(gardener.StateKey))
+				Expect(props).To(HaveKeyWithValue(gardener.StateKey+"[in]", "creating,failed"))
+			})
+		})
+
+		Context("when the state is queried with an operator", func() {
+			It("does not add the default state", func() {
+				_, err := gdnr.Containers(garden.Properties{gardener.StateKey + "[not]": gardener.StateFailed})
+				Expect(err).NotTo(HaveOccurred())
+
+				_, props := propertyManager.FilterArgsForCall(0)
+				Expect(props).To(Equal(garden.Properties{gardener.StateKey + "[not]": gardener.StateFailed}))
+			})
+		})
+
+		It("passes every handle to be filtered", func() {
+			_, err := gdnr.Containers(nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			handles, _ := propertyManager.FilterArgsForCall(0)
+			Expect(handles).To(Equal([]string{"banana", "banana2", "cola"}))
+		})
+
+		Context("when filtering fails", func() {
+			It("returns the error", func() {
+				propertyManager.FilterReturns(nil, errors.New("some-error"))
+
+				_, err := gdnr.Containers(garden.Properties{"name": "value"})
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+
+		It("does not modify the properties it was passed", func() {
+			props := garden.Properties{"somename": "somevalue"}
+			_, err := gdnr.Containers(props)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(props).To(Equal(garden.Properties{"somename": "somevalue"}))
+		})
+
+		Context("when a container is being created but is not in the depot yet", func() {
+			It("can be listed", func() {
+				unblockCreate := make(chan struct{})
+				volumeCreator.CreateStub = func(_ lager.Logger, _ string, _ rootfs_provider.Spec) (string, []string, error) {
+					<-unblockCreate
+					return "", nil, nil
+				}
+
+				createDone := make(chan struct{})
+				go func() {
+					defer GinkgoRecover()
+					defer close(createDone)
+					gdnr.Create(garden.ContainerSpec{Handle: "in-flight"})
+				}()
+				Eventually(volumeCreator.CreateCallCount).Should(Equal(1))
+
+				propertyManager.FilterStub = func(handles []string, props garden.Properties) ([]string, error) {
+					if props[gardener.StateKey] == gardener.StateCreating && len(handles) == 4 && handles[3] == "in-flight" {
+						return []string{"in-flight"}, nil
+					}
+					return nil, nil
+				}
+
+				c, err := gdnr.Containers(garden.Properties{gardener.StateKey: gardener.StateCreating})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(c).To(HaveLen(1))
+				Expect(c[0].Handle()).To(Equal("in-flight"))
+
+				close(unblockCreate)
+				<-createDone
+			})
+		})
 	})
 
 	Context("when no containers exist", func() {
@@ -836,65 +1317,352 @@ var _ = Describe("Gardener", func() {
 			Expect(handle).To(Equal("banana"))
 			Expect(kill).To(Equal(true))
 		})
-	})
 
-	Describe("Destroy", func() {
-		It("returns garden.ContainreNotFoundError if the container handle isn't in the depot", func() {
-			containerizer.HandlesReturns([]string{}, nil)
-			Expect(gdnr.Destroy("cake!")).To(MatchError(garden.ContainerNotFoundError{Handle: "cake!"}))
-		})
+		It("publishes a stopped event", func() {
+			container, err := gdnr.Lookup("banana")
+			Expect(err).NotTo(HaveOccurred())
 
-		It("asks the containerizer to destroy the container", func() {
-			Expect(gdnr.Destroy("some-handle")).To(Succeed())
-			Expect(containerizer.DestroyCallCount()).To(Equal(1))
-			_, handle := containerizer.DestroyArgsForCall(0)
-			Expect(handle).To(Equal("some-handle"))
+			Expect(container.Stop(false)).To(Succeed())
+			Expect(eventPublisher.PublishCallCount()).To(Equal(1))
+			Expect(eventPublisher.PublishArgsForCall(0)).To(Equal(gardener.Event{Type: gardener.EventStopped, Handle: "banana"}))
 		})
 
-		It("asks the networker to destroy the container network", func() {
-			gdnr.Destroy("some-handle")
-			Expect(networker.DestroyCallCount()).To(Equal(1))
-			networkLogger, handleToDestroy := networker.DestroyArgsForCall(0)
-			Expect(handleToDestroy).To(Equal("some-handle"))
-			Expect(networkLogger).To(Equal(logger))
-		})
+		Context("when stopping fails", func() {
+			It("does not publish a stopped event", func() {
+				containerizer.StopReturns(errors.New("stop-error"))
 
-		It("asks the volume creator to destroy the container rootfs", func() {
-			gdnr.Destroy("some-handle")
-			Expect(volumeCreator.DestroyCallCount()).To(Equal(1))
-			_, handleToDestroy := volumeCreator.DestroyArgsForCall(0)
-			Expect(handleToDestroy).To(Equal("some-handle"))
-		})
+				container, err := gdnr.Lookup("banana")
+				Expect(err).NotTo(HaveOccurred())
 
-		It("should destroy the key space of the property manager", func() {
-			gdnr.Destroy("some-handle")
+				Expect(container.Stop(false)).To(MatchError("stop-error"))
+				Expect(eventPublisher.PublishCallCount()).To(Equal(0))
+			})
 
-			Expect(propertyManager.DestroyKeySpaceCallCount()).To(Equal(1))
-			Expect(propertyManager.DestroyKeySpaceArgsForCall(0)).To(Equal("some-handle"))
-		})
+			It("sets the container state to failed", func() {
+				containerizer.StopReturns(errors.New("stop-error"))
 
-		It("asks the containerizer to remove the bundle from the depot", func() {
-			Expect(gdnr.Destroy("some-handle")).To(Succeed())
-			_, handle := containerizer.RemoveBundleArgsForCall(0)
-			Expect(handle).To(Equal("some-handle"))
-		})
+				container, err := gdnr.Lookup("banana")
+				Expect(err).NotTo(HaveOccurred())
+				container.Stop(false)
 
-		Context("when containerizer fails to destroy the container", func() {
-			BeforeEach(func() {
-				containerizer.DestroyReturns(errors.New("containerized deletion failed"))
+				_, name, value := propertyManager.SetArgsForCall(propertyManager.SetCallCount() - 1)
+				Expect(name).To(Equal(gardener.StateKey))
+				Expect(value).To(Equal(gardener.StateFailed))
 			})
+		})
 
-			It("return the error", func() {
-				err := gdnr.Destroy("some-handle")
-				Expect(err).To(MatchError("containerized deletion failed"))
-			})
+		It("sets the container state to stopping and back to created", func() {
+			propertyManager.GetReturns(gardener.StateCreated, true)
 
-			It("should not destroy the network configuration", func() {
-				err := gdnr.Destroy("some-handle")
-				Expect(err).To(HaveOccurred())
+			container, err := gdnr.Lookup("banana")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(container.Stop(false)).To(Succeed())
 
-				Expect(networker.DestroyCallCount()).To(Equal(0))
-			})
+			Expect(propertyManager.SetCallCount()).To(Equal(2))
+			_, _, value := propertyManager.SetArgsForCall(0)
+			Expect(value).To(Equal(gardener.StateStopping))
+			_, _, value = propertyManager.SetArgsForCall(1)
+			Expect(value).To(Equal(gardener.StateCreated))
+		})
+
+		Context("when the container is still being created", func() {
+			It("refuses to stop it", func() {
+				propertyManager.GetReturns(gardener.StateCreating, true)
+
+				container, err := gdnr.Lookup("banana")
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(container.Stop(false)).To(MatchError("container banana is creating, cannot move to stopping"))
+				Expect(containerizer.StopCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when the container is stopped twice at once", func() {
+			var unblockStop chan struct{}
+
+			BeforeEach(func() {
+				var (
+					mu    sync.Mutex
+					state = gardener.StateCreated
+				)
+
+				// a slow read widens the window between reading and moving on
+				// from the state
+				propertyManager.GetStub = func(_, _ string) (string, bool) {
+					time.Sleep(10 * time.Millisecond)
+
+					mu.Lock()
+					defer mu.Unlock()
+					return state, true
+				}
+				propertyManager.SetStub = func(_, _, value string) {
+					mu.Lock()
+					defer mu.Unlock()
+					state = value
+				}
+
+				unblockStop = make(chan struct{})
+				containerizer.StopStub = func(_ lager.Logger, _ string, _ bool) error {
+					<-unblockStop
+					return nil
+				}
+			})
+
+			It("lets only one of them stop it", func() {
+				container, err := gdnr.Lookup("banana")
+				Expect(err).NotTo(HaveOccurred())
+
+				errs := make(chan error, 2)
+				for i := 0; i < 2; i++ {
+					go func() {
+						errs <- container.Stop(false)
+					}()
+				}
+
+				Eventually(errs).Should(Receive(MatchError("container banana is stopping, cannot move to stopping")))
+				close(unblockStop)
+				Eventually(errs).Should(Receive(BeNil()))
+				Expect(containerizer.StopCallCount()).To(Equal(1))
+			})
+		})
+	})
+
+	Describe("Pause", func() {
+		It("returns garden.ContainerNotFoundError if the container handle isn't in the depot", func() {
+			containerizer.HandlesReturns([]string{}, nil)
+			Expect(gdnr.Pause("cake!")).To(MatchError(garden.ContainerNotFoundError{Handle: "cake!"}))
+			Expect(containerizer.PauseCallCount()).To(Equal(0))
+		})
+
+		It("asks the containerizer to pause the container", func() {
+			Expect(gdnr.Pause("some-handle")).To(Succeed())
+			Expect(containerizer.PauseCallCount()).To(Equal(1))
+			_, handle := containerizer.PauseArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+		})
+
+		Context("when pausing fails", func() {
+			It("forwards the error", func() {
+				containerizer.PauseReturns(errors.New("some-error"))
+				Expect(gdnr.Pause("some-handle")).To(MatchError("some-error"))
+			})
+		})
+
+		Context("when listing the handles fails", func() {
+			It("forwards the error", func() {
+				containerizer.HandlesReturns(nil, errors.New("some-error"))
+				Expect(gdnr.Pause("some-handle")).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("Resume", func() {
+		It("returns garden.ContainerNotFoundError if the container handle isn't in the depot", func() {
+			containerizer.HandlesReturns([]string{}, nil)
+			Expect(gdnr.Resume("cake!")).To(MatchError(garden.ContainerNotFoundError{Handle: "cake!"}))
+			Expect(containerizer.ResumeCallCount()).To(Equal(0))
+		})
+
+		It("asks the containerizer to resume the container", func() {
+			Expect(gdnr.Resume("some-handle")).To(Succeed())
+			Expect(containerizer.ResumeCallCount()).To(Equal(1))
+			_, handle := containerizer.ResumeArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+		})
+
+		Context("when resuming fails", func() {
+			It("forwards the error", func() {
+				containerizer.ResumeReturns(errors.New("some-error"))
+				Expect(gdnr.Resume("some-handle")).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("Checkpoint", func() {
+		It("returns garden.ContainerNotFoundError if the container handle isn't in the depot", func() {
+			containerizer.HandlesReturns([]string{}, nil)
+			Expect(gdnr.Checkpoint("cake!")).To(MatchError(garden.ContainerNotFoundError{Handle: "cake!"}))
+			Expect(containerizer.CheckpointCallCount()).To(Equal(0))
+		})
+
+		It("asks the containerizer to checkpoint the container", func() {
+			Expect(gdnr.Checkpoint("some-handle")).To(Succeed())
+			Expect(containerizer.CheckpointCallCount()).To(Equal(1))
+			_, handle := containerizer.CheckpointArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+		})
+
+		Context("when checkpointing fails", func() {
+			It("forwards the error", func() {
+				containerizer.CheckpointReturns(errors.New("some-error"))
+				Expect(gdnr.Checkpoint("some-handle")).To(MatchError("some-error"))
+			})
+		})
+	})
+
+	Describe("RestoreCheckpoint", func() {
+		BeforeEach(func() {
+			containerizer.InfoReturns(gardener.ActualContainerSpec{Pid: 42}, nil)
+		})
+
+		It("returns garden.ContainerNotFoundError if the container handle isn't in the depot", func() {
+			containerizer.HandlesReturns([]string{}, nil)
+			Expect(gdnr.RestoreCheckpoint("cake!")).To(MatchError(garden.ContainerNotFoundError{Handle: "cake!"}))
+			Expect(containerizer.RestoreCheckpointCallCount()).To(Equal(0))
+		})
+
+		It("asks the containerizer to restore the container", func() {
+			Expect(gdnr.RestoreCheckpoint("some-handle")).To(Succeed())
+			Expect(containerizer.RestoreCheckpointCallCount()).To(Equal(1))
+			_, handle := containerizer.RestoreCheckpointArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+		})
+
+		It("asks the networker to reconfigure the restored init process", func() {
+			Expect(gdnr.RestoreCheckpoint("some-handle")).To(Succeed())
+			Expect(networker.ReconfigureCallCount()).To(Equal(1))
+			_, handle, pid := networker.ReconfigureArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(pid).To(Equal(42))
+		})
+
+		Context("when restoring fails", func() {
+			BeforeEach(func() {
+				containerizer.RestoreCheckpointReturns(errors.New("some-error"))
+			})
+
+			It("forwards the error without reconfiguring the network", func() {
+				Expect(gdnr.RestoreCheckpoint("some-handle")).To(MatchError("some-error"))
+				Expect(networker.ReconfigureCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when getting the container info fails", func() {
+			BeforeEach(func() {
+				containerizer.InfoReturns(gardener.ActualContainerSpec{}, errors.New("info-error"))
+			})
+
+			It("forwards the error without reconfiguring the network", func() {
+				Expect(gdnr.RestoreCheckpoint("some-handle")).To(MatchError("info-error"))
+				Expect(networker.ReconfigureCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when reconfiguring the network fails", func() {
+			It("forwards the error", func() {
+				networker.ReconfigureReturns(errors.New("network-error"))
+				Expect(gdnr.RestoreCheckpoint("some-handle")).To(MatchError("network-error"))
+			})
+		})
+	})
+
+	Describe("Destroy", func() {
+		It("returns garden.ContainreNotFoundError if the container handle isn't in the depot", func() {
+			containerizer.HandlesReturns([]string{}, nil)
+			Expect(gdnr.Destroy("cake!")).To(MatchError(garden.ContainerNotFoundError{Handle: "cake!"}))
+		})
+
+		It("asks the containerizer to destroy the container", func() {
+			Expect(gdnr.Destroy("some-handle")).To(Succeed())
+			Expect(containerizer.DestroyCallCount()).To(Equal(1))
+			_, handle := containerizer.DestroyArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+		})
+
+		It("asks the networker to destroy the container network", func() {
+			gdnr.Destroy("some-handle")
+			Expect(networker.DestroyCallCount()).To(Equal(1))
+			networkLogger, handleToDestroy := networker.DestroyArgsForCall(0)
+			Expect(handleToDestroy).To(Equal("some-handle"))
+			Expect(networkLogger).To(Equal(logger))
+		})
+
+		It("asks the volume creator to destroy the container rootfs", func() {
+			gdnr.Destroy("some-handle")
+			Expect(volumeCreator.DestroyCallCount()).To(Equal(1))
+			_, handleToDestroy := volumeCreator.DestroyArgsForCall(0)
+			Expect(handleToDestroy).To(Equal("some-handle"))
+		})
+
+		It("should destroy the key space of the property manager", func() {
+			gdnr.Destroy("some-handle")
+
+			Expect(propertyManager.DestroyKeySpaceCallCount()).To(Equal(1))
+			Expect(propertyManager.DestroyKeySpaceArgsForCall(0)).To(Equal("some-handle"))
+		})
+
+		It("asks the containerizer to remove the bundle from the depot", func() {
+			Expect(gdnr.Destroy("some-handle")).To(Succeed())
+			_, handle := containerizer.RemoveBundleArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+		})
+
+		It("publishes a destroyed event", func() {
+			Expect(gdnr.Destroy("some-handle")).To(Succeed())
+			Expect(eventPublisher.PublishCallCount()).To(Equal(1))
+
+			event := eventPublisher.PublishArgsForCall(0)
+			Expect(event.Type).To(Equal(gardener.EventDestroyed))
+			Expect(event.Handle).To(Equal("some-handle"))
+		})
+
+		It("matches the destroyed event against the properties the container had", func() {
+			propertyManager.AllReturns(garden.Properties{"team": "main"}, nil)
+			Expect(gdnr.Destroy("some-handle")).To(Succeed())
+
+			event := eventPublisher.PublishArgsForCall(0)
+			Expect(gardener.EventFilter{Properties: garden.Properties{"team": "main"}}.Matches(event)).To(BeTrue())
+		})
+
+		Context("when the container is still being created", func() {
+			It("refuses to destroy it", func() {
+				propertyManager.GetReturns(gardener.StateCreating, true)
+
+				Expect(gdnr.Destroy("some-handle")).To(MatchError("container some-handle is creating, cannot move to destroying"))
+				Expect(containerizer.DestroyCallCount()).To(Equal(0))
+			})
+		})
+
+		It("sets the container state to destroying", func() {
+			Expect(gdnr.Destroy("some-handle")).To(Succeed())
+
+			handle, name, value := propertyManager.SetArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(name).To(Equal(gardener.StateKey))
+			Expect(value).To(Equal(gardener.StateDestroying))
+		})
+
+		It("sets the container state to failed when destroying fails", func() {
+			containerizer.DestroyReturns(errors.New("destroy-error"))
+			Expect(gdnr.Destroy("some-handle")).NotTo(Succeed())
+
+			_, name, value := propertyManager.SetArgsForCall(propertyManager.SetCallCount() - 1)
+			Expect(name).To(Equal(gardener.StateKey))
+			Expect(value).To(Equal(gardener.StateFailed))
+		})
+
+		It("does not publish a destroyed event when destroying fails", func() {
+			containerizer.DestroyReturns(errors.New("destroy-error"))
+			Expect(gdnr.Destroy("some-handle")).To(MatchError("destroy-error"))
+			Expect(eventPublisher.PublishCallCount()).To(Equal(0))
+		})
+
+		Context("when containerizer fails to destroy the container", func() {
+			BeforeEach(func() {
+				containerizer.DestroyReturns(errors.New("containerized deletion failed"))
+			})
+
+			It("return the error", func() {
+				err := gdnr.Destroy("some-handle")
+				Expect(err).To(MatchError("containerized deletion failed"))
+			})
+
+			It("should not destroy the network configuration", func() {
+				err := gdnr.Destroy("some-handle")
+				Expect(err).To(HaveOccurred())
+
+				Expect(networker.DestroyCallCount()).To(Equal(0))
+			})
 		})
 
 		Context("when containerizer fails to remove the bundle from the depot", func() {
@@ -960,6 +1728,31 @@ var _ = Describe("Gardener", func() {
 		})
 	})
 
+	Describe("Ping", func() {
+		It("succeeds when no health checker is configured", func() {
+			Expect(gdnr.Ping()).To(Succeed())
+		})
+
+		Context("when a health checker is configured", func() {
+			var healthChecker *fakes.FakeHealthChecker
+
+			BeforeEach(func() {
+				healthChecker = new(fakes.FakeHealthChecker)
+				gdnr.HealthChecker = healthChecker
+			})
+
+			It("runs the health checks", func() {
+				Expect(gdnr.Ping()).To(Succeed())
+				Expect(healthChecker.CheckCallCount()).To(Equal(1))
+			})
+
+			It("returns the error when a check fails", func() {
+				healthChecker.CheckReturns(errors.New("unhealthy: runc: missing"))
+				Expect(gdnr.Ping()).To(MatchError("unhealthy: runc: missing"))
+			})
+		})
+	})
+
 	Describe("getting capacity", func() {
 		BeforeEach(func() {
 			sysinfoProvider.TotalMemoryReturns(999, nil)
@@ -1118,6 +1911,16 @@ var _ = Describe("Gardener", func() {
 			Expect(info.State).To(Equal("stopped"))
 		})
 
+		It("returns state as 'paused' when the actual container is paused", func() {
+			containerizer.InfoReturns(gardener.ActualContainerSpec{
+				Paused: true,
+			}, nil)
+
+			info, err := container.Info()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.State).To(Equal("paused"))
+		})
+
 		It("returns the garden.network.container-ip property from the propertyManager as the ContainerIP", func() {
 			info, err := container.Info()
 			Expect(err).NotTo(HaveOccurred())
@@ -1246,6 +2049,16 @@ var _ = Describe("Gardener", func() {
 				"some", "things", "happened",
 			}))
 		})
+
+		It("returns the process IDs reported by the containerizer", func() {
+			containerizer.InfoReturns(gardener.ActualContainerSpec{
+				ProcessIDs: []string{"process-1", "process-2"},
+			}, nil)
+
+			info, err := container.Info()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.ProcessIDs).To(Equal([]string{"process-1", "process-2"}))
+		})
 	})
 
 	Describe("BulkInfo", func() {
@@ -1293,6 +2106,143 @@ var _ = Describe("Gardener", func() {
 				Expect(infos["some-handle-1"].Err).To(MatchError(ContainSubstring("no property found")))
 			})
 		})
+
+		It("queries at most BulkParallelism containers at once", func() {
+			gdnr.BulkParallelism = 2
+
+			var inFlight, maxInFlight int32
+			containerizer.InfoStub = func(_ lager.Logger, _ string) (gardener.ActualContainerSpec, error) {
+				n := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+
+				for {
+					max := atomic.LoadInt32(&maxInFlight)
+					if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+						break
+					}
+				}
+
+				time.Sleep(20 * time.Millisecond)
+				return gardener.ActualContainerSpec{}, nil
+			}
+
+			infos, err := gdnr.BulkInfo([]string{"h1", "h2", "h3", "h4", "h5", "h6"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(infos).To(HaveLen(6))
+
+			Expect(atomic.LoadInt32(&maxInFlight)).To(Equal(int32(2)))
+		})
+
+		Context("when a container takes longer than BulkHandleTimeout", func() {
+			var blockInfo chan struct{}
+
+			BeforeEach(func() {
+				blockInfo = make(chan struct{})
+				gdnr.BulkParallelism = 2
+				gdnr.BulkHandleTimeout = 50 * time.Millisecond
+
+				containerizer.InfoStub = func(_ lager.Logger, handle string) (gardener.ActualContainerSpec, error) {
+					if handle == "some-handle-2" {
+						<-blockInfo
+					}
+
+					return gardener.ActualContainerSpec{}, nil
+				}
+			})
+
+			AfterEach(func() {
+				close(blockInfo)
+			})
+
+			It("returns an error for that container only", func() {
+				infos, err := gdnr.BulkInfo([]string{"some-handle-1", "some-handle-2"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(infos["some-handle-1"].Err).To(BeNil())
+				Expect(infos["some-handle-2"].Err).To(MatchError(ContainSubstring("timed out")))
+			})
+
+			It("does not query that container again until the earlier query returns", func() {
+				_, err := gdnr.BulkInfo([]string{"some-handle-2"})
+				Expect(err).NotTo(HaveOccurred())
+
+				infos, err := gdnr.BulkInfo([]string{"some-handle-1", "some-handle-2"})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(infos["some-handle-1"].Err).To(BeNil())
+				Expect(infos["some-handle-2"].Err).To(MatchError(ContainSubstring("still waiting for an earlier query")))
+
+				queried := 0
+				for i := 0; i < containerizer.InfoCallCount(); i++ {
+					if _, handle := containerizer.InfoArgsForCall(i); handle == "some-handle-2" {
+						queried++
+					}
+				}
+				Expect(queried).To(Equal(1))
+			})
+
+			It("queries a container which is asked about more than once only once", func() {
+				go func() { blockInfo <- struct{}{} }()
+				infos, err := gdnr.BulkInfo([]string{"some-handle-2", "some-handle-2"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(infos).To(HaveLen(1))
+				Expect(infos["some-handle-2"].Err).To(BeNil())
+				Expect(containerizer.InfoCallCount()).To(Equal(1))
+			})
+
+			It("queries that container again once the earlier query returns", func() {
+				_, err := gdnr.BulkInfo([]string{"some-handle-2"})
+				Expect(err).NotTo(HaveOccurred())
+
+				blockInfo <- struct{}{}
+
+				Eventually(func() error {
+					infos, err := gdnr.BulkInfo([]string{"some-handle-2"})
+					Expect(err).NotTo(HaveOccurred())
+					return infos["some-handle-2"].Err
+				}).Should(MatchError(ContainSubstring("timed out")))
+			})
+		})
+	})
+
+	Describe("BulkMetrics", func() {
+		It("returns the metrics of each container", func() {
+			containerizer.MetricsReturns(gardener.ActualContainerMetrics{
+				CPU: garden.ContainerCPUStat{Usage: 12},
+			}, nil)
+
+			metrics, err := gdnr.BulkMetrics([]string{"some-handle-1", "some-handle-2"})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(metrics).To(HaveLen(2))
+			Expect(metrics["some-handle-1"].Metrics.CPUStat.Usage).To(Equal(uint64(12)))
+			Expect(metrics["some-handle-2"].Err).To(BeNil())
+		})
+
+		Context("when a container takes longer than BulkHandleTimeout", func() {
+			var blockMetrics chan struct{}
+
+			BeforeEach(func() {
+				blockMetrics = make(chan struct{})
+				gdnr.BulkHandleTimeout = 50 * time.Millisecond
+
+				containerizer.MetricsStub = func(_ lager.Logger, handle string) (gardener.ActualContainerMetrics, error) {
+					<-blockMetrics
+					return gardener.ActualContainerMetrics{}, nil
+				}
+			})
+
+			AfterEach(func() {
+				close(blockMetrics)
+			})
+
+			It("returns an error for the container", func() {
+				metrics, err := gdnr.BulkMetrics([]string{"some-handle-1"})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(metrics["some-handle-1"].Err).To(MatchError(ContainSubstring("timed out")))
+			})
+		})
 	})
 
 	Describe("Metrics", func() {
@@ -1473,6 +2423,221 @@ var _ = Describe("Gardener", func() {
 			Expect(currentMemoryLimits.LimitInBytes).To(BeEquivalentTo(20))
 		})
 
+		It("asks the containerizer to limit memory", func() {
+			Expect(container.LimitMemory(garden.MemoryLimits{LimitInBytes: 30})).To(Succeed())
+
+			Expect(containerizer.LimitMemoryCallCount()).To(Equal(1))
+			_, handle, limits := containerizer.LimitMemoryArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(limits).To(Equal(garden.MemoryLimits{LimitInBytes: 30}))
+		})
+
+		Context("when limiting memory fails", func() {
+			It("forwards the error", func() {
+				containerizer.LimitMemoryReturns(errors.New("some-error"))
+				Expect(container.LimitMemory(garden.MemoryLimits{LimitInBytes: 30})).To(MatchError("some-error"))
+			})
+		})
+
+		It("asks the containerizer to limit cpu", func() {
+			Expect(container.LimitCPU(garden.CPULimits{LimitInShares: 40})).To(Succeed())
+
+			Expect(containerizer.LimitCPUCallCount()).To(Equal(1))
+			_, handle, limits := containerizer.LimitCPUArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(limits).To(Equal(garden.CPULimits{LimitInShares: 40}))
+		})
+
+		Context("when limiting cpu fails", func() {
+			It("forwards the error", func() {
+				containerizer.LimitCPUReturns(errors.New("some-error"))
+				Expect(container.LimitCPU(garden.CPULimits{LimitInShares: 40})).To(MatchError("some-error"))
+			})
+		})
+
+		It("asks the networker to limit bandwidth", func() {
+			limits := garden.BandwidthLimits{RateInBytesPerSecond: 50, BurstRateInBytesPerSecond: 60}
+			Expect(container.LimitBandwidth(limits)).To(Succeed())
+
+			Expect(networker.LimitBandwidthCallCount()).To(Equal(1))
+			_, handle, actualLimits := networker.LimitBandwidthArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(actualLimits).To(Equal(limits))
+		})
+
+		Context("when limiting bandwidth fails", func() {
+			It("forwards the error", func() {
+				networker.LimitBandwidthReturns(errors.New("some-error"))
+				Expect(container.LimitBandwidth(garden.BandwidthLimits{})).To(MatchError("some-error"))
+			})
+		})
+
+		It("gets the bandwidth limits from the networker", func() {
+			networker.BandwidthLimitsReturns(garden.BandwidthLimits{RateInBytesPerSecond: 70}, nil)
+
+			currentBandwidthLimits, err := container.CurrentBandwidthLimits()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(currentBandwidthLimits.RateInBytesPerSecond).To(BeEquivalentTo(70))
+
+			_, handle := networker.BandwidthLimitsArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+		})
+
+		Context("when getting the bandwidth limits fails", func() {
+			It("forwards the error", func() {
+				networker.BandwidthLimitsReturns(garden.BandwidthLimits{}, errors.New("some-error"))
+
+				_, err := container.CurrentBandwidthLimits()
+				Expect(err).To(MatchError("some-error"))
+			})
+		})
+
+		Describe("disk limits", func() {
+			var volumeResizer *fakes.FakeVolumeResizer
+
+			BeforeEach(func() {
+				volumeResizer = new(fakes.FakeVolumeResizer)
+				gdnr.VolumeCreator = resizableVolumeCreator{volumeCreator, volumeResizer}
+
+				var err error
+				container, err = gdnr.Lookup("some-handle")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("asks the volume creator to resize the volume", func() {
+				limits := garden.DiskLimits{ByteHard: 80, Scope: garden.DiskLimitScopeExclusive}
+				Expect(container.LimitDisk(limits)).To(Succeed())
+
+				Expect(volumeResizer.ResizeCallCount()).To(Equal(1))
+				_, handle, namespaced, actualLimits := volumeResizer.ResizeArgsForCall(0)
+				Expect(handle).To(Equal("some-handle"))
+				Expect(namespaced).To(BeTrue())
+				Expect(actualLimits).To(Equal(limits))
+			})
+
+			Context("when the container is privileged", func() {
+				It("asks for a privileged resize", func() {
+					containerizer.InfoReturns(gardener.ActualContainerSpec{Privileged: true}, nil)
+					Expect(container.LimitDisk(garden.DiskLimits{ByteHard: 80})).To(Succeed())
+
+					_, _, namespaced, _ := volumeResizer.ResizeArgsForCall(0)
+					Expect(namespaced).To(BeFalse())
+				})
+			})
+
+			It("records the limit in force via the property manager", func() {
+				Expect(container.LimitDisk(garden.DiskLimits{ByteHard: 80, ByteSoft: 70})).To(Succeed())
+
+				Expect(propertyManager.SetCallCount()).To(Equal(1))
+				handle, name, value := propertyManager.SetArgsForCall(0)
+				Expect(handle).To(Equal("some-handle"))
+				Expect(name).To(Equal(gardener.DiskLimitsKey))
+
+				var limits garden.DiskLimits
+				Expect(json.Unmarshal([]byte(value), &limits)).To(Succeed())
+				Expect(limits).To(Equal(garden.DiskLimits{ByteHard: 80}))
+			})
+
+			Context("when resizing fails", func() {
+				BeforeEach(func() {
+					volumeResizer.ResizeReturns(errors.New("some-error"))
+				})
+
+				It("forwards the error", func() {
+					Expect(container.LimitDisk(garden.DiskLimits{ByteHard: 80})).To(MatchError("some-error"))
+				})
+
+				It("does not record the limit", func() {
+					container.LimitDisk(garden.DiskLimits{ByteHard: 80})
+					Expect(propertyManager.SetCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when Info fails", func() {
+				It("forwards the error without resizing", func() {
+					containerizer.InfoReturns(gardener.ActualContainerSpec{}, errors.New("some-error"))
+
+					Expect(container.LimitDisk(garden.DiskLimits{ByteHard: 80})).To(MatchError("some-error"))
+					Expect(volumeResizer.ResizeCallCount()).To(Equal(0))
+				})
+			})
+
+			Context("when the volume creator cannot resize volumes", func() {
+				BeforeEach(func() {
+					gdnr.VolumeCreator = volumeCreator
+
+					var err error
+					container, err = gdnr.Lookup("some-handle")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("returns ErrDiskResizeNotSupported", func() {
+					Expect(container.LimitDisk(garden.DiskLimits{ByteHard: 80})).To(Equal(gardener.ErrDiskResizeNotSupported))
+				})
+			})
+
+			It("asks the volume creator for the disk limits in force", func() {
+				volumeResizer.DiskLimitsReturns(garden.DiskLimits{ByteHard: 100, Scope: garden.DiskLimitScopeExclusive}, nil)
+
+				currentDiskLimits, err := container.CurrentDiskLimits()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(currentDiskLimits).To(Equal(garden.DiskLimits{ByteHard: 100, Scope: garden.DiskLimitScopeExclusive}))
+
+				Expect(volumeResizer.DiskLimitsCallCount()).To(Equal(1))
+				_, handle, namespaced := volumeResizer.DiskLimitsArgsForCall(0)
+				Expect(handle).To(Equal("some-handle"))
+				Expect(namespaced).To(BeTrue())
+			})
+
+			Context("when the volume creator cannot say what the disk limits are", func() {
+				BeforeEach(func() {
+					volumeResizer.DiskLimitsReturns(garden.DiskLimits{}, errors.New("some-error"))
+				})
+
+				It("falls back to the recorded disk limits", func() {
+					propertyManager.GetReturns(`{"byte_hard": 90}`, true)
+					Expect(container.CurrentDiskLimits()).To(Equal(garden.DiskLimits{ByteHard: 90}))
+				})
+			})
+
+			Context("when the volume creator cannot resize volumes", func() {
+				BeforeEach(func() {
+					gdnr.VolumeCreator = volumeCreator
+
+					var err error
+					container, err = gdnr.Lookup("some-handle")
+					Expect(err).NotTo(HaveOccurred())
+				})
+
+				It("gets the recorded disk limits", func() {
+					propertyManager.GetStub = func(handle, name string) (string, bool) {
+						Expect(handle).To(Equal("some-handle"))
+						Expect(name).To(Equal(gardener.DiskLimitsKey))
+						return `{"byte_hard": 90}`, true
+					}
+
+					currentDiskLimits, err := container.CurrentDiskLimits()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(currentDiskLimits).To(Equal(garden.DiskLimits{ByteHard: 90}))
+				})
+
+				Context("when no disk limits were recorded", func() {
+					It("returns empty limits", func() {
+						propertyManager.GetReturns("", false)
+						Expect(container.CurrentDiskLimits()).To(Equal(garden.DiskLimits{}))
+					})
+				})
+
+				Context("when the recorded disk limits are invalid", func() {
+					It("returns an error", func() {
+						propertyManager.GetReturns("potato", true)
+						_, err := container.CurrentDiskLimits()
+						Expect(err).To(MatchError(ContainSubstring("unmarshaling disk limits")))
+					})
+				})
+			})
+		})
+
 		Context("when Info fails", func() {
 			It("forwards the error", func() {
 				containerizer.InfoReturns(gardener.ActualContainerSpec{}, errors.New("some-error"))
@@ -1525,3 +2690,13 @@ var _ = Describe("Gardener", func() {
 		})
 	})
 })
+
+type resizableVolumeCreator struct {
+	*fakes.FakeVolumeCreator
+	*fakes.FakeVolumeResizer
+}
+
+type watchableProcess struct {
+	*gardenfakes.FakeProcess
+	*fakes.FakeExitWatcher
+}
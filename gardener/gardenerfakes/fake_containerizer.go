@@ -82,6 +82,48 @@ type FakeContainerizer struct {
 	stopReturns struct {
 		result1 error
 	}
+	PauseStub        func(log lager.Logger, handle string) error
+	pauseMutex       sync.RWMutex
+	pauseArgsForCall []struct {
+		log    lager.Logger
+		handle string
+	}
+	pauseReturns struct {
+		result1 error
+	}
+	ResumeStub        func(log lager.Logger, handle string) error
+	resumeMutex       sync.RWMutex
+	resumeArgsForCall []struct {
+		log    lager.Logger
+		handle string
+	}
+	resumeReturns struct {
+		result1 error
+	}
+	CheckpointStub        func(log lager.Logger, handle string) error
+	checkpointMutex       sync.RWMutex
+	checkpointArgsForCall []struct {
+		log    lager.Logger
+		handle string
+	}
+	checkpointReturns struct {
+		result1 error
+	}
+	RestoreCheckpointStub        func(log lager.Logger, handle string) error
+	restoreCheckpointMutex       sync.RWMutex
+	restoreCheckpointArgsForCall []struct {
+		log    lager.Logger
+		handle string
+	}
+	restoreCheckpointReturns struct {
+		result1 error
+	}
+	WatchEventsStub        func(log lager.Logger, handle string)
+	watchEventsMutex       sync.RWMutex
+	watchEventsArgsForCall []struct {
+		log    lager.Logger
+		handle string
+	}
 	DestroyStub        func(log lager.Logger, handle string) error
 	destroyMutex       sync.RWMutex
 	destroyArgsForCall []struct {
@@ -120,6 +162,26 @@ type FakeContainerizer struct {
 		result1 gardener.ActualContainerMetrics
 		result2 error
 	}
+	LimitMemoryStub        func(log lager.Logger, handle string, limits garden.MemoryLimits) error
+	limitMemoryMutex       sync.RWMutex
+	limitMemoryArgsForCall []struct {
+		log    lager.Logger
+		handle string
+		limits garden.MemoryLimits
+	}
+	limitMemoryReturns struct {
+		result1 error
+	}
+	LimitCPUStub        func(log lager.Logger, handle string, limits garden.CPULimits) error
+	limitCPUMutex       sync.RWMutex
+	limitCPUArgsForCall []struct {
+		log    lager.Logger
+		handle string
+		limits garden.CPULimits
+	}
+	limitCPUReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -364,6 +426,167 @@ func (fake *FakeContainerizer) StopReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeContainerizer) Pause(log lager.Logger, handle string) error {
+	fake.pauseMutex.Lock()
+	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
+		log    lager.Logger
+		handle string
+	}{log, handle})
+	fake.recordInvocation("Pause", []interface{}{log, handle})
+	fake.pauseMutex.Unlock()
+	if fake.PauseStub != nil {
+		return fake.PauseStub(log, handle)
+	} else {
+		return fake.pauseReturns.result1
+	}
+}
+
+func (fake *FakeContainerizer) PauseCallCount() int {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return len(fake.pauseArgsForCall)
+}
+
+func (fake *FakeContainerizer) PauseArgsForCall(i int) (lager.Logger, string) {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return fake.pauseArgsForCall[i].log, fake.pauseArgsForCall[i].handle
+}
+
+func (fake *FakeContainerizer) PauseReturns(result1 error) {
+	fake.PauseStub = nil
+	fake.pauseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainerizer) Resume(log lager.Logger, handle string) error {
+	fake.resumeMutex.Lock()
+	fake.resumeArgsForCall = append(fake.resumeArgsForCall, struct {
+		log    lager.Logger
+		handle string
+	}{log, handle})
+	fake.recordInvocation("Resume", []interface{}{log, handle})
+	fake.resumeMutex.Unlock()
+	if fake.ResumeStub != nil {
+		return fake.ResumeStub(log, handle)
+	} else {
+		return fake.resumeReturns.result1
+	}
+}
+
+func (fake *FakeContainerizer) ResumeCallCount() int {
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	return len(fake.resumeArgsForCall)
+}
+
+func (fake *FakeContainerizer) ResumeArgsForCall(i int) (lager.Logger, string) {
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	return fake.resumeArgsForCall[i].log, fake.resumeArgsForCall[i].handle
+}
+
+func (fake *FakeContainerizer) ResumeReturns(result1 error) {
+	fake.ResumeStub = nil
+	fake.resumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainerizer) Checkpoint(log lager.Logger, handle string) error {
+	fake.checkpointMutex.Lock()
+	fake.checkpointArgsForCall = append(fake.checkpointArgsForCall, struct {
+		log    lager.Logger
+		handle string
+	}{log, handle})
+	fake.recordInvocation("Checkpoint", []interface{}{log, handle})
+	fake.checkpointMutex.Unlock()
+	if fake.CheckpointStub != nil {
+		return fake.CheckpointStub(log, handle)
+	} else {
+		return fake.checkpointReturns.result1
+	}
+}
+
+func (fake *FakeContainerizer) CheckpointCallCount() int {
+	fake.checkpointMutex.RLock()
+	defer fake.checkpointMutex.RUnlock()
+	return len(fake.checkpointArgsForCall)
+}
+
+func (fake *FakeContainerizer) CheckpointArgsForCall(i int) (lager.Logger, string) {
+	fake.checkpointMutex.RLock()
+	defer fake.checkpointMutex.RUnlock()
+	return fake.checkpointArgsForCall[i].log, fake.checkpointArgsForCall[i].handle
+}
+
+func (fake *FakeContainerizer) CheckpointReturns(result1 error) {
+	fake.CheckpointStub = nil
+	fake.checkpointReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainerizer) RestoreCheckpoint(log lager.Logger, handle string) error {
+	fake.restoreCheckpointMutex.Lock()
+	fake.restoreCheckpointArgsForCall = append(fake.restoreCheckpointArgsForCall, struct {
+		log    lager.Logger
+		handle string
+	}{log, handle})
+	fake.recordInvocation("RestoreCheckpoint", []interface{}{log, handle})
+	fake.restoreCheckpointMutex.Unlock()
+	if fake.RestoreCheckpointStub != nil {
+		return fake.RestoreCheckpointStub(log, handle)
+	} else {
+		return fake.restoreCheckpointReturns.result1
+	}
+}
+
+func (fake *FakeContainerizer) RestoreCheckpointCallCount() int {
+	fake.restoreCheckpointMutex.RLock()
+	defer fake.restoreCheckpointMutex.RUnlock()
+	return len(fake.restoreCheckpointArgsForCall)
+}
+
+func (fake *FakeContainerizer) RestoreCheckpointArgsForCall(i int) (lager.Logger, string) {
+	fake.restoreCheckpointMutex.RLock()
+	defer fake.restoreCheckpointMutex.RUnlock()
+	return fake.restoreCheckpointArgsForCall[i].log, fake.restoreCheckpointArgsForCall[i].handle
+}
+
+func (fake *FakeContainerizer) RestoreCheckpointReturns(result1 error) {
+	fake.RestoreCheckpointStub = nil
+	fake.restoreCheckpointReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainerizer) WatchEvents(log lager.Logger, handle string) {
+	fake.watchEventsMutex.Lock()
+	fake.watchEventsArgsForCall = append(fake.watchEventsArgsForCall, struct {
+		log    lager.Logger
+		handle string
+	}{log, handle})
+	fake.recordInvocation("WatchEvents", []interface{}{log, handle})
+	fake.watchEventsMutex.Unlock()
+	if fake.WatchEventsStub != nil {
+		fake.WatchEventsStub(log, handle)
+	}
+}
+
+func (fake *FakeContainerizer) WatchEventsCallCount() int {
+	fake.watchEventsMutex.RLock()
+	defer fake.watchEventsMutex.RUnlock()
+	return len(fake.watchEventsArgsForCall)
+}
+
+func (fake *FakeContainerizer) WatchEventsArgsForCall(i int) (lager.Logger, string) {
+	fake.watchEventsMutex.RLock()
+	defer fake.watchEventsMutex.RUnlock()
+	return fake.watchEventsArgsForCall[i].log, fake.watchEventsArgsForCall[i].handle
+}
+
 func (fake *FakeContainerizer) Destroy(log lager.Logger, handle string) error {
 	fake.destroyMutex.Lock()
 	fake.destroyArgsForCall = append(fake.destroyArgsForCall, struct {
@@ -502,6 +725,76 @@ func (fake *FakeContainerizer) MetricsReturns(result1 gardener.ActualContainerMe
 	}{result1, result2}
 }
 
+func (fake *FakeContainerizer) LimitMemory(log lager.Logger, handle string, limits garden.MemoryLimits) error {
+	fake.limitMemoryMutex.Lock()
+	fake.limitMemoryArgsForCall = append(fake.limitMemoryArgsForCall, struct {
+		log    lager.Logger
+		handle string
+		limits garden.MemoryLimits
+	}{log, handle, limits})
+	fake.recordInvocation("LimitMemory", []interface{}{log, handle, limits})
+	fake.limitMemoryMutex.Unlock()
+	if fake.LimitMemoryStub != nil {
+		return fake.LimitMemoryStub(log, handle, limits)
+	} else {
+		return fake.limitMemoryReturns.result1
+	}
+}
+
+func (fake *FakeContainerizer) LimitMemoryCallCount() int {
+	fake.limitMemoryMutex.RLock()
+	defer fake.limitMemoryMutex.RUnlock()
+	return len(fake.limitMemoryArgsForCall)
+}
+
+func (fake *FakeContainerizer) LimitMemoryArgsForCall(i int) (lager.Logger, string, garden.MemoryLimits) {
+	fake.limitMemoryMutex.RLock()
+	defer fake.limitMemoryMutex.RUnlock()
+	return fake.limitMemoryArgsForCall[i].log, fake.limitMemoryArgsForCall[i].handle, fake.limitMemoryArgsForCall[i].limits
+}
+
+func (fake *FakeContainerizer) LimitMemoryReturns(result1 error) {
+	fake.LimitMemoryStub = nil
+	fake.limitMemoryReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeContainerizer) LimitCPU(log lager.Logger, handle string, limits garden.CPULimits) error {
+	fake.limitCPUMutex.Lock()
+	fake.limitCPUArgsForCall = append(fake.limitCPUArgsForCall, struct {
+		log    lager.Logger
+		handle string
+		limits garden.CPULimits
+	}{log, handle, limits})
+	fake.recordInvocation("LimitCPU", []interface{}{log, handle, limits})
+	fake.limitCPUMutex.Unlock()
+	if fake.LimitCPUStub != nil {
+		return fake.LimitCPUStub(log, handle, limits)
+	} else {
+		return fake.limitCPUReturns.result1
+	}
+}
+
+func (fake *FakeContainerizer) LimitCPUCallCount() int {
+	fake.limitCPUMutex.RLock()
+	defer fake.limitCPUMutex.RUnlock()
+	return len(fake.limitCPUArgsForCall)
+}
+
+func (fake *FakeContainerizer) LimitCPUArgsForCall(i int) (lager.Logger, string, garden.CPULimits) {
+	fake.limitCPUMutex.RLock()
+	defer fake.limitCPUMutex.RUnlock()
+	return fake.limitCPUArgsForCall[i].log, fake.limitCPUArgsForCall[i].handle, fake.limitCPUArgsForCall[i].limits
+}
+
+func (fake *FakeContainerizer) LimitCPUReturns(result1 error) {
+	fake.LimitCPUStub = nil
+	fake.limitCPUReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeContainerizer) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -519,6 +812,16 @@ func (fake *FakeContainerizer) Invocations() map[string][][]interface{} {
 	defer fake.attachMutex.RUnlock()
 	fake.stopMutex.RLock()
 	defer fake.stopMutex.RUnlock()
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	fake.checkpointMutex.RLock()
+	defer fake.checkpointMutex.RUnlock()
+	fake.restoreCheckpointMutex.RLock()
+	defer fake.restoreCheckpointMutex.RUnlock()
+	fake.watchEventsMutex.RLock()
+	defer fake.watchEventsMutex.RUnlock()
 	fake.destroyMutex.RLock()
 	defer fake.destroyMutex.RUnlock()
 	fake.removeBundleMutex.RLock()
@@ -527,6 +830,10 @@ func (fake *FakeContainerizer) Invocations() map[string][][]interface{} {
 	defer fake.infoMutex.RUnlock()
 	fake.metricsMutex.RLock()
 	defer fake.metricsMutex.RUnlock()
+	fake.limitMemoryMutex.RLock()
+	defer fake.limitMemoryMutex.RUnlock()
+	fake.limitCPUMutex.RLock()
+	defer fake.limitCPUMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,110 @@
+// This file was generated by counterfeiter
+package gardenerfakes
+
+import (
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+)
+
+type FakeDrainer struct {
+	DrainStub        func(timeout time.Duration) error
+	drainMutex       sync.RWMutex
+	drainArgsForCall []struct {
+		timeout time.Duration
+	}
+	drainReturns struct {
+		result1 error
+	}
+	DrainingStub        func() bool
+	drainingMutex       sync.RWMutex
+	drainingArgsForCall []struct{}
+	drainingReturns     struct {
+		result1 bool
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeDrainer) Drain(timeout time.Duration) error {
+	fake.drainMutex.Lock()
+	fake.drainArgsForCall = append(fake.drainArgsForCall, struct {
+		timeout time.Duration
+	}{timeout})
+	fake.recordInvocation("Drain", []interface{}{timeout})
+	fake.drainMutex.Unlock()
+	if fake.DrainStub != nil {
+		return fake.DrainStub(timeout)
+	} else {
+		return fake.drainReturns.result1
+	}
+}
+
+func (fake *FakeDrainer) DrainCallCount() int {
+	fake.drainMutex.RLock()
+	defer fake.drainMutex.RUnlock()
+	return len(fake.drainArgsForCall)
+}
+
+func (fake *FakeDrainer) DrainArgsForCall(i int) time.Duration {
+	fake.drainMutex.RLock()
+	defer fake.drainMutex.RUnlock()
+	return fake.drainArgsForCall[i].timeout
+}
+
+func (fake *FakeDrainer) DrainReturns(result1 error) {
+	fake.DrainStub = nil
+	fake.drainReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeDrainer) Draining() bool {
+	fake.drainingMutex.Lock()
+	fake.drainingArgsForCall = append(fake.drainingArgsForCall, struct{}{})
+	fake.recordInvocation("Draining", []interface{}{})
+	fake.drainingMutex.Unlock()
+	if fake.DrainingStub != nil {
+		return fake.DrainingStub()
+	} else {
+		return fake.drainingReturns.result1
+	}
+}
+
+func (fake *FakeDrainer) DrainingCallCount() int {
+	fake.drainingMutex.RLock()
+	defer fake.drainingMutex.RUnlock()
+	return len(fake.drainingArgsForCall)
+}
+
+func (fake *FakeDrainer) DrainingReturns(result1 bool) {
+	fake.DrainingStub = nil
+	fake.drainingReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeDrainer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.drainMutex.RLock()
+	defer fake.drainMutex.RUnlock()
+	fake.drainingMutex.RLock()
+	defer fake.drainingMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeDrainer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ gardener.Drainer = new(FakeDrainer)
@@ -0,0 +1,64 @@
+// This file was generated by counterfeiter
+package gardenerfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/guardian/gardener"
+)
+
+type FakeEventPublisher struct {
+	PublishStub        func(event gardener.Event)
+	publishMutex       sync.RWMutex
+	publishArgsForCall []struct {
+		event gardener.Event
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeEventPublisher) Publish(event gardener.Event) {
+	fake.publishMutex.Lock()
+	fake.publishArgsForCall = append(fake.publishArgsForCall, struct {
+		event gardener.Event
+	}{event})
+	fake.recordInvocation("Publish", []interface{}{event})
+	fake.publishMutex.Unlock()
+	if fake.PublishStub != nil {
+		fake.PublishStub(event)
+	}
+}
+
+func (fake *FakeEventPublisher) PublishCallCount() int {
+	fake.publishMutex.RLock()
+	defer fake.publishMutex.RUnlock()
+	return len(fake.publishArgsForCall)
+}
+
+func (fake *FakeEventPublisher) PublishArgsForCall(i int) gardener.Event {
+	fake.publishMutex.RLock()
+	defer fake.publishMutex.RUnlock()
+	return fake.publishArgsForCall[i].event
+}
+
+func (fake *FakeEventPublisher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.publishMutex.RLock()
+	defer fake.publishMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeEventPublisher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ gardener.EventPublisher = new(FakeEventPublisher)
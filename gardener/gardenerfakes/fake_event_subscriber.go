@@ -0,0 +1,78 @@
+// This file was generated by counterfeiter
+package gardenerfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/guardian/gardener"
+)
+
+type FakeEventSubscriber struct {
+	SubscribeStub        func(filter gardener.EventFilter) (events <-chan gardener.Event, unsubscribe func())
+	subscribeMutex       sync.RWMutex
+	subscribeArgsForCall []struct {
+		filter gardener.EventFilter
+	}
+	subscribeReturns struct {
+		result1 <-chan gardener.Event
+		result2 func()
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeEventSubscriber) Subscribe(filter gardener.EventFilter) (events <-chan gardener.Event, unsubscribe func()) {
+	fake.subscribeMutex.Lock()
+	fake.subscribeArgsForCall = append(fake.subscribeArgsForCall, struct {
+		filter gardener.EventFilter
+	}{filter})
+	fake.recordInvocation("Subscribe", []interface{}{filter})
+	fake.subscribeMutex.Unlock()
+	if fake.SubscribeStub != nil {
+		return fake.SubscribeStub(filter)
+	} else {
+		return fake.subscribeReturns.result1, fake.subscribeReturns.result2
+	}
+}
+
+func (fake *FakeEventSubscriber) SubscribeCallCount() int {
+	fake.subscribeMutex.RLock()
+	defer fake.subscribeMutex.RUnlock()
+	return len(fake.subscribeArgsForCall)
+}
+
+func (fake *FakeEventSubscriber) SubscribeArgsForCall(i int) gardener.EventFilter {
+	fake.subscribeMutex.RLock()
+	defer fake.subscribeMutex.RUnlock()
+	return fake.subscribeArgsForCall[i].filter
+}
+
+func (fake *FakeEventSubscriber) SubscribeReturns(result1 <-chan gardener.Event, result2 func()) {
+	fake.SubscribeStub = nil
+	fake.subscribeReturns = struct {
+		result1 <-chan gardener.Event
+		result2 func()
+	}{result1, result2}
+}
+
+func (fake *FakeEventSubscriber) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.subscribeMutex.RLock()
+	defer fake.subscribeMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeEventSubscriber) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ gardener.EventSubscriber = new(FakeEventSubscriber)
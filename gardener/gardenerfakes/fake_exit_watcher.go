@@ -0,0 +1,70 @@
+// This file was generated by counterfeiter
+package gardenerfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/guardian/gardener"
+)
+
+type FakeExitWatcher struct {
+	WaitForExitStub        func() (int, *gardener.ResourceUsage, error)
+	waitForExitMutex       sync.RWMutex
+	waitForExitArgsForCall []struct{}
+	waitForExitReturns     struct {
+		result1 int
+		result2 *gardener.ResourceUsage
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeExitWatcher) WaitForExit() (int, *gardener.ResourceUsage, error) {
+	fake.waitForExitMutex.Lock()
+	fake.waitForExitArgsForCall = append(fake.waitForExitArgsForCall, struct{}{})
+	fake.recordInvocation("WaitForExit", []interface{}{})
+	fake.waitForExitMutex.Unlock()
+	if fake.WaitForExitStub != nil {
+		return fake.WaitForExitStub()
+	} else {
+		return fake.waitForExitReturns.result1, fake.waitForExitReturns.result2, fake.waitForExitReturns.result3
+	}
+}
+
+func (fake *FakeExitWatcher) WaitForExitCallCount() int {
+	fake.waitForExitMutex.RLock()
+	defer fake.waitForExitMutex.RUnlock()
+	return len(fake.waitForExitArgsForCall)
+}
+
+func (fake *FakeExitWatcher) WaitForExitReturns(result1 int, result2 *gardener.ResourceUsage, result3 error) {
+	fake.WaitForExitStub = nil
+	fake.waitForExitReturns = struct {
+		result1 int
+		result2 *gardener.ResourceUsage
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeExitWatcher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.waitForExitMutex.RLock()
+	defer fake.waitForExitMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeExitWatcher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ gardener.ExitWatcher = new(FakeExitWatcher)
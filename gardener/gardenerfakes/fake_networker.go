@@ -68,6 +68,36 @@ type FakeNetworker struct {
 	netOutReturns struct {
 		result1 error
 	}
+	LimitBandwidthStub        func(log lager.Logger, handle string, limits garden.BandwidthLimits) error
+	limitBandwidthMutex       sync.RWMutex
+	limitBandwidthArgsForCall []struct {
+		log    lager.Logger
+		handle string
+		limits garden.BandwidthLimits
+	}
+	limitBandwidthReturns struct {
+		result1 error
+	}
+	BandwidthLimitsStub        func(log lager.Logger, handle string) (garden.BandwidthLimits, error)
+	bandwidthLimitsMutex       sync.RWMutex
+	bandwidthLimitsArgsForCall []struct {
+		log    lager.Logger
+		handle string
+	}
+	bandwidthLimitsReturns struct {
+		result1 garden.BandwidthLimits
+		result2 error
+	}
+	ReconfigureStub        func(log lager.Logger, handle string, pid int) error
+	reconfigureMutex       sync.RWMutex
+	reconfigureArgsForCall []struct {
+		log    lager.Logger
+		handle string
+		pid    int
+	}
+	reconfigureReturns struct {
+		result1 error
+	}
 	RestoreStub        func(log lager.Logger, handle string) error
 	restoreMutex       sync.RWMutex
 	restoreArgsForCall []struct {
@@ -288,6 +318,111 @@ func (fake *FakeNetworker) NetOutReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeNetworker) LimitBandwidth(log lager.Logger, handle string, limits garden.BandwidthLimits) error {
+	fake.limitBandwidthMutex.Lock()
+	fake.limitBandwidthArgsForCall = append(fake.limitBandwidthArgsForCall, struct {
+		log    lager.Logger
+		handle string
+		limits garden.BandwidthLimits
+	}{log, handle, limits})
+	fake.recordInvocation("LimitBandwidth", []interface{}{log, handle, limits})
+	fake.limitBandwidthMutex.Unlock()
+	if fake.LimitBandwidthStub != nil {
+		return fake.LimitBandwidthStub(log, handle, limits)
+	} else {
+		return fake.limitBandwidthReturns.result1
+	}
+}
+
+func (fake *FakeNetworker) LimitBandwidthCallCount() int {
+	fake.limitBandwidthMutex.RLock()
+	defer fake.limitBandwidthMutex.RUnlock()
+	return len(fake.limitBandwidthArgsForCall)
+}
+
+func (fake *FakeNetworker) LimitBandwidthArgsForCall(i int) (lager.Logger, string, garden.BandwidthLimits) {
+	fake.limitBandwidthMutex.RLock()
+	defer fake.limitBandwidthMutex.RUnlock()
+	return fake.limitBandwidthArgsForCall[i].log, fake.limitBandwidthArgsForCall[i].handle, fake.limitBandwidthArgsForCall[i].limits
+}
+
+func (fake *FakeNetworker) LimitBandwidthReturns(result1 error) {
+	fake.LimitBandwidthStub = nil
+	fake.limitBandwidthReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetworker) BandwidthLimits(log lager.Logger, handle string) (garden.BandwidthLimits, error) {
+	fake.bandwidthLimitsMutex.Lock()
+	fake.bandwidthLimitsArgsForCall = append(fake.bandwidthLimitsArgsForCall, struct {
+		log    lager.Logger
+		handle string
+	}{log, handle})
+	fake.recordInvocation("BandwidthLimits", []interface{}{log, handle})
+	fake.bandwidthLimitsMutex.Unlock()
+	if fake.BandwidthLimitsStub != nil {
+		return fake.BandwidthLimitsStub(log, handle)
+	} else {
+		return fake.bandwidthLimitsReturns.result1, fake.bandwidthLimitsReturns.result2
+	}
+}
+
+func (fake *FakeNetworker) BandwidthLimitsCallCount() int {
+	fake.bandwidthLimitsMutex.RLock()
+	defer fake.bandwidthLimitsMutex.RUnlock()
+	return len(fake.bandwidthLimitsArgsForCall)
+}
+
+func (fake *FakeNetworker) BandwidthLimitsArgsForCall(i int) (lager.Logger, string) {
+	fake.bandwidthLimitsMutex.RLock()
+	defer fake.bandwidthLimitsMutex.RUnlock()
+	return fake.bandwidthLimitsArgsForCall[i].log, fake.bandwidthLimitsArgsForCall[i].handle
+}
+
+func (fake *FakeNetworker) BandwidthLimitsReturns(result1 garden.BandwidthLimits, result2 error) {
+	fake.BandwidthLimitsStub = nil
+	fake.bandwidthLimitsReturns = struct {
+		result1 garden.BandwidthLimits
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNetworker) Reconfigure(log lager.Logger, handle string, pid int) error {
+	fake.reconfigureMutex.Lock()
+	fake.reconfigureArgsForCall = append(fake.reconfigureArgsForCall, struct {
+		log    lager.Logger
+		handle string
+		pid    int
+	}{log, handle, pid})
+	fake.recordInvocation("Reconfigure", []interface{}{log, handle, pid})
+	fake.reconfigureMutex.Unlock()
+	if fake.ReconfigureStub != nil {
+		return fake.ReconfigureStub(log, handle, pid)
+	} else {
+		return fake.reconfigureReturns.result1
+	}
+}
+
+func (fake *FakeNetworker) ReconfigureCallCount() int {
+	fake.reconfigureMutex.RLock()
+	defer fake.reconfigureMutex.RUnlock()
+	return len(fake.reconfigureArgsForCall)
+}
+
+func (fake *FakeNetworker) ReconfigureArgsForCall(i int) (lager.Logger, string, int) {
+	fake.reconfigureMutex.RLock()
+	defer fake.reconfigureMutex.RUnlock()
+	return fake.reconfigureArgsForCall[i].log, fake.reconfigureArgsForCall[i].handle, fake.reconfigureArgsForCall[i].pid
+}
+
+func (fake *FakeNetworker) ReconfigureReturns(result1 error) {
+	fake.ReconfigureStub = nil
+	fake.reconfigureReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeNetworker) Restore(log lager.Logger, handle string) error {
 	fake.restoreMutex.Lock()
 	fake.restoreArgsForCall = append(fake.restoreArgsForCall, struct {
@@ -337,6 +472,12 @@ func (fake *FakeNetworker) Invocations() map[string][][]interface{} {
 	defer fake.bulkNetOutMutex.RUnlock()
 	fake.netOutMutex.RLock()
 	defer fake.netOutMutex.RUnlock()
+	fake.limitBandwidthMutex.RLock()
+	defer fake.limitBandwidthMutex.RUnlock()
+	fake.bandwidthLimitsMutex.RLock()
+	defer fake.bandwidthLimitsMutex.RUnlock()
+	fake.reconfigureMutex.RLock()
+	defer fake.reconfigureMutex.RUnlock()
 	fake.restoreMutex.RLock()
 	defer fake.restoreMutex.RUnlock()
 	return fake.invocations
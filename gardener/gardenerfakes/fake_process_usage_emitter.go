@@ -0,0 +1,64 @@
+// This file was generated by counterfeiter
+package gardenerfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/guardian/gardener"
+)
+
+type FakeProcessUsageEmitter struct {
+	EmitProcessUsageStub        func(usage gardener.ResourceUsage)
+	emitProcessUsageMutex       sync.RWMutex
+	emitProcessUsageArgsForCall []struct {
+		usage gardener.ResourceUsage
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeProcessUsageEmitter) EmitProcessUsage(usage gardener.ResourceUsage) {
+	fake.emitProcessUsageMutex.Lock()
+	fake.emitProcessUsageArgsForCall = append(fake.emitProcessUsageArgsForCall, struct {
+		usage gardener.ResourceUsage
+	}{usage})
+	fake.recordInvocation("EmitProcessUsage", []interface{}{usage})
+	fake.emitProcessUsageMutex.Unlock()
+	if fake.EmitProcessUsageStub != nil {
+		fake.EmitProcessUsageStub(usage)
+	}
+}
+
+func (fake *FakeProcessUsageEmitter) EmitProcessUsageCallCount() int {
+	fake.emitProcessUsageMutex.RLock()
+	defer fake.emitProcessUsageMutex.RUnlock()
+	return len(fake.emitProcessUsageArgsForCall)
+}
+
+func (fake *FakeProcessUsageEmitter) EmitProcessUsageArgsForCall(i int) gardener.ResourceUsage {
+	fake.emitProcessUsageMutex.RLock()
+	defer fake.emitProcessUsageMutex.RUnlock()
+	return fake.emitProcessUsageArgsForCall[i].usage
+}
+
+func (fake *FakeProcessUsageEmitter) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.emitProcessUsageMutex.RLock()
+	defer fake.emitProcessUsageMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeProcessUsageEmitter) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ gardener.ProcessUsageEmitter = new(FakeProcessUsageEmitter)
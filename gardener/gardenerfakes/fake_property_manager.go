@@ -44,14 +44,15 @@ type FakePropertyManager struct {
 		result1 string
 		result2 bool
 	}
-	MatchesAllStub        func(handle string, props garden.Properties) bool
-	matchesAllMutex       sync.RWMutex
-	matchesAllArgsForCall []struct {
-		handle string
-		props  garden.Properties
+	FilterStub        func(handles []string, props garden.Properties) ([]string, error)
+	filterMutex       sync.RWMutex
+	filterArgsForCall []struct {
+		handles []string
+		props   garden.Properties
 	}
-	matchesAllReturns struct {
-		result1 bool
+	filterReturns struct {
+		result1 []string
+		result2 error
 	}
 	DestroyKeySpaceStub        func(string) error
 	destroyKeySpaceMutex       sync.RWMutex
@@ -194,38 +195,44 @@ func (fake *FakePropertyManager) GetReturns(result1 string, result2 bool) {
 	}{result1, result2}
 }
 
-func (fake *FakePropertyManager) MatchesAll(handle string, props garden.Properties) bool {
-	fake.matchesAllMutex.Lock()
-	fake.matchesAllArgsForCall = append(fake.matchesAllArgsForCall, struct {
-		handle string
-		props  garden.Properties
-	}{handle, props})
-	fake.recordInvocation("MatchesAll", []interface{}{handle, props})
-	fake.matchesAllMutex.Unlock()
-	if fake.MatchesAllStub != nil {
-		return fake.MatchesAllStub(handle, props)
+func (fake *FakePropertyManager) Filter(handles []string, props garden.Properties) ([]string, error) {
+	var handlesCopy []string
+	if handles != nil {
+		handlesCopy = make([]string, len(handles))
+		copy(handlesCopy, handles)
+	}
+	fake.filterMutex.Lock()
+	fake.filterArgsForCall = append(fake.filterArgsForCall, struct {
+		handles []string
+		props   garden.Properties
+	}{handlesCopy, props})
+	fake.recordInvocation("Filter", []interface{}{handlesCopy, props})
+	fake.filterMutex.Unlock()
+	if fake.FilterStub != nil {
+		return fake.FilterStub(handles, props)
 	} else {
-		return fake.matchesAllReturns.result1
+		return fake.filterReturns.result1, fake.filterReturns.result2
 	}
 }
 
-func (fake *FakePropertyManager) MatchesAllCallCount() int {
-	fake.matchesAllMutex.RLock()
-	defer fake.matchesAllMutex.RUnlock()
-	return len(fake.matchesAllArgsForCall)
+func (fake *FakePropertyManager) FilterCallCount() int {
+	fake.filterMutex.RLock()
+	defer fake.filterMutex.RUnlock()
+	return len(fake.filterArgsForCall)
 }
 
-func (fake *FakePropertyManager) MatchesAllArgsForCall(i int) (string, garden.Properties) {
-	fake.matchesAllMutex.RLock()
-	defer fake.matchesAllMutex.RUnlock()
-	return fake.matchesAllArgsForCall[i].handle, fake.matchesAllArgsForCall[i].props
+func (fake *FakePropertyManager) FilterArgsForCall(i int) ([]string, garden.Properties) {
+	fake.filterMutex.RLock()
+	defer fake.filterMutex.RUnlock()
+	return fake.filterArgsForCall[i].handles, fake.filterArgsForCall[i].props
 }
 
-func (fake *FakePropertyManager) MatchesAllReturns(result1 bool) {
-	fake.MatchesAllStub = nil
-	fake.matchesAllReturns = struct {
-		result1 bool
-	}{result1}
+func (fake *FakePropertyManager) FilterReturns(result1 []string, result2 error) {
+	fake.FilterStub = nil
+	fake.filterReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
 }
 
 func (fake *FakePropertyManager) DestroyKeySpace(arg1 string) error {
@@ -272,8 +279,8 @@ func (fake *FakePropertyManager) Invocations() map[string][][]interface{} {
 	defer fake.removeMutex.RUnlock()
 	fake.getMutex.RLock()
 	defer fake.getMutex.RUnlock()
-	fake.matchesAllMutex.RLock()
-	defer fake.matchesAllMutex.RUnlock()
+	fake.filterMutex.RLock()
+	defer fake.filterMutex.RUnlock()
 	fake.destroyKeySpaceMutex.RLock()
 	defer fake.destroyKeySpaceMutex.RUnlock()
 	return fake.invocations
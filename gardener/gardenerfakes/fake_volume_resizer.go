@@ -0,0 +1,133 @@
+// This file was generated by counterfeiter
+package gardenerfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/lager"
+)
+
+type FakeVolumeResizer struct {
+	ResizeStub        func(log lager.Logger, handle string, namespaced bool, limits garden.DiskLimits) error
+	resizeMutex       sync.RWMutex
+	resizeArgsForCall []struct {
+		log        lager.Logger
+		handle     string
+		namespaced bool
+		limits     garden.DiskLimits
+	}
+	resizeReturns struct {
+		result1 error
+	}
+	DiskLimitsStub        func(log lager.Logger, handle string, namespaced bool) (garden.DiskLimits, error)
+	diskLimitsMutex       sync.RWMutex
+	diskLimitsArgsForCall []struct {
+		log        lager.Logger
+		handle     string
+		namespaced bool
+	}
+	diskLimitsReturns struct {
+		result1 garden.DiskLimits
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeVolumeResizer) Resize(log lager.Logger, handle string, namespaced bool, limits garden.DiskLimits) error {
+	fake.resizeMutex.Lock()
+	fake.resizeArgsForCall = append(fake.resizeArgsForCall, struct {
+		log        lager.Logger
+		handle     string
+		namespaced bool
+		limits     garden.DiskLimits
+	}{log, handle, namespaced, limits})
+	fake.recordInvocation("Resize", []interface{}{log, handle, namespaced, limits})
+	fake.resizeMutex.Unlock()
+	if fake.ResizeStub != nil {
+		return fake.ResizeStub(log, handle, namespaced, limits)
+	} else {
+		return fake.resizeReturns.result1
+	}
+}
+
+func (fake *FakeVolumeResizer) ResizeCallCount() int {
+	fake.resizeMutex.RLock()
+	defer fake.resizeMutex.RUnlock()
+	return len(fake.resizeArgsForCall)
+}
+
+func (fake *FakeVolumeResizer) ResizeArgsForCall(i int) (lager.Logger, string, bool, garden.DiskLimits) {
+	fake.resizeMutex.RLock()
+	defer fake.resizeMutex.RUnlock()
+	return fake.resizeArgsForCall[i].log, fake.resizeArgsForCall[i].handle, fake.resizeArgsForCall[i].namespaced, fake.resizeArgsForCall[i].limits
+}
+
+func (fake *FakeVolumeResizer) ResizeReturns(result1 error) {
+	fake.ResizeStub = nil
+	fake.resizeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolumeResizer) DiskLimits(log lager.Logger, handle string, namespaced bool) (garden.DiskLimits, error) {
+	fake.diskLimitsMutex.Lock()
+	fake.diskLimitsArgsForCall = append(fake.diskLimitsArgsForCall, struct {
+		log        lager.Logger
+		handle     string
+		namespaced bool
+	}{log, handle, namespaced})
+	fake.recordInvocation("DiskLimits", []interface{}{log, handle, namespaced})
+	fake.diskLimitsMutex.Unlock()
+	if fake.DiskLimitsStub != nil {
+		return fake.DiskLimitsStub(log, handle, namespaced)
+	} else {
+		return fake.diskLimitsReturns.result1, fake.diskLimitsReturns.result2
+	}
+}
+
+func (fake *FakeVolumeResizer) DiskLimitsCallCount() int {
+	fake.diskLimitsMutex.RLock()
+	defer fake.diskLimitsMutex.RUnlock()
+	return len(fake.diskLimitsArgsForCall)
+}
+
+func (fake *FakeVolumeResizer) DiskLimitsArgsForCall(i int) (lager.Logger, string, bool) {
+	fake.diskLimitsMutex.RLock()
+	defer fake.diskLimitsMutex.RUnlock()
+	return fake.diskLimitsArgsForCall[i].log, fake.diskLimitsArgsForCall[i].handle, fake.diskLimitsArgsForCall[i].namespaced
+}
+
+func (fake *FakeVolumeResizer) DiskLimitsReturns(result1 garden.DiskLimits, result2 error) {
+	fake.DiskLimitsStub = nil
+	fake.diskLimitsReturns = struct {
+		result1 garden.DiskLimits
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVolumeResizer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.resizeMutex.RLock()
+	defer fake.resizeMutex.RUnlock()
+	fake.diskLimitsMutex.RLock()
+	defer fake.diskLimitsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeVolumeResizer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ gardener.VolumeResizer = new(FakeVolumeResizer)
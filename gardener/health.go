@@ -0,0 +1,202 @@
+package gardener
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pivotal-golang/clock"
+)
+
+//go:generate counterfeiter . HealthCheck
+//go:generate counterfeiter . HealthChecker
+
+// HealthCheck checks that one of the server's dependencies is usable
+type HealthCheck interface {
+	Check() error
+}
+
+// HealthChecker decides whether the server is healthy, it backs Ping
+type HealthChecker interface {
+	Check() error
+}
+
+type HealthResult struct {
+	Name      string        `json:"name"`
+	Healthy   bool          `json:"healthy"`
+	Error     string        `json:"error,omitempty"`
+	Duration  time.Duration `json:"duration"`
+	CheckedAt time.Time     `json:"checked_at"`
+}
+
+// ErrCheckStillRunning is reported for a check which has not yet finished
+// since it last timed out, so that a wedged check does not pile up goroutines
+var ErrCheckStillRunning = errors.New("previous check has not finished")
+
+// HealthChecks runs a set of named health checks concurrently, each with a
+// timeout. Results are cached so that frequent pings do not hammer the host.
+type HealthChecks struct {
+	clock    clock.Clock
+	timeout  time.Duration
+	cacheFor time.Duration
+	checks   map[string]HealthCheck
+
+	mu       sync.Mutex
+	results  []HealthResult
+	checking chan struct{}
+
+	inFlightMu sync.Mutex
+	inFlight   map[string]bool
+}
+
+func NewHealthChecks(clock clock.Clock, timeout, cacheFor time.Duration, checks map[string]HealthCheck) *HealthChecks {
+	return &HealthChecks{
+		clock:    clock,
+		timeout:  timeout,
+		cacheFor: cacheFor,
+		checks:   checks,
+		inFlight: make(map[string]bool),
+	}
+}
+
+// Check returns an error naming every failing check
+func (h *HealthChecks) Check() error {
+	var failures []string
+	for _, result := range h.Results() {
+		if !result.Healthy {
+			failures = append(failures, fmt.Sprintf("%s: %s", result.Name, result.Error))
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("unhealthy: %s", strings.Join(failures, ", "))
+	}
+
+	return nil
+}
+
+// Results returns the result of every check sorted by name, running the
+// checks again if the cached results have expired. The checks run without
+// holding the lock, and callers which arrive while they run are given the
+// expired results rather than waiting, unless there are none yet.
+func (h *HealthChecks) Results() []HealthResult {
+	h.mu.Lock()
+
+	if len(h.results) > 0 && h.clock.Since(h.results[0].CheckedAt) < h.cacheFor {
+		results := h.results
+		h.mu.Unlock()
+		return results
+	}
+
+	if h.checking != nil {
+		results, checking := h.results, h.checking
+		h.mu.Unlock()
+
+		if len(results) > 0 {
+			return results
+		}
+
+		<-checking
+		return h.cachedResults()
+	}
+
+	checking := make(chan struct{})
+	h.checking = checking
+	h.mu.Unlock()
+
+	results := h.runAll()
+
+	h.mu.Lock()
+	h.results = results
+	h.checking = nil
+	h.mu.Unlock()
+
+	close(checking)
+	return results
+}
+
+func (h *HealthChecks) cachedResults() []HealthResult {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.results
+}
+
+func (h *HealthChecks) runAll() []HealthResult {
+	names := make([]string, 0, len(h.checks))
+	for name := range h.checks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	checkedAt := h.clock.Now()
+	results := make([]HealthResult, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+
+			err := h.run(name)
+			results[i] = HealthResult{
+				Name:      name,
+				Healthy:   err == nil,
+				Duration:  h.clock.Since(checkedAt),
+				CheckedAt: checkedAt,
+			}
+			if err != nil {
+				results[i].Error = err.Error()
+			}
+		}(i, name)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// run runs the named check, giving up after the timeout. A check which times
+// out carries on in the background and is not run again until it finishes.
+func (h *HealthChecks) run(name string) error {
+	if !h.start(name) {
+		return ErrCheckStillRunning
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- h.checks[name].Check()
+		h.finish(name)
+	}()
+
+	timer := h.clock.NewTimer(h.timeout)
+	defer timer.Stop()
+
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C():
+		return fmt.Errorf("timed out after %s", h.timeout)
+	}
+}
+
+func (h *HealthChecks) start(name string) bool {
+	h.inFlightMu.Lock()
+	defer h.inFlightMu.Unlock()
+
+	if h.inFlight[name] {
+		return false
+	}
+
+	h.inFlight[name] = true
+	return true
+}
+
+func (h *HealthChecks) finish(name string) {
+	h.inFlightMu.Lock()
+	defer h.inFlightMu.Unlock()
+
+	delete(h.inFlight, name)
+}
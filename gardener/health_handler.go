@@ -0,0 +1,35 @@
+package gardener
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type HealthReporter interface {
+	Results() []HealthResult
+}
+
+type healthHandler struct {
+	reporter HealthReporter
+}
+
+// NewHealthHandler serves the result of each health check as JSON. The status
+// is 503 Service Unavailable when any check is failing.
+func NewHealthHandler(reporter HealthReporter) http.Handler {
+	return &healthHandler{reporter: reporter}
+}
+
+func (h *healthHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	results := h.reporter.Results()
+
+	status := http.StatusOK
+	for _, result := range results {
+		if !result.Healthy {
+			status = http.StatusServiceUnavailable
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(results)
+}
@@ -0,0 +1,199 @@
+package gardener_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	fakes "code.cloudfoundry.org/guardian/gardener/gardenerfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock"
+	"github.com/pivotal-golang/clock/fakeclock"
+)
+
+var _ = Describe("HealthChecks", func() {
+	var (
+		fakeClock *fakeclock.FakeClock
+		depot     *fakes.FakeHealthCheck
+		runc      *fakes.FakeHealthCheck
+		checks    *gardener.HealthChecks
+	)
+
+	BeforeEach(func() {
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+		depot = new(fakes.FakeHealthCheck)
+		runc = new(fakes.FakeHealthCheck)
+
+		checks = gardener.NewHealthChecks(fakeClock, time.Second, 10*time.Second, map[string]gardener.HealthCheck{
+			"depot": depot,
+			"runc":  runc,
+		})
+	})
+
+	It("succeeds when every check passes", func() {
+		Expect(checks.Check()).To(Succeed())
+		Expect(depot.CheckCallCount()).To(Equal(1))
+		Expect(runc.CheckCallCount()).To(Equal(1))
+	})
+
+	It("names each failing check in the error", func() {
+		runc.CheckReturns(errors.New("executable file not found"))
+		Expect(checks.Check()).To(MatchError("unhealthy: runc: executable file not found"))
+	})
+
+	It("reports the result of every check, sorted by name", func() {
+		runc.CheckReturns(errors.New("missing"))
+
+		results := checks.Results()
+		Expect(results).To(HaveLen(2))
+		Expect(results[0].Name).To(Equal("depot"))
+		Expect(results[0].Healthy).To(BeTrue())
+		Expect(results[1].Name).To(Equal("runc"))
+		Expect(results[1].Healthy).To(BeFalse())
+		Expect(results[1].Error).To(Equal("missing"))
+		Expect(results[1].CheckedAt).To(Equal(time.Unix(123, 0)))
+	})
+
+	It("caches the results", func() {
+		checks.Check()
+		fakeClock.Increment(9 * time.Second)
+		checks.Check()
+
+		Expect(depot.CheckCallCount()).To(Equal(1))
+	})
+
+	It("runs the checks again once the cache expires", func() {
+		checks.Check()
+		fakeClock.Increment(10 * time.Second)
+		checks.Check()
+
+		Expect(depot.CheckCallCount()).To(Equal(2))
+	})
+
+	Context("while the checks are running", func() {
+		var unblock chan struct{}
+
+		BeforeEach(func() {
+			unblock = make(chan struct{})
+		})
+
+		blockDepot := func() {
+			depot.CheckStub = func() error {
+				<-unblock
+				return nil
+			}
+		}
+
+		It("gives callers the expired results rather than making them wait", func() {
+			Expect(checks.Check()).To(Succeed())
+			fakeClock.Increment(10 * time.Second)
+			blockDepot()
+
+			done := make(chan []gardener.HealthResult)
+			go func() {
+				done <- checks.Results()
+			}()
+			Eventually(depot.CheckCallCount).Should(Equal(2))
+
+			results := checks.Results()
+			Expect(results[0].CheckedAt).To(Equal(time.Unix(123, 0)))
+
+			close(unblock)
+			Expect((<-done)[0].CheckedAt).To(Equal(time.Unix(133, 0)))
+			Expect(depot.CheckCallCount()).To(Equal(2))
+		})
+
+		It("makes callers wait for the first results, which they share", func() {
+			blockDepot()
+
+			done := make(chan []gardener.HealthResult, 2)
+			for i := 0; i < 2; i++ {
+				go func() {
+					done <- checks.Results()
+				}()
+			}
+			Eventually(depot.CheckCallCount).Should(Equal(1))
+			Consistently(done).ShouldNot(Receive())
+
+			close(unblock)
+			Eventually(done).Should(Receive(HaveLen(2)))
+			Eventually(done).Should(Receive(HaveLen(2)))
+			Expect(depot.CheckCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when a check takes longer than the timeout", func() {
+		var unblock chan struct{}
+
+		BeforeEach(func() {
+			unblock = make(chan struct{})
+			runc.CheckStub = func() error {
+				<-unblock
+				return nil
+			}
+
+			checks = gardener.NewHealthChecks(clock.NewClock(), 10*time.Millisecond, 0, map[string]gardener.HealthCheck{
+				"depot": depot,
+				"runc":  runc,
+			})
+		})
+
+		AfterEach(func() {
+			close(unblock)
+		})
+
+		It("fails the check", func() {
+			Expect(checks.Check()).To(MatchError("unhealthy: runc: timed out after 10ms"))
+		})
+
+		It("does not run the check again until it has finished", func() {
+			checks.Check()
+			Expect(checks.Check()).To(MatchError(ContainSubstring(gardener.ErrCheckStillRunning.Error())))
+			Expect(runc.CheckCallCount()).To(Equal(1))
+		})
+	})
+})
+
+var _ = Describe("HealthHandler", func() {
+	var (
+		check   *fakes.FakeHealthCheck
+		handler http.Handler
+	)
+
+	BeforeEach(func() {
+		check = new(fakes.FakeHealthCheck)
+		handler = gardener.NewHealthHandler(gardener.NewHealthChecks(clock.NewClock(), time.Second, 0, map[string]gardener.HealthCheck{
+			"iptables-lock": check,
+		}))
+	})
+
+	It("serves the results as JSON", func() {
+		recorder := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/debug/health", nil)
+		Expect(err).NotTo(HaveOccurred())
+		handler.ServeHTTP(recorder, req)
+
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		var results []gardener.HealthResult
+		Expect(json.NewDecoder(recorder.Body).Decode(&results)).To(Succeed())
+		Expect(results).To(HaveLen(1))
+		Expect(results[0].Name).To(Equal("iptables-lock"))
+		Expect(results[0].Healthy).To(BeTrue())
+	})
+
+	It("responds with 503 when a check fails", func() {
+		check.CheckReturns(errors.New("wedged"))
+
+		recorder := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", "/debug/health", nil)
+		Expect(err).NotTo(HaveOccurred())
+		handler.ServeHTTP(recorder, req)
+
+		Expect(recorder.Code).To(Equal(http.StatusServiceUnavailable))
+	})
+})
@@ -0,0 +1,117 @@
+package gardener
+
+import (
+	"fmt"
+	"sync"
+)
+
+// StateKey is the property which records where a container is in its
+// lifecycle
+const StateKey = "garden.state"
+
+const (
+	StateCreating   = "creating"
+	StateCreated    = "created"
+	StateStopping   = "stopping"
+	StateDestroying = "destroying"
+	StateFailed     = "failed"
+)
+
+// stateTransitions lists the states which each state may move to. A container
+// may always be destroyed once it is no longer being created, however it got
+// stuck, so that it can be cleaned up. A create which fails cleans up after
+// itself, and one which a restart interrupted is failed on start up.
+var stateTransitions = map[string][]string{
+	StateCreating:   {StateCreated, StateFailed},
+	StateCreated:    {StateStopping, StateDestroying},
+	StateStopping:   {StateCreated, StateFailed, StateDestroying},
+	StateDestroying: {StateDestroying, StateFailed},
+	StateFailed:     {StateDestroying},
+}
+
+type lifecycle struct {
+	propertyManager PropertyManager
+	locks           *handleLocks
+}
+
+// begin records that the container is being created
+func (l lifecycle) begin(handle string) {
+	defer l.locks.lock(handle)()
+
+	l.propertyManager.Set(handle, StateKey, StateCreating)
+}
+
+// transition moves the container to the given state. Containers created
+// before states were recorded have none, and may move to any state.
+func (l lifecycle) transition(handle, to string) error {
+	defer l.locks.lock(handle)()
+
+	from, _ := l.propertyManager.Get(handle, StateKey)
+	if from != "" && !contains(stateTransitions[from], to) {
+		return fmt.Errorf("container %s is %s, cannot move to %s", handle, from, to)
+	}
+
+	l.propertyManager.Set(handle, StateKey, to)
+	return nil
+}
+
+// fail records that the container is stuck, this is always allowed
+func (l lifecycle) fail(handle string) {
+	defer l.locks.lock(handle)()
+
+	l.propertyManager.Set(handle, StateKey, StateFailed)
+}
+
+// failInterrupted fails a container which was being created when the server
+// went away, since nothing will finish creating it
+func (l lifecycle) failInterrupted(handle string) bool {
+	defer l.locks.lock(handle)()
+
+	if state, _ := l.propertyManager.Get(handle, StateKey); state != StateCreating {
+		return false
+	}
+
+	l.propertyManager.Set(handle, StateKey, StateFailed)
+	return true
+}
+
+// handleLocks serialises the transitions of each container, so that two
+// transitions cannot both see the same state and move on from it
+type handleLocks struct {
+	mu    sync.Mutex
+	locks map[string]*handleLock
+}
+
+type handleLock struct {
+	sync.Mutex
+	users int
+}
+
+// lock locks the handle and returns a function which unlocks it
+func (h *handleLocks) lock(handle string) func() {
+	h.mu.Lock()
+	if h.locks == nil {
+		h.locks = make(map[string]*handleLock)
+	}
+
+	l, ok := h.locks[handle]
+	if !ok {
+		l = &handleLock{}
+		h.locks[handle] = l
+	}
+	l.users++
+	h.mu.Unlock()
+
+	l.Lock()
+	return func() {
+		l.Unlock()
+
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		l.users--
+		if l.users == 0 {
+			delete(h.locks, handle)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package gardener
+
+import (
+	"fmt"
+	"sync"
+)
+
+// handleReservations holds the handles of containers created by this server
+// which have not yet been destroyed. A handle is reserved atomically before
+// any resources are created for it, so that concurrent creates with the same
+// handle cannot both go ahead and then clean up each other's resources.
+type handleReservations struct {
+	mu      sync.Mutex
+	handles map[string]struct{}
+}
+
+func (r *handleReservations) reserve(handle string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.handles[handle]; ok {
+		return fmt.Errorf("Handle '%s' already in use", handle)
+	}
+
+	if r.handles == nil {
+		r.handles = make(map[string]struct{})
+	}
+
+	r.handles[handle] = struct{}{}
+	return nil
+}
+
+func (r *handleReservations) release(handle string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.handles, handle)
+}
+
+func (r *handleReservations) list() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	handles := make([]string, 0, len(r.handles))
+	for handle := range r.handles {
+		handles = append(handles, handle)
+	}
+
+	return handles
+}
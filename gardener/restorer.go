@@ -3,12 +3,14 @@ package gardener
 import "code.cloudfoundry.org/lager"
 
 type restorer struct {
-	networker Networker
+	networker     Networker
+	containerizer Containerizer
 }
 
-func NewRestorer(networker Networker) Restorer {
+func NewRestorer(networker Networker, containerizer Containerizer) Restorer {
 	return &restorer{
-		networker: networker,
+		networker:     networker,
+		containerizer: containerizer,
 	}
 }
 
@@ -22,7 +24,10 @@ func (r *restorer) Restore(logger lager.Logger, handles []string) []string {
 		if err != nil {
 			log.Error("failed-restoring-container", err)
 			failedHandles = append(failedHandles, handle)
+			continue
 		}
+
+		r.containerizer.WatchEvents(logger, handle)
 	}
 
 	return failedHandles
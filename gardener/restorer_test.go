@@ -14,14 +14,16 @@ import (
 
 var _ = Describe("Restorer", func() {
 	var (
-		fakeNetworker *fakes.FakeNetworker
-		restorer      gardener.Restorer
-		logger        lager.Logger
+		fakeNetworker     *fakes.FakeNetworker
+		fakeContainerizer *fakes.FakeContainerizer
+		restorer          gardener.Restorer
+		logger            lager.Logger
 	)
 
 	BeforeEach(func() {
 		fakeNetworker = new(fakes.FakeNetworker)
-		restorer = gardener.NewRestorer(fakeNetworker)
+		fakeContainerizer = new(fakes.FakeContainerizer)
+		restorer = gardener.NewRestorer(fakeNetworker, fakeContainerizer)
 		logger = lagertest.NewTestLogger("test")
 	})
 
@@ -47,5 +49,22 @@ var _ = Describe("Restorer", func() {
 
 			Expect(restorer.Restore(logger, []string{"foo", "bar"})).To(Equal([]string{"bar"}))
 		})
+
+		It("starts watching each restored container for events", func() {
+			Expect(restorer.Restore(logger, []string{"foo", "bar"})).To(BeEmpty())
+
+			Expect(fakeContainerizer.WatchEventsCallCount()).To(Equal(2))
+			_, handle := fakeContainerizer.WatchEventsArgsForCall(0)
+			Expect(handle).To(Equal("foo"))
+			_, handle = fakeContainerizer.WatchEventsArgsForCall(1)
+			Expect(handle).To(Equal("bar"))
+		})
+
+		It("does not watch containers that it can't restore", func() {
+			fakeNetworker.RestoreReturns(errors.New("banana"))
+
+			restorer.Restore(logger, []string{"foo"})
+			Expect(fakeContainerizer.WatchEventsCallCount()).To(Equal(0))
+		})
 	})
 })
@@ -0,0 +1,15 @@
+package gardener
+
+import "code.cloudfoundry.org/garden"
+
+// garden only names SIGTERM (0) and SIGKILL (1). These are the other signals
+// which processes can be sent, an extension to the Garden API which is
+// documented in the README. Their values are what clients put on the wire, so
+// they must never be renumbered.
+const (
+	SignalHangup    garden.Signal = 2
+	SignalInterrupt garden.Signal = 3
+	SignalQuit      garden.Signal = 4
+	SignalUser1     garden.Signal = 5
+	SignalUser2     garden.Signal = 6
+)
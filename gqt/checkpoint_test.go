@@ -0,0 +1,66 @@
+package gqt_test
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gqt/runner"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Checkpointing a container", func() {
+	var (
+		client    *runner.RunningGarden
+		container garden.Container
+		debugAddr string
+	)
+
+	BeforeEach(func() {
+		fakeRuncBinPath, err := gexec.Build("code.cloudfoundry.org/guardian/gqt/cmd/fake_runc")
+		Expect(err).NotTo(HaveOccurred())
+
+		debugAddr = fmt.Sprintf("127.0.0.1:%d", 8080+GinkgoParallelNode())
+		client = startGarden("--runc-bin", fakeRuncBinPath, "--runtime-arg", "runc:--fake-checkpoint", "--debug-bind-ip", "127.0.0.1", "--debug-bind-port", fmt.Sprintf("%d", 8080+GinkgoParallelNode()))
+
+		container, err = client.Create(garden.ContainerSpec{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.DestroyAndStop()).To(Succeed())
+	})
+
+	post := func(path string) int {
+		res, err := http.Post(fmt.Sprintf("http://%s%s", debugAddr, path), "", nil)
+		Expect(err).NotTo(HaveOccurred())
+		defer res.Body.Close()
+
+		return res.StatusCode
+	}
+
+	It("checkpoints the container into its bundle and restores it through the debug server", func() {
+		Expect(post("/debug/checkpoint?handle=" + container.Handle())).To(Equal(http.StatusOK))
+		Expect(filepath.Join(client.DepotDir, container.Handle(), "checkpoint", "fake-checkpoint")).To(BeAnExistingFile())
+
+		Expect(post("/debug/restore?handle=" + container.Handle())).To(Equal(http.StatusOK))
+
+		stdout := gbytes.NewBuffer()
+		process, err := container.Run(garden.ProcessSpec{
+			Path: "echo",
+			Args: []string{"restored"},
+		}, garden.ProcessIO{Stdout: stdout})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(process.Wait()).To(Equal(0))
+		Expect(stdout).To(gbytes.Say("restored"))
+	})
+
+	It("responds with 404 for a container which does not exist", func() {
+		Expect(post("/debug/checkpoint?handle=not-a-container")).To(Equal(http.StatusNotFound))
+	})
+})
@@ -1,12 +1,36 @@
 package main
 
 import (
+	"fmt"
+	"io/ioutil"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"syscall"
 
 	"github.com/Sirupsen/logrus"
 )
 
+// fake_runc writes some test messages to runc's log and exits, unless it is
+// given the global --fake-checkpoint flag. It then stands in for runc's
+// checkpoint and restore, which need CRIU on the host, and passes every other
+// command through to runc. Checkpointing writes a marker to the image path
+// and deletes the container, and restoring runs the container from its bundle
+// again if the marker is there.
+
+const (
+	fakeCheckpointFlag = "--fake-checkpoint"
+	marker             = "fake-checkpoint"
+)
+
 func main() {
+	args := os.Args[1:]
+	for i, arg := range args {
+		if arg == fakeCheckpointFlag {
+			os.Exit(fakeCheckpoint(append(append([]string{}, args[:i]...), args[i+1:]...)))
+		}
+	}
+
 	logPath := ""
 	for idx, s := range os.Args {
 		if s == "-log" || s == "--log" {
@@ -26,3 +50,78 @@ func main() {
 	logrus.Error("guardian-runc-logging-test-error")
 	logrus.Print("guardian-runc-logging-test-print")
 }
+
+func fakeCheckpoint(args []string) int {
+	runc, err := exec.LookPath("runc")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	for i, arg := range args {
+		switch arg {
+		case "checkpoint":
+			return checkpoint(runc, args[:i], args[i+1:])
+		case "restore":
+			return restore(runc, args[:i], args[i+1:])
+		}
+	}
+
+	err = syscall.Exec(runc, append([]string{runc}, args...), os.Environ())
+	fmt.Fprintln(os.Stderr, err)
+	return 1
+}
+
+func checkpoint(runc string, globalArgs, args []string) int {
+	imagePath, id := flagValue(args, "--image-path"), args[len(args)-1]
+
+	// CRIU cannot dump a network namespace whose veth peer is outside it
+	if flagValue(args, "--empty-ns") != "network" {
+		fmt.Fprintln(os.Stderr, "cannot dump the network namespace without --empty-ns network")
+		return 1
+	}
+
+	if err := os.MkdirAll(imagePath, 0700); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(imagePath, marker), []byte(id), 0600); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return run(runc, globalArgs, "delete", "--force", id)
+}
+
+func restore(runc string, globalArgs, args []string) int {
+	imagePath, bundlePath, id := flagValue(args, "--image-path"), flagValue(args, "--bundle"), args[len(args)-1]
+
+	if _, err := os.Stat(filepath.Join(imagePath, marker)); err != nil {
+		fmt.Fprintln(os.Stderr, "no checkpoint:", err)
+		return 1
+	}
+
+	return run(runc, globalArgs, "run", "--detach", "--bundle", bundlePath, id)
+}
+
+func flagValue(args []string, name string) string {
+	for i, arg := range args {
+		if arg == name && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+
+	return ""
+}
+
+func run(runc string, globalArgs []string, args ...string) int {
+	// the detached container must not hold on to our caller's output pipes
+	cmd := exec.Command(runc, append(globalArgs, args...)...)
+	if err := cmd.Run(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	return 0
+}
@@ -74,6 +74,28 @@ var _ = Describe("CPU Limits", func() {
 				Expect(err).NotTo(HaveOccurred())
 				Expect(strings.TrimSpace(string(period))).To(Equal("1280"))
 			})
+
+			Context("when the cpu limit is changed", func() {
+				JustBeforeEach(func() {
+					Expect(container.LimitCPU(garden.CPULimits{LimitInShares: 256})).To(Succeed())
+				})
+
+				It("updates cpu.shares and cpu.cfs_quota_us", func() {
+					shares, err := ioutil.ReadFile(filepath.Join(containerCpuCgroupPath, "cpu.shares"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(strings.TrimSpace(string(shares))).To(Equal("256"))
+
+					quota, err := ioutil.ReadFile(filepath.Join(containerCpuCgroupPath, "cpu.cfs_quota_us"))
+					Expect(err).NotTo(HaveOccurred())
+					Expect(strings.TrimSpace(string(quota))).To(Equal("2560"))
+				})
+
+				It("reports the new limit", func() {
+					limits, err := container.CurrentCPULimits()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(limits.LimitInShares).To(BeEquivalentTo(256))
+				})
+			})
 		})
 	})
 
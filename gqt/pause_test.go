@@ -0,0 +1,72 @@
+package gqt_test
+
+import (
+	"fmt"
+	"net/http"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gqt/runner"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Pausing a container", func() {
+	var (
+		client    *runner.RunningGarden
+		container garden.Container
+		debugAddr string
+	)
+
+	BeforeEach(func() {
+		debugAddr = fmt.Sprintf("127.0.0.1:%d", 8080+GinkgoParallelNode())
+		client = startGarden("--debug-bind-ip", "127.0.0.1", "--debug-bind-port", fmt.Sprintf("%d", 8080+GinkgoParallelNode()))
+
+		var err error
+		container, err = client.Create(garden.ContainerSpec{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(client.DestroyAndStop()).To(Succeed())
+	})
+
+	post := func(path string) int {
+		res, err := http.Post(fmt.Sprintf("http://%s%s", debugAddr, path), "", nil)
+		Expect(err).NotTo(HaveOccurred())
+		defer res.Body.Close()
+
+		return res.StatusCode
+	}
+
+	It("freezes the container until it is resumed through the debug server", func() {
+		Expect(post("/debug/pause?handle=" + container.Handle())).To(Equal(http.StatusOK))
+
+		info, err := container.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.State).To(Equal("paused"))
+
+		_, err = container.Run(garden.ProcessSpec{Path: "echo"}, garden.ProcessIO{})
+		Expect(err).To(MatchError(ContainSubstring("container is paused")))
+
+		Expect(post("/debug/resume?handle=" + container.Handle())).To(Equal(http.StatusOK))
+
+		info, err = container.Info()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(info.State).To(Equal("active"))
+
+		stdout := gbytes.NewBuffer()
+		process, err := container.Run(garden.ProcessSpec{
+			Path: "echo",
+			Args: []string{"resumed"},
+		}, garden.ProcessIO{Stdout: stdout})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(process.Wait()).To(Equal(0))
+		Expect(stdout).To(gbytes.Say("resumed"))
+	})
+
+	It("responds with 404 for a container which does not exist", func() {
+		Expect(post("/debug/pause?handle=not-a-container")).To(Equal(http.StatusNotFound))
+	})
+})
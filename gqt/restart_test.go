@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
 	"code.cloudfoundry.org/guardian/gqt/runner"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -90,7 +91,7 @@ var _ = Describe("Surviving Restarts", func() {
 			existingProc, err = container.Run(
 				garden.ProcessSpec{
 					Path: "/bin/sh",
-					Args: []string{"-c", "while true; do echo hello; sleep 1; done;"},
+					Args: []string{"-c", "trap 'exit 42' HUP; while true; do echo hello; sleep 1; done;"},
 				},
 				garden.ProcessIO{
 					Stdout: io.MultiWriter(GinkgoWriter, out),
@@ -231,6 +232,17 @@ var _ = Describe("Surviving Restarts", func() {
 					Expect(err).NotTo(HaveOccurred())
 				})
 
+				It("can send any supported signal to processes that are still running", func() {
+					process, err := container.Attach(existingProc.ID(), garden.ProcessIO{
+						Stdout: GinkgoWriter,
+						Stderr: GinkgoWriter,
+					})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(process.Signal(gardener.SignalHangup)).To(Succeed())
+					Expect(process.Wait()).To(Equal(42))
+				})
+
 				It("can still destroy the container", func() {
 					Expect(client.Destroy(container.Handle())).To(Succeed())
 				})
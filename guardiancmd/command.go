@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"code.cloudfoundry.org/idmapper"
@@ -26,6 +28,7 @@ import (
 	"code.cloudfoundry.org/garden/server"
 	"code.cloudfoundry.org/guardian/bindata"
 	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/healthcheck"
 	"code.cloudfoundry.org/guardian/imageplugin"
 	"code.cloudfoundry.org/guardian/kawasaki"
 	"code.cloudfoundry.org/guardian/kawasaki/dns"
@@ -151,17 +154,31 @@ type ServerCommand struct {
 
 		Tag       string `hidden:"true" long:"tag" description:"Optional 2-character identifier used for namespacing global configuration."`
 		SkipSetup bool   `long:"skip-setup" description:"Skip the preparation part of the host that requires root privileges"`
+
+		BulkParallelism   int           `long:"bulk-parallelism"    default:"8"   description:"Number of containers to query at once for bulk info and metrics requests."`
+		BulkHandleTimeout time.Duration `long:"bulk-handle-timeout" default:"30s" description:"Time after which a container is reported as failed in bulk info and metrics requests, or 0 to wait indefinitely."`
+
+		HealthCheckTimeout  time.Duration `long:"health-check-timeout"  default:"5s"  description:"Time after which a health check run by ping is reported as failed."`
+		HealthCheckCacheFor time.Duration `long:"health-check-cache-for" default:"10s" description:"Time for which ping reuses the results of the health checks."`
+
+		DrainTimeout time.Duration `long:"drain-timeout" default:"60s" description:"Time to wait for in-flight creates and destroys to finish when shutting down, or 0 to wait indefinitely."`
 	} `group:"Server Configuration"`
 
 	Containers struct {
-		Dir                string `long:"depot" default:"/var/run/gdn/depot" description:"Directory in which to store container data."`
-		PropertiesPath     string `long:"properties-path" description:"Path in which to store properties."`
-		ConsoleSocketsPath string `long:"console-sockets-path" description:"Path in which to store temporary sockets"`
+		Dir                    string `long:"depot" default:"/var/run/gdn/depot" description:"Directory in which to store container data."`
+		PropertiesPath         string `long:"properties-path" description:"Path in which to store properties."`
+		PropertiesCompactAfter int    `long:"properties-compact-after" default:"1000" description:"Number of property changes to journal before compacting them into the properties file."`
+		ConsoleSocketsPath     string `long:"console-sockets-path" description:"Path in which to store temporary sockets"`
 
 		DefaultRootFS              string        `long:"default-rootfs"     description:"Default rootfs to use when not specified on container creation."`
 		DefaultGraceTime           time.Duration `long:"default-grace-time" description:"Default time after which idle containers should expire."`
 		DestroyContainersOnStartup bool          `long:"destroy-containers-on-startup" description:"Clean up all the existing containers on startup."`
 		ApparmorProfile            string        `long:"apparmor" description:"Apparmor profile to use for unprivileged container processes"`
+		DefaultRuntime             string        `long:"default-runtime" default:"runc" description:"Name of the OCI runtime to use for containers which do not set the garden.runtime property."`
+		StopStrategy               string        `long:"stop-strategy" default:"freeze" choice:"freeze" choice:"signal" description:"How to stop container processes: 'freeze' signals them while their cgroup is frozen, falling back to 'signal', which signals them repeatedly until they exit."`
+		ExitStatusRetention        time.Duration `long:"exit-status-retention" default:"5m" description:"Time for which the exit status of a process is kept after it has been waited for, so that later attaches can see it. 0 forgets it straight away."`
+		OutputLogSegmentBytes      int64         `long:"process-output-log-segment-bytes" default:"0" description:"Size at which the logs of each process's output are rotated. The debug server's /debug/output endpoint replays the logged output from an offset. 0 disables the logs."`
+		OutputLogSegments          int           `long:"process-output-log-segments" default:"4" description:"Number of rotated segments of each process's output logs to keep."`
 	} `group:"Container Lifecycle"`
 
 	Bin struct {
@@ -173,6 +190,10 @@ type ServerCommand struct {
 		IPTablesRestore FileFlag `long:"iptables-restore-bin"  default:"/sbin/iptables-restore" description:"path to the iptables-restore binary"`
 		Init            FileFlag `long:"init-bin"       description:"Path execute as pid 1 inside each container."`
 		Runc            string   `long:"runc-bin"      default:"runc" description:"Path to the 'runc' binary."`
+		TC              string   `long:"tc-bin"        default:"tc" description:"Path to the 'tc' binary."`
+
+		Runtimes    map[string]string `long:"runtime-bin" description:"Name and path of an additional OCI runtime with a runc-compatible command line (e.g. crun), as name:path. Only runc's state directory is read directly; other runtimes are asked for their containers' state with their 'state' command. The 'runc' runtime is always registered using --runc-bin. Can be specified multiple times."`
+		RuntimeArgs []string          `long:"runtime-arg" description:"Global argument to pass to the named OCI runtime, as name:arg. Can be specified multiple times."`
 	} `group:"Binary Tools"`
 
 	Graph struct {
@@ -365,7 +386,11 @@ func (cmd *ServerCommand) Run(signals <-chan os.Signal, ready chan<- struct{}) e
 		return err
 	}
 
-	restorer := gardener.NewRestorer(networker)
+	eventBus := gardener.NewEventBus(propManager)
+
+	containerizer := cmd.wireContainerizer(logger, cmd.Containers.Dir, cmd.Bin.Dadoo.Path(), cmd.Bin.Runc, cmd.Bin.NSTar.Path(), cmd.Bin.Tar.Path(), cmd.Containers.ApparmorProfile, propManager, eventBus)
+
+	restorer := gardener.NewRestorer(networker, containerizer)
 	if cmd.Containers.DestroyContainersOnStartup {
 		restorer = &gardener.NoopRestorer{}
 	}
@@ -381,16 +406,36 @@ func (cmd *ServerCommand) Run(signals <-chan os.Signal, ready chan<- struct{}) e
 		bulkStarter = gardener.NoopBulkStarter
 	}
 
+	healthChecks := cmd.wireHealthChecks()
+
 	backend := &gardener.Gardener{
 		UidGenerator:    cmd.wireUidGenerator(),
 		BulkStarter:     bulkStarter,
 		SysInfoProvider: sysinfo.NewProvider(cmd.Containers.Dir),
 		Networker:       networker,
 		VolumeCreator:   volumeCreator,
-		Containerizer:   cmd.wireContainerizer(logger, cmd.Containers.Dir, cmd.Bin.Dadoo.Path(), cmd.Bin.Runc, cmd.Bin.NSTar.Path(), cmd.Bin.Tar.Path(), cmd.Containers.ApparmorProfile, propManager),
+		Containerizer:   containerizer,
 		PropertyManager: propManager,
 		MaxContainers:   cmd.Limits.MaxContainers,
 		Restorer:        restorer,
+		EventPublisher:  eventBus,
+
+		ProcessUsageEmitter: metrics.ProcessUsageEmitter{},
+
+		BulkParallelism:   cmd.Server.BulkParallelism,
+		BulkHandleTimeout: cmd.Server.BulkHandleTimeout,
+
+		HealthChecker: healthChecks,
+
+		DrainTimeout: cmd.Server.DrainTimeout,
+		Flushers: []gardener.Flusher{
+			gardener.FlusherFunc(func() error {
+				return cmd.saveProperties(logger, cmd.Containers.PropertiesPath, propManager)
+			}),
+			gardener.FlusherFunc(func() error {
+				return ports.SaveState(cmd.Network.PortPoolPropertiesPath, portPool.RefreshState())
+			}),
+		},
 
 		Logger: logger,
 	}
@@ -415,7 +460,18 @@ func (cmd *ServerCommand) Run(signals <-chan os.Signal, ready chan<- struct{}) e
 
 	if cmd.Server.DebugBindIP != nil {
 		addr := fmt.Sprintf("%s:%d", cmd.Server.DebugBindIP.IP(), cmd.Server.DebugBindPort)
-		metrics.StartDebugServer(addr, reconfigurableSink, metricsProvider)
+		metrics.StartDebugServer(addr, reconfigurableSink, metricsProvider, map[string]http.Handler{
+			"/debug/events":        rundmc.NewEventsHandler(containerizer),
+			"/debug/events/stream": gardener.NewEventStreamHandler(eventBus),
+			"/debug/processes":     rundmc.NewProcessesHandler(logger.Session("debug-processes"), containerizer),
+			"/debug/output":        rundmc.NewOutputHandler(logger.Session("debug-output"), containerizer),
+			"/debug/health":        gardener.NewHealthHandler(healthChecks),
+			"/debug/drain":         gardener.NewDrainHandler(backend, cmd.Server.DrainTimeout),
+			"/debug/pause":         gardener.NewContainerActionHandler(backend.Pause),
+			"/debug/resume":        gardener.NewContainerActionHandler(backend.Resume),
+			"/debug/checkpoint":    gardener.NewContainerActionHandler(backend.Checkpoint),
+			"/debug/restore":       gardener.NewContainerActionHandler(backend.RestoreCheckpoint),
+		})
 	}
 
 	err = gardenServer.Start()
@@ -433,18 +489,20 @@ func (cmd *ServerCommand) Run(signals <-chan os.Signal, ready chan<- struct{}) e
 
 	<-signals
 
+	// the server stops accepting connections and then stops the backend,
+	// which drains once: it lets in-flight creates and destroys finish, and
+	// then flushes port pool state and compacts the properties journal
 	gardenServer.Stop()
 
-	cmd.saveProperties(logger, cmd.Containers.PropertiesPath, propManager)
-
-	portPoolState = portPool.RefreshState()
-	ports.SaveState(cmd.Network.PortPoolPropertiesPath, portPoolState)
-
 	return nil
 }
 
 func (cmd *ServerCommand) loadProperties(logger lager.Logger, propertiesPath string) (*properties.Manager, error) {
-	propManager, err := properties.Load(propertiesPath)
+	if propertiesPath == "" {
+		return properties.NewManager(), nil
+	}
+
+	propManager, err := properties.Open(logger, propertiesPath, cmd.Containers.PropertiesCompactAfter)
 	if err != nil {
 		logger.Error("failed-to-load-properties", err, lager.Data{"propertiesPath": propertiesPath})
 		return &properties.Manager{}, err
@@ -453,13 +511,16 @@ func (cmd *ServerCommand) loadProperties(logger lager.Logger, propertiesPath str
 	return propManager, nil
 }
 
-func (cmd *ServerCommand) saveProperties(logger lager.Logger, propertiesPath string, propManager *properties.Manager) {
+func (cmd *ServerCommand) saveProperties(logger lager.Logger, propertiesPath string, propManager *properties.Manager) error {
 	if propertiesPath != "" {
 		err := properties.Save(propertiesPath, propManager)
 		if err != nil {
 			logger.Error("failed-to-save-properties", err, lager.Data{"propertiesPath": propertiesPath})
+			return err
 		}
 	}
+
+	return nil
 }
 
 func (cmd *ServerCommand) wireUidGenerator() gardener.UidGeneratorFunc {
@@ -467,14 +528,15 @@ func (cmd *ServerCommand) wireUidGenerator() gardener.UidGeneratorFunc {
 }
 
 func (cmd *ServerCommand) wireRunDMCStarter(logger lager.Logger) gardener.Starter {
-	var cgroupsMountpoint string
+	return rundmc.NewStarter(logger, mustOpen("/proc/cgroups"), mustOpen("/proc/self/cgroup"), cmd.cgroupsMountpoint(), linux_command_runner.New())
+}
+
+func (cmd *ServerCommand) cgroupsMountpoint() string {
 	if cmd.Server.Tag != "" {
-		cgroupsMountpoint = filepath.Join(os.TempDir(), fmt.Sprintf("cgroups-%s", cmd.Server.Tag))
-	} else {
-		cgroupsMountpoint = "/sys/fs/cgroup"
+		return filepath.Join(os.TempDir(), fmt.Sprintf("cgroups-%s", cmd.Server.Tag))
 	}
 
-	return rundmc.NewStarter(logger, mustOpen("/proc/cgroups"), mustOpen("/proc/self/cgroup"), cgroupsMountpoint, linux_command_runner.New())
+	return "/sys/fs/cgroup"
 }
 
 func (cmd *ServerCommand) wireNetworker(log lager.Logger, propManager kawasaki.ConfigStore, portPool *ports.PortPool) (gardener.Networker, gardener.Starter, error) {
@@ -529,7 +591,7 @@ func (cmd *ServerCommand) wireNetworker(log lager.Logger, propManager kawasaki.C
 		subnets.NewPool(cmd.Network.Pool.CIDR()),
 		kawasaki.NewConfigCreator(idGenerator, interfacePrefix, chainPrefix, externalIP, dnsServers, cmd.Network.Mtu),
 		propManager,
-		factory.NewDefaultConfigurer(ipTables),
+		factory.NewDefaultConfigurer(ipTables, cmd.Bin.TC),
 		portPool,
 		iptables.NewPortForwarder(ipTables),
 		iptables.NewFirewallOpener(ruleTranslator, ipTables),
@@ -691,7 +753,7 @@ func (cmd *ServerCommand) wireImagePlugin() gardener.VolumeCreator {
 	}
 }
 
-func (cmd *ServerCommand) wireContainerizer(log lager.Logger, depotPath, dadooPath, runcPath, nstarPath, tarPath, appArmorProfile string, properties gardener.PropertyManager) *rundmc.Containerizer {
+func (cmd *ServerCommand) wireContainerizer(log lager.Logger, depotPath, dadooPath, runcPath, nstarPath, tarPath, appArmorProfile string, properties gardener.PropertyManager, eventPublisher gardener.EventPublisher) *rundmc.Containerizer {
 	depot := depot.New(depotPath)
 
 	commandRunner := linux_command_runner.New()
@@ -706,20 +768,60 @@ func (cmd *ServerCommand) wireContainerizer(log lager.Logger, depotPath, dadooPa
 		SleepInterval: time.Millisecond * 100,
 	}
 
-	runcrunner := runrunc.New(
-		commandRunner,
-		runrunc.NewLogRunner(commandRunner, runrunc.LogDir(os.TempDir()).GenerateLogFile),
-		goci.RuncBinary(runcPath),
-		dadooPath,
-		runcPath,
-		runrunc.NewExecPreparer(&goci.BndlLoader{}, runrunc.LookupFunc(runrunc.LookupUser), chrootMkdir, NonRootMaxCaps),
-		dadoo.NewExecRunner(
+	execPreparer := runrunc.NewExecPreparer(&goci.BndlLoader{}, runrunc.LookupFunc(runrunc.LookupUser), chrootMkdir, NonRootMaxCaps)
+
+	runtimePaths := map[string]string{"runc": runcPath}
+	for name, path := range cmd.Bin.Runtimes {
+		runtimePaths[name] = path
+	}
+
+	runtimeArgs := map[string][]string{}
+	for _, nameAndArg := range cmd.Bin.RuntimeArgs {
+		parts := strings.SplitN(nameAndArg, ":", 2)
+		if len(parts) != 2 {
+			log.Fatal("invalid-runtime-arg", fmt.Errorf("expected name:arg, got %q", nameAndArg))
+		}
+
+		runtimeArgs[parts[0]] = append(runtimeArgs[parts[0]], parts[1])
+	}
+
+	ociRuntimes := map[string]rundmc.OCIRuntime{}
+	for name, path := range runtimePaths {
+		runtime := runrunc.New(
+			commandRunner,
+			runrunc.NewLogRunner(commandRunner, runrunc.LogDir(os.TempDir()).GenerateLogFile),
+			goci.RuntimeBinary{RuncBinary: goci.RuncBinary(path), Args: runtimeArgs[name]},
 			dadooPath,
-			runcPath,
-			cmd.wireUidGenerator(),
-			pidFileReader,
-			linux_command_runner.New()),
-	)
+			execPreparer,
+			dadoo.NewExecRunner(
+				dadooPath,
+				path,
+				runtimeArgs[name],
+				cmd.wireUidGenerator(),
+				pidFileReader,
+				linux_command_runner.New(),
+				cmd.Containers.ExitStatusRetention,
+				dadoo.OutputLogLimits{
+					SegmentBytes: cmd.Containers.OutputLogSegmentBytes,
+					Segments:     cmd.Containers.OutputLogSegments,
+				}),
+		)
+
+		// only runc's on-disk state format is understood, so other runtimes
+		// are asked for the state of their containers every time
+		if name == "runc" {
+			ociRuntimes[name] = rundmc.NewStateCache(runtime, runrunc.StateFile{Root: runcRoot(runtimeArgs[name])})
+		} else {
+			ociRuntimes[name] = runtime
+		}
+	}
+
+	if _, ok := ociRuntimes[cmd.Containers.DefaultRuntime]; !ok {
+		log.Fatal("unknown-default-runtime", fmt.Errorf("no runtime registered with name %q", cmd.Containers.DefaultRuntime))
+	}
+
+	log.Info("runtimes", lager.Data{"runtimes": runtimePaths, "default": cmd.Containers.DefaultRuntime})
+	runtimes := rundmc.NewRuntimes(depot, cmd.Containers.DefaultRuntime, ociRuntimes)
 
 	mounts := []specs.Mount{
 		{Type: "sysfs", Source: "sysfs", Destination: "/sys", Options: []string{"nosuid", "noexec", "nodev", "ro"}},
@@ -800,6 +902,10 @@ func (cmd *ServerCommand) wireContainerizer(log lager.Logger, depotPath, dadooPa
 			WithGIDMappings(idMappings[0])
 	}
 
+	limits := bundlerules.Limits{
+		CpuQuotaPerShare: cmd.Limits.CpuQuotaPerShare,
+	}
+
 	template := &rundmc.BundleTemplate{
 		Rules: []rundmc.BundlerRule{
 			bundlerules.Base{
@@ -811,9 +917,7 @@ func (cmd *ServerCommand) wireContainerizer(log lager.Logger, depotPath, dadooPa
 				ContainerRootGID: idMappings.Map(0),
 				MkdirChown:       chrootMkdir,
 			},
-			bundlerules.Limits{
-				CpuQuotaPerShare: cmd.Limits.CpuQuotaPerShare,
-			},
+			limits,
 			bundlerules.BindMounts{},
 			bundlerules.Env{},
 			bundlerules.Hostname{},
@@ -825,12 +929,68 @@ func (cmd *ServerCommand) wireContainerizer(log lager.Logger, depotPath, dadooPa
 		"unprivileged": unprivilegedBundle,
 	})
 
-	eventStore := rundmc.NewEventStore(properties)
+	eventStore := rundmc.NewEventStore(properties, clock.NewClock(), eventPublisher)
 	stateStore := rundmc.NewStateStore(properties)
 
 	nstar := rundmc.NewNstarRunner(nstarPath, tarPath, linux_command_runner.New())
-	stopper := stopper.New(stopper.NewRuncStateCgroupPathResolver("/run/runc"), nil, retrier.New(retrier.ConstantBackoff(10, 1*time.Second), nil))
-	return rundmc.New(depot, template, runcrunner, &goci.BndlLoader{}, nstar, stopper, eventStore, stateStore)
+	var freezer stopper.Freezer
+	if cmd.Containers.StopStrategy == "freeze" {
+		freezer = stopper.CgroupFreezer{}
+	}
+
+	// as with state, only runc's on-disk state is understood, so the cgroups of
+	// other runtimes' containers are found from their init process
+	cgroupPathResolvers := map[string]rundmc.CgroupPathResolver{
+		"runc": stopper.NewRuncStateCgroupPathResolver(runcRoot(runtimeArgs["runc"])),
+	}
+	for name, runtime := range ociRuntimes {
+		if name == "runc" {
+			continue
+		}
+
+		runtime := runtime
+		cgroupPathResolvers[name] = stopper.NewProcCgroupPathResolver("/proc", cmd.cgroupsMountpoint(), func(handle string) (int, error) {
+			state, err := runtime.State(log, handle)
+			return state.Pid, err
+		})
+	}
+
+	stopper := stopper.New(runtimes.CgroupPathResolver(log, cgroupPathResolvers), nil, retrier.New(retrier.ConstantBackoff(10, 1*time.Second), nil), freezer)
+	newWatchRetrier := func() rundmc.Retrier {
+		return retrier.New(retrier.ExponentialBackoff(10, 100*time.Millisecond), nil)
+	}
+	return rundmc.New(depot, template, runtimes, &goci.BndlLoader{}, nstar, stopper, eventStore, stateStore, limits, newWatchRetrier, clock.NewClock(), dadoo.ProcessLister{TombstoneRetention: cmd.Containers.ExitStatusRetention})
+}
+
+func (cmd *ServerCommand) wireHealthChecks() *gardener.HealthChecks {
+	checks := map[string]gardener.HealthCheck{
+		"depot":        healthcheck.Writable{Dir: cmd.Containers.Dir},
+		"dadoo-binary": healthcheck.Executable{Path: cmd.Bin.Dadoo.Path()},
+		"runc-binary":  healthcheck.Executable{Path: cmd.Bin.Runc},
+	}
+
+	for name, path := range cmd.Bin.Runtimes {
+		checks["runtime-binary-"+name] = healthcheck.Executable{Path: path}
+	}
+
+	// plugins are only checked to be executable, since they have no command
+	// which is cheap and side-effect free to run
+	if cmd.Image.Plugin.Path() != "" {
+		checks["image-plugin-binary"] = healthcheck.Executable{Path: cmd.Image.Plugin.Path()}
+	}
+
+	if cmd.Image.PrivilegedPlugin.Path() != "" {
+		checks["privileged-image-plugin-binary"] = healthcheck.Executable{Path: cmd.Image.PrivilegedPlugin.Path()}
+	}
+
+	// the iptables lock is only taken by the built-in networker
+	if cmd.Network.Plugin.Path() != "" {
+		checks["network-plugin-binary"] = healthcheck.Executable{Path: cmd.Network.Plugin.Path()}
+	} else {
+		checks["iptables-lock"] = healthcheck.Lockable{Locksmith: &locksmithpkg.FileSystem{}, Key: iptables.LockKey}
+	}
+
+	return gardener.NewHealthChecks(clock.NewClock(), cmd.Server.HealthCheckTimeout, cmd.Server.HealthCheckCacheFor, checks)
 }
 
 func (cmd *ServerCommand) wireMetricsProvider(log lager.Logger, depotPath, graphRoot string) metrics.Metrics {
@@ -892,3 +1052,19 @@ func mustOpen(path string) io.ReadCloser {
 		return r
 	}
 }
+
+// runcRoot returns the state directory runc is configured to use by its global
+// arguments
+func runcRoot(args []string) string {
+	root := "/run/runc"
+	for i, arg := range args {
+		switch {
+		case (arg == "--root" || arg == "-root") && i+1 < len(args):
+			root = args[i+1]
+		case strings.HasPrefix(arg, "--root="):
+			root = strings.TrimPrefix(arg, "--root=")
+		}
+	}
+
+	return root
+}
@@ -0,0 +1,70 @@
+// Package healthcheck contains the checks run behind Ping, each of which
+// checks that one of the server's dependencies is usable.
+package healthcheck
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+
+	"code.cloudfoundry.org/guardian/pkg/locksmith"
+)
+
+// Writable checks that files can be written to a directory, i.e. that its disk
+// is neither full nor read-only
+type Writable struct {
+	Dir string
+}
+
+func (w Writable) Check() error {
+	f, err := ioutil.TempFile(w.Dir, ".health-check")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write([]byte("ok")); err != nil {
+		f.Close()
+		return err
+	}
+
+	// the write is only known to have found space once it is synced
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// Executable checks that a binary exists and can be executed, paths without a
+// slash are looked up in $PATH
+type Executable struct {
+	Path string
+}
+
+func (e Executable) Check() error {
+	_, err := exec.LookPath(e.Path)
+	return err
+}
+
+type Locksmith interface {
+	Lock(key string) (locksmith.Unlocker, error)
+}
+
+// Lockable checks that a lock can be acquired, i.e. that it is not held by a
+// wedged process. Acquiring the lock blocks, so this relies on the timeout
+// around health checks.
+type Lockable struct {
+	Locksmith Locksmith
+	Key       string
+}
+
+func (l Lockable) Check() error {
+	unlocker, err := l.Locksmith.Lock(l.Key)
+	if err != nil {
+		return err
+	}
+
+	return unlocker.Unlock()
+}
@@ -0,0 +1,78 @@
+package healthcheck_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/guardian/healthcheck"
+	"code.cloudfoundry.org/guardian/pkg/locksmith"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Health checks", func() {
+	var tmpDir string
+
+	BeforeEach(func() {
+		var err error
+		tmpDir, err = ioutil.TempDir("", "healthcheck")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(tmpDir)).To(Succeed())
+	})
+
+	Describe("Writable", func() {
+		It("succeeds when the directory is writable", func() {
+			Expect(healthcheck.Writable{Dir: tmpDir}.Check()).To(Succeed())
+		})
+
+		It("cleans up after itself", func() {
+			Expect(healthcheck.Writable{Dir: tmpDir}.Check()).To(Succeed())
+			Expect(ioutil.ReadDir(tmpDir)).To(BeEmpty())
+		})
+
+		It("fails when the directory does not exist", func() {
+			Expect(healthcheck.Writable{Dir: filepath.Join(tmpDir, "missing")}.Check()).NotTo(Succeed())
+		})
+	})
+
+	Describe("Executable", func() {
+		It("succeeds for an executable file", func() {
+			path := filepath.Join(tmpDir, "runc")
+			Expect(ioutil.WriteFile(path, []byte("#!/bin/sh"), 0755)).To(Succeed())
+
+			Expect(healthcheck.Executable{Path: path}.Check()).To(Succeed())
+		})
+
+		It("looks up bare names in $PATH", func() {
+			Expect(healthcheck.Executable{Path: "sh"}.Check()).To(Succeed())
+		})
+
+		It("fails for a file which is not executable", func() {
+			path := filepath.Join(tmpDir, "runc")
+			Expect(ioutil.WriteFile(path, []byte("#!/bin/sh"), 0644)).To(Succeed())
+
+			Expect(healthcheck.Executable{Path: path}.Check()).NotTo(Succeed())
+		})
+
+		It("fails for a missing file", func() {
+			Expect(healthcheck.Executable{Path: filepath.Join(tmpDir, "missing")}.Check()).NotTo(Succeed())
+		})
+	})
+
+	Describe("Lockable", func() {
+		It("acquires and releases the lock", func() {
+			check := healthcheck.Lockable{Locksmith: locksmith.NewFileSystem(), Key: filepath.Join(tmpDir, "lock")}
+			Expect(check.Check()).To(Succeed())
+			Expect(check.Check()).To(Succeed())
+		})
+
+		It("fails when the lock cannot be created", func() {
+			check := healthcheck.Lockable{Locksmith: locksmith.NewFileSystem(), Key: filepath.Join(tmpDir, "missing", "lock")}
+			Expect(check.Check()).NotTo(Succeed())
+		})
+	})
+})
@@ -22,11 +22,7 @@ func (cc *DefaultCommandCreator) CreateCommand(log lager.Logger, handle string,
 	args := append(cc.ExtraArgs, "create")
 
 	if spec.QuotaSize > 0 {
-		args = append(args, "--disk-limit-size-bytes", strconv.FormatInt(spec.QuotaSize, 10))
-
-		if spec.QuotaScope == garden.DiskLimitScopeExclusive {
-			args = append(args, "--exclude-image-from-quota")
-		}
+		args = append(args, quotaArgs(uint64(spec.QuotaSize), spec.QuotaScope)...)
 	}
 
 	if spec.Username != "" {
@@ -51,6 +47,32 @@ func (cc *DefaultCommandCreator) MetricsCommand(log lager.Logger, handle string)
 	return exec.Command(cc.BinPath, append(cc.ExtraArgs, "stats", handle)...)
 }
 
+func (cc *DefaultCommandCreator) ResizeCommand(log lager.Logger, handle string, limits garden.DiskLimits) (*exec.Cmd, error) {
+	// copied, since appending to the extra args could otherwise write into
+	// their spare capacity, which they share with earlier commands
+	args := append([]string{}, cc.ExtraArgs...)
+	args = append(args, "resize")
+	args = append(args, quotaArgs(limits.ByteHard, limits.Scope)...)
+	args = append(args, handle)
+	return exec.Command(cc.BinPath, args...), nil
+}
+
+func (cc *DefaultCommandCreator) QuotaCommand(log lager.Logger, handle string) (*exec.Cmd, error) {
+	args := append([]string{}, cc.ExtraArgs...)
+	args = append(args, "quota", handle)
+	return exec.Command(cc.BinPath, args...), nil
+}
+
+func quotaArgs(size uint64, scope garden.DiskLimitScope) []string {
+	args := []string{"--disk-limit-size-bytes", strconv.FormatUint(size, 10)}
+
+	if scope == garden.DiskLimitScopeExclusive {
+		args = append(args, "--exclude-image-from-quota")
+	}
+
+	return args
+}
+
 func stringifyMapping(mapping specs.LinuxIDMapping) string {
 	return fmt.Sprintf("%d:%d:%d", mapping.ContainerID, mapping.HostID, mapping.Size)
 }
@@ -251,4 +251,118 @@ var _ = Describe("DefaultCommandCreator", func() {
 			Expect(metricsCmd.SysProcAttr).To(BeNil())
 		})
 	})
+
+	Describe("ResizeCommand", func() {
+		var (
+			resizeCmd *exec.Cmd
+			limits    garden.DiskLimits
+		)
+
+		BeforeEach(func() {
+			limits = garden.DiskLimits{ByteHard: 100000}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			resizeCmd, err = commandCreator.ResizeCommand(nil, "test-handle", limits)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns a command with the correct image plugin path", func() {
+			Expect(resizeCmd.Path).To(Equal(binPath))
+		})
+
+		It("returns a command with the resize action", func() {
+			Expect(resizeCmd.Args[1]).To(Equal("resize"))
+		})
+
+		It("returns a command with the quota", func() {
+			Expect(resizeCmd.Args[2]).To(Equal("--disk-limit-size-bytes"))
+			Expect(resizeCmd.Args[3]).To(Equal("100000"))
+		})
+
+		It("returns a command with the provided handle as id", func() {
+			Expect(resizeCmd.Args[len(resizeCmd.Args)-1]).To(Equal("test-handle"))
+		})
+
+		Context("when the limit has an exclusive scope", func() {
+			BeforeEach(func() {
+				limits.Scope = garden.DiskLimitScopeExclusive
+			})
+
+			It("returns a command with the quota and an exclusive scope", func() {
+				Expect(resizeCmd.Args).To(ContainElement("--exclude-image-from-quota"))
+			})
+		})
+
+		Context("when the limit has a total scope", func() {
+			BeforeEach(func() {
+				limits.Scope = garden.DiskLimitScopeTotal
+			})
+
+			It("returns a command with the quota and a total scope", func() {
+				Expect(resizeCmd.Args).NotTo(ContainElement("--exclude-image-from-quota"))
+			})
+		})
+
+		Context("when extra args are provided", func() {
+			BeforeEach(func() {
+				extraArgs = []string{"foo", "bar"}
+			})
+
+			It("returns a command with the extra args as global args preceeding the action", func() {
+				Expect(resizeCmd.Args[1]).To(Equal("foo"))
+				Expect(resizeCmd.Args[2]).To(Equal("bar"))
+				Expect(resizeCmd.Args[3]).To(Equal("resize"))
+			})
+		})
+
+		Context("when the extra args have spare capacity", func() {
+			BeforeEach(func() {
+				extraArgs = make([]string, 1, 10)
+				extraArgs[0] = "foo"
+			})
+
+			It("does not write into it", func() {
+				Expect(extraArgs[:2]).To(Equal([]string{"foo", ""}))
+
+				otherCmd, err := commandCreator.ResizeCommand(nil, "other-handle", limits)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(resizeCmd.Args[len(resizeCmd.Args)-1]).To(Equal("test-handle"))
+				Expect(otherCmd.Args[len(otherCmd.Args)-1]).To(Equal("other-handle"))
+			})
+		})
+
+		It("returns a command that runs as the current user (SysProcAttr.Credential not set)", func() {
+			Expect(resizeCmd.SysProcAttr).To(BeNil())
+		})
+	})
+
+	Describe("QuotaCommand", func() {
+		var quotaCmd *exec.Cmd
+
+		JustBeforeEach(func() {
+			var err error
+			quotaCmd, err = commandCreator.QuotaCommand(nil, "test-handle")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns a command with the correct image plugin path", func() {
+			Expect(quotaCmd.Path).To(Equal(binPath))
+		})
+
+		It("returns a command with the quota action and the provided handle as id", func() {
+			Expect(quotaCmd.Args[1:]).To(Equal([]string{"quota", "test-handle"}))
+		})
+
+		Context("when extra args are provided", func() {
+			BeforeEach(func() {
+				extraArgs = []string{"foo", "bar"}
+			})
+
+			It("returns a command with the extra args as global args preceeding the action", func() {
+				Expect(quotaCmd.Args[1:]).To(Equal([]string{"foo", "bar", "quota", "test-handle"}))
+			})
+		})
+	})
 })
@@ -22,6 +22,8 @@ type CommandCreator interface {
 	CreateCommand(log lager.Logger, handle string, spec rootfs_provider.Spec) (*exec.Cmd, error)
 	DestroyCommand(log lager.Logger, handle string) *exec.Cmd
 	MetricsCommand(log lager.Logger, handle string) *exec.Cmd
+	ResizeCommand(log lager.Logger, handle string, limits garden.DiskLimits) (*exec.Cmd, error)
+	QuotaCommand(log lager.Logger, handle string) (*exec.Cmd, error)
 }
 
 type ImagePlugin struct {
@@ -142,6 +144,84 @@ func (p *ImagePlugin) Metrics(log lager.Logger, handle string, namespaced bool)
 	}, nil
 }
 
+func (p *ImagePlugin) Resize(log lager.Logger, handle string, namespaced bool, limits garden.DiskLimits) error {
+	log = log.Session("image-plugin-resize", lager.Data{"handle": handle, "namespaced": namespaced, "limits": limits})
+	log.Debug("start")
+	defer log.Debug("end")
+
+	var (
+		resizeCmd *exec.Cmd
+		err       error
+	)
+	if namespaced {
+		resizeCmd, err = p.UnprivilegedCommandCreator.ResizeCommand(log, handle, limits)
+	} else {
+		resizeCmd, err = p.PrivilegedCommandCreator.ResizeCommand(log, handle, limits)
+	}
+	if err != nil {
+		return errorwrapper.Wrap(err, "creating resize command")
+	}
+
+	stdoutBuffer := bytes.NewBuffer([]byte{})
+	resizeCmd.Stdout = stdoutBuffer
+	resizeCmd.Stderr = lagregator.NewRelogger(log)
+
+	if err := p.CommandRunner.Run(resizeCmd); err != nil {
+		logData := lager.Data{"action": "resize", "stdout": stdoutBuffer.String()}
+		log.Error("image-plugin-result", err, logData)
+		return errorwrapper.Wrapf(err, "running image plugin resize: %s", stdoutBuffer.String())
+	}
+
+	return nil
+}
+
+// DiskLimits asks the plugin for the disk quota of a volume, which it prints
+// as JSON in the form of the resize command's flags, e.g.
+// {"disk_limit_size_bytes": 1024, "exclude_image_from_quota": true}
+func (p *ImagePlugin) DiskLimits(log lager.Logger, handle string, namespaced bool) (garden.DiskLimits, error) {
+	log = log.Session("image-plugin-quota", lager.Data{"handle": handle, "namespaced": namespaced})
+	log.Debug("start")
+	defer log.Debug("end")
+
+	var (
+		quotaCmd *exec.Cmd
+		err      error
+	)
+	if namespaced {
+		quotaCmd, err = p.UnprivilegedCommandCreator.QuotaCommand(log, handle)
+	} else {
+		quotaCmd, err = p.PrivilegedCommandCreator.QuotaCommand(log, handle)
+	}
+	if err != nil {
+		return garden.DiskLimits{}, errorwrapper.Wrap(err, "creating quota command")
+	}
+
+	stdoutBuffer := bytes.NewBuffer([]byte{})
+	quotaCmd.Stdout = stdoutBuffer
+	quotaCmd.Stderr = lagregator.NewRelogger(log)
+
+	if err := p.CommandRunner.Run(quotaCmd); err != nil {
+		logData := lager.Data{"action": "quota", "stdout": stdoutBuffer.String()}
+		log.Error("image-plugin-result", err, logData)
+		return garden.DiskLimits{}, errorwrapper.Wrapf(err, "running image plugin quota: %s", stdoutBuffer.String())
+	}
+
+	var quota struct {
+		DiskLimitSizeBytes    uint64 `json:"disk_limit_size_bytes"`
+		ExcludeImageFromQuota bool   `json:"exclude_image_from_quota"`
+	}
+	if err := json.NewDecoder(bytes.NewReader(stdoutBuffer.Bytes())).Decode(&quota); err != nil {
+		return garden.DiskLimits{}, errorwrapper.Wrapf(err, "parsing quota: %s", stdoutBuffer.String())
+	}
+
+	limits := garden.DiskLimits{ByteHard: quota.DiskLimitSizeBytes}
+	if quota.ExcludeImageFromQuota {
+		limits.Scope = garden.DiskLimitScopeExclusive
+	}
+
+	return limits, nil
+}
+
 func (p *ImagePlugin) GC(log lager.Logger) error {
 	return nil
 }
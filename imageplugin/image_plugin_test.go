@@ -570,4 +570,235 @@ var _ = Describe("ImagePlugin", func() {
 			})
 		})
 	})
+
+	Describe("Resize", func() {
+		var (
+			cmd *exec.Cmd
+
+			handle     string
+			namespaced bool
+			limits     garden.DiskLimits
+
+			fakeImagePluginStdout string
+			fakeImagePluginStderr string
+			fakeImagePluginError  error
+
+			resizeErr error
+		)
+
+		BeforeEach(func() {
+			cmd = exec.Command("unpriv-plugin", "resize")
+			fakeUnprivilegedCommandCreator.ResizeCommandReturns(cmd, nil)
+			fakePrivilegedCommandCreator.ResizeCommandReturns(cmd, nil)
+
+			handle = "test-handle"
+			namespaced = true //assume unprivileged by default
+			limits = garden.DiskLimits{ByteHard: 1024}
+
+			fakeImagePluginStdout = ""
+			fakeImagePluginStderr = ""
+			fakeImagePluginError = nil
+
+			resizeErr = nil
+		})
+
+		JustBeforeEach(func() {
+			fakeCommandRunner.WhenRunning(
+				fake_command_runner.CommandSpec{
+					Path: cmd.Path,
+				},
+				func(cmd *exec.Cmd) error {
+					cmd.Stdout.Write([]byte(fakeImagePluginStdout))
+					cmd.Stderr.Write([]byte(fakeImagePluginStderr))
+					return fakeImagePluginError
+				},
+			)
+
+			resizeErr = imagePlugin.Resize(fakeLogger, handle, namespaced, limits)
+		})
+
+		It("calls the unprivileged command creator to generate a resize command", func() {
+			Expect(resizeErr).NotTo(HaveOccurred())
+			Expect(fakePrivilegedCommandCreator.ResizeCommandCallCount()).To(Equal(0))
+			Expect(fakeUnprivilegedCommandCreator.ResizeCommandCallCount()).To(Equal(1))
+
+			_, handleArg, limitsArg := fakeUnprivilegedCommandCreator.ResizeCommandArgsForCall(0)
+			Expect(handleArg).To(Equal(handle))
+			Expect(limitsArg).To(Equal(limits))
+		})
+
+		Context("when resizing a privileged volume", func() {
+			BeforeEach(func() {
+				namespaced = false
+			})
+
+			It("calls the privileged command creator to generate a resize command", func() {
+				Expect(resizeErr).NotTo(HaveOccurred())
+				Expect(fakePrivilegedCommandCreator.ResizeCommandCallCount()).To(Equal(1))
+				Expect(fakeUnprivilegedCommandCreator.ResizeCommandCallCount()).To(Equal(0))
+
+				_, handleArg, limitsArg := fakePrivilegedCommandCreator.ResizeCommandArgsForCall(0)
+				Expect(handleArg).To(Equal(handle))
+				Expect(limitsArg).To(Equal(limits))
+			})
+		})
+
+		Context("when the command creator returns an error", func() {
+			BeforeEach(func() {
+				fakeUnprivilegedCommandCreator.ResizeCommandReturns(nil, errors.New("explosion"))
+			})
+
+			It("returns that error", func() {
+				Expect(resizeErr).To(MatchError("creating resize command: explosion"))
+			})
+		})
+
+		It("runs the plugin command with the command runner", func() {
+			Expect(resizeErr).NotTo(HaveOccurred())
+			Expect(fakeCommandRunner.ExecutedCommands()).To(HaveLen(1))
+			executedCmd := fakeCommandRunner.ExecutedCommands()[0]
+
+			Expect(executedCmd).To(Equal(cmd))
+		})
+
+		Context("when running the image plugin resize fails", func() {
+			BeforeEach(func() {
+				fakeImagePluginStdout = "image-plugin-exploded-due-to-oom"
+				fakeImagePluginError = errors.New("image-plugin-resize-failed")
+			})
+
+			It("returns the wrapped error and plugin stdout, with context", func() {
+				str := fmt.Sprintf("running image plugin resize: %s: %s",
+					fakeImagePluginStdout, fakeImagePluginError)
+				Expect(resizeErr).To(MatchError(str))
+			})
+		})
+
+		Context("when the image plugin emits logs to stderr", func() {
+			BeforeEach(func() {
+				buffer := gbytes.NewBuffer()
+				externalLogger := lager.NewLogger("external-plugin")
+				externalLogger.RegisterSink(lager.NewWriterSink(buffer, lager.DEBUG))
+				externalLogger.Error("error-message", errors.New("failed!"), lager.Data{"type": "error"})
+
+				fakeImagePluginStderr = string(buffer.Contents())
+			})
+
+			It("relogs the log entries", func() {
+				Expect(fakeLogger).To(glager.ContainSequence(
+					glager.Error(
+						errors.New("failed!"),
+						glager.Message("image-plugin.image-plugin-resize.external-plugin.error-message"),
+						glager.Data("type", "error"),
+					),
+				))
+			})
+		})
+	})
+
+	Describe("DiskLimits", func() {
+		var (
+			cmd *exec.Cmd
+
+			namespaced bool
+
+			fakeImagePluginStdout string
+			fakeImagePluginError  error
+
+			limits    garden.DiskLimits
+			limitsErr error
+		)
+
+		BeforeEach(func() {
+			cmd = exec.Command("unpriv-plugin", "quota")
+			fakeUnprivilegedCommandCreator.QuotaCommandReturns(cmd, nil)
+			fakePrivilegedCommandCreator.QuotaCommandReturns(cmd, nil)
+
+			namespaced = true
+			fakeImagePluginStdout = `{"disk_limit_size_bytes": 1024}`
+			fakeImagePluginError = nil
+		})
+
+		JustBeforeEach(func() {
+			fakeCommandRunner.WhenRunning(
+				fake_command_runner.CommandSpec{
+					Path: cmd.Path,
+				},
+				func(cmd *exec.Cmd) error {
+					cmd.Stdout.Write([]byte(fakeImagePluginStdout))
+					return fakeImagePluginError
+				},
+			)
+
+			limits, limitsErr = imagePlugin.DiskLimits(fakeLogger, "test-handle", namespaced)
+		})
+
+		It("calls the unprivileged command creator to generate a quota command", func() {
+			Expect(limitsErr).NotTo(HaveOccurred())
+			Expect(fakePrivilegedCommandCreator.QuotaCommandCallCount()).To(Equal(0))
+			Expect(fakeUnprivilegedCommandCreator.QuotaCommandCallCount()).To(Equal(1))
+
+			_, handleArg := fakeUnprivilegedCommandCreator.QuotaCommandArgsForCall(0)
+			Expect(handleArg).To(Equal("test-handle"))
+		})
+
+		Context("when querying a privileged volume", func() {
+			BeforeEach(func() {
+				namespaced = false
+			})
+
+			It("calls the privileged command creator to generate a quota command", func() {
+				Expect(limitsErr).NotTo(HaveOccurred())
+				Expect(fakePrivilegedCommandCreator.QuotaCommandCallCount()).To(Equal(1))
+				Expect(fakeUnprivilegedCommandCreator.QuotaCommandCallCount()).To(Equal(0))
+			})
+		})
+
+		It("returns the quota the plugin prints", func() {
+			Expect(limitsErr).NotTo(HaveOccurred())
+			Expect(limits).To(Equal(garden.DiskLimits{ByteHard: 1024}))
+		})
+
+		Context("when the image is excluded from the quota", func() {
+			BeforeEach(func() {
+				fakeImagePluginStdout = `{"disk_limit_size_bytes": 1024, "exclude_image_from_quota": true}`
+			})
+
+			It("returns the quota with an exclusive scope", func() {
+				Expect(limitsErr).NotTo(HaveOccurred())
+				Expect(limits).To(Equal(garden.DiskLimits{ByteHard: 1024, Scope: garden.DiskLimitScopeExclusive}))
+			})
+		})
+
+		Context("when the command creator returns an error", func() {
+			BeforeEach(func() {
+				fakeUnprivilegedCommandCreator.QuotaCommandReturns(nil, errors.New("explosion"))
+			})
+
+			It("returns that error", func() {
+				Expect(limitsErr).To(MatchError("creating quota command: explosion"))
+			})
+		})
+
+		Context("when running the image plugin quota fails", func() {
+			BeforeEach(func() {
+				fakeImagePluginStdout = "image-plugin-exploded"
+				fakeImagePluginError = errors.New("image-plugin-quota-failed")
+			})
+
+			It("returns the wrapped error and plugin stdout, with context", func() {
+				Expect(limitsErr).To(MatchError("running image plugin quota: image-plugin-exploded: image-plugin-quota-failed"))
+			})
+		})
+
+		Context("when the plugin prints something other than a quota", func() {
+			BeforeEach(func() {
+				fakeImagePluginStdout = "potato"
+			})
+
+			It("returns an error", func() {
+				Expect(limitsErr).To(MatchError(ContainSubstring("parsing quota: potato")))
+			})
+		})
+	})
 })
@@ -5,6 +5,7 @@ import (
 	"os/exec"
 	"sync"
 
+	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden-shed/rootfs_provider"
 	"code.cloudfoundry.org/guardian/imageplugin"
 	"code.cloudfoundry.org/lager"
@@ -40,6 +41,27 @@ type FakeCommandCreator struct {
 	metricsCommandReturns struct {
 		result1 *exec.Cmd
 	}
+	ResizeCommandStub        func(log lager.Logger, handle string, limits garden.DiskLimits) (*exec.Cmd, error)
+	resizeCommandMutex       sync.RWMutex
+	resizeCommandArgsForCall []struct {
+		log    lager.Logger
+		handle string
+		limits garden.DiskLimits
+	}
+	resizeCommandReturns struct {
+		result1 *exec.Cmd
+		result2 error
+	}
+	QuotaCommandStub        func(log lager.Logger, handle string) (*exec.Cmd, error)
+	quotaCommandMutex       sync.RWMutex
+	quotaCommandArgsForCall []struct {
+		log    lager.Logger
+		handle string
+	}
+	quotaCommandReturns struct {
+		result1 *exec.Cmd
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -148,6 +170,77 @@ func (fake *FakeCommandCreator) MetricsCommandReturns(result1 *exec.Cmd) {
 	}{result1}
 }
 
+func (fake *FakeCommandCreator) ResizeCommand(log lager.Logger, handle string, limits garden.DiskLimits) (*exec.Cmd, error) {
+	fake.resizeCommandMutex.Lock()
+	fake.resizeCommandArgsForCall = append(fake.resizeCommandArgsForCall, struct {
+		log    lager.Logger
+		handle string
+		limits garden.DiskLimits
+	}{log, handle, limits})
+	fake.recordInvocation("ResizeCommand", []interface{}{log, handle, limits})
+	fake.resizeCommandMutex.Unlock()
+	if fake.ResizeCommandStub != nil {
+		return fake.ResizeCommandStub(log, handle, limits)
+	} else {
+		return fake.resizeCommandReturns.result1, fake.resizeCommandReturns.result2
+	}
+}
+
+func (fake *FakeCommandCreator) ResizeCommandCallCount() int {
+	fake.resizeCommandMutex.RLock()
+	defer fake.resizeCommandMutex.RUnlock()
+	return len(fake.resizeCommandArgsForCall)
+}
+
+func (fake *FakeCommandCreator) ResizeCommandArgsForCall(i int) (lager.Logger, string, garden.DiskLimits) {
+	fake.resizeCommandMutex.RLock()
+	defer fake.resizeCommandMutex.RUnlock()
+	return fake.resizeCommandArgsForCall[i].log, fake.resizeCommandArgsForCall[i].handle, fake.resizeCommandArgsForCall[i].limits
+}
+
+func (fake *FakeCommandCreator) ResizeCommandReturns(result1 *exec.Cmd, result2 error) {
+	fake.ResizeCommandStub = nil
+	fake.resizeCommandReturns = struct {
+		result1 *exec.Cmd
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeCommandCreator) QuotaCommand(log lager.Logger, handle string) (*exec.Cmd, error) {
+	fake.quotaCommandMutex.Lock()
+	fake.quotaCommandArgsForCall = append(fake.quotaCommandArgsForCall, struct {
+		log    lager.Logger
+		handle string
+	}{log, handle})
+	fake.recordInvocation("QuotaCommand", []interface{}{log, handle})
+	fake.quotaCommandMutex.Unlock()
+	if fake.QuotaCommandStub != nil {
+		return fake.QuotaCommandStub(log, handle)
+	} else {
+		return fake.quotaCommandReturns.result1, fake.quotaCommandReturns.result2
+	}
+}
+
+func (fake *FakeCommandCreator) QuotaCommandCallCount() int {
+	fake.quotaCommandMutex.RLock()
+	defer fake.quotaCommandMutex.RUnlock()
+	return len(fake.quotaCommandArgsForCall)
+}
+
+func (fake *FakeCommandCreator) QuotaCommandArgsForCall(i int) (lager.Logger, string) {
+	fake.quotaCommandMutex.RLock()
+	defer fake.quotaCommandMutex.RUnlock()
+	return fake.quotaCommandArgsForCall[i].log, fake.quotaCommandArgsForCall[i].handle
+}
+
+func (fake *FakeCommandCreator) QuotaCommandReturns(result1 *exec.Cmd, result2 error) {
+	fake.QuotaCommandStub = nil
+	fake.quotaCommandReturns = struct {
+		result1 *exec.Cmd
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeCommandCreator) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -157,6 +250,10 @@ func (fake *FakeCommandCreator) Invocations() map[string][][]interface{} {
 	defer fake.destroyCommandMutex.RUnlock()
 	fake.metricsCommandMutex.RLock()
 	defer fake.metricsCommandMutex.RUnlock()
+	fake.resizeCommandMutex.RLock()
+	defer fake.resizeCommandMutex.RUnlock()
+	fake.quotaCommandMutex.RLock()
+	defer fake.quotaCommandMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -3,6 +3,7 @@ package imageplugin
 import (
 	"os/exec"
 
+	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden-shed/rootfs_provider"
 	"code.cloudfoundry.org/lager"
 )
@@ -22,3 +23,11 @@ func (cc *NotImplementedCommandCreator) DestroyCommand(log lager.Logger, handle
 func (cc *NotImplementedCommandCreator) MetricsCommand(log lager.Logger, handle string) *exec.Cmd {
 	return nil
 }
+
+func (cc *NotImplementedCommandCreator) ResizeCommand(log lager.Logger, handle string, limits garden.DiskLimits) (*exec.Cmd, error) {
+	return nil, cc.Err
+}
+
+func (cc *NotImplementedCommandCreator) QuotaCommand(log lager.Logger, handle string) (*exec.Cmd, error) {
+	return nil, cc.Err
+}
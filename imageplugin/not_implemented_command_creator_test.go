@@ -3,6 +3,7 @@ package imageplugin_test
 import (
 	"errors"
 
+	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden-shed/rootfs_provider"
 	"code.cloudfoundry.org/guardian/imageplugin"
 	. "github.com/onsi/ginkgo"
@@ -39,4 +40,20 @@ var _ = Describe("NotImplementedCommandCreator", func() {
 			Expect(notImplementedCommandCreator.MetricsCommand(nil, "")).To(BeNil())
 		})
 	})
+
+	Describe("ResizeCommand", func() {
+		It("returns nil and provided error", func() {
+			cmd, err := notImplementedCommandCreator.ResizeCommand(nil, "", garden.DiskLimits{})
+			Expect(cmd).To(BeNil())
+			Expect(err).To(MatchError(errors.New("NOT IMPLEMENTED")))
+		})
+	})
+
+	Describe("QuotaCommand", func() {
+		It("returns nil and provided error", func() {
+			cmd, err := notImplementedCommandCreator.QuotaCommand(nil, "")
+			Expect(cmd).To(BeNil())
+			Expect(err).To(MatchError(errors.New("NOT IMPLEMENTED")))
+		})
+	})
 })
@@ -5,6 +5,7 @@ import (
 	"net"
 	"os"
 
+	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/guardian/kawasaki"
 	"code.cloudfoundry.org/lager"
 )
@@ -30,6 +31,10 @@ type Host struct {
 	FileOpener interface {
 		Open(path string) (*os.File, error)
 	}
+
+	Shaper interface {
+		Shape(intfName string, limits garden.BandwidthLimits) error
+	}
 }
 
 func (c *Host) Apply(logger lager.Logger, config kawasaki.NetworkConfig, pid int) error {
@@ -78,6 +83,23 @@ func (c *Host) Apply(logger lager.Logger, config kawasaki.NetworkConfig, pid int
 	return nil
 }
 
+// LimitBandwidth applies the given rate and burst to both directions of the
+// host side of the container's veth pair
+func (c *Host) LimitBandwidth(logger lager.Logger, config kawasaki.NetworkConfig, limits garden.BandwidthLimits) error {
+	log := logger.Session("limit-bandwidth", lager.Data{
+		"hostIface": config.HostIntf,
+		"limits":    limits,
+	})
+
+	log.Debug("shaping")
+	if err := c.Shaper.Shape(config.HostIntf, limits); err != nil {
+		log.Error("shape", err)
+		return err
+	}
+
+	return nil
+}
+
 func (c *Host) Destroy(config kawasaki.NetworkConfig) error {
 	return c.Bridge.Destroy(config.BridgeName)
 }
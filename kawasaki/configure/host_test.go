@@ -6,6 +6,7 @@ import (
 	"net"
 	"os"
 
+	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/guardian/kawasaki"
 	"code.cloudfoundry.org/guardian/kawasaki/configure"
 	"code.cloudfoundry.org/guardian/kawasaki/devices/fakedevices"
@@ -22,6 +23,7 @@ var _ = Describe("Host", func() {
 		vethCreator    *fakedevices.FaveVethCreator
 		linkConfigurer *fakedevices.FakeLink
 		bridger        *fakedevices.FakeBridge
+		shaper         *fakedevices.FakeShaper
 		nsOpener       func(path string) (*os.File, error)
 
 		configurer *configure.Host
@@ -34,6 +36,7 @@ var _ = Describe("Host", func() {
 		vethCreator = &fakedevices.FaveVethCreator{}
 		linkConfigurer = &fakedevices.FakeLink{AddIPReturns: make(map[string]error)}
 		bridger = &fakedevices.FakeBridge{}
+		shaper = &fakedevices.FakeShaper{}
 
 		logger = lagertest.NewTestLogger("test")
 		config = kawasaki.NetworkConfig{}
@@ -45,6 +48,7 @@ var _ = Describe("Host", func() {
 			Link:       linkConfigurer,
 			Bridge:     bridger,
 			FileOpener: netns.Opener(nsOpener),
+			Shaper:     shaper,
 		}
 	})
 
@@ -230,6 +234,25 @@ var _ = Describe("Host", func() {
 		})
 	})
 
+	Describe("LimitBandwidth", func() {
+		It("shapes the host side of the veth pair", func() {
+			config.HostIntf = "host"
+			limits := garden.BandwidthLimits{RateInBytesPerSecond: 100, BurstRateInBytesPerSecond: 200}
+			Expect(configurer.LimitBandwidth(logger, config, limits)).To(Succeed())
+
+			Expect(shaper.ShapeCalledWith.IntfName).To(Equal("host"))
+			Expect(shaper.ShapeCalledWith.Limits).To(Equal(limits))
+		})
+
+		Context("when shaping fails", func() {
+			It("returns the error", func() {
+				shaper.ShapeReturns = errors.New("banana-tc-failure")
+
+				Expect(configurer.LimitBandwidth(logger, config, garden.BandwidthLimits{})).To(MatchError("banana-tc-failure"))
+			})
+		})
+	})
+
 	Describe("Destroy", func() {
 		It("should destroy the bridge", func() {
 			config.HostIntf = "host"
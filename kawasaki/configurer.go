@@ -4,6 +4,7 @@ import (
 	"net"
 	"os"
 
+	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/guardian/kawasaki/netns"
 	"code.cloudfoundry.org/lager"
 )
@@ -24,6 +25,7 @@ type configurer struct {
 //go:generate counterfeiter . HostConfigurer
 type HostConfigurer interface {
 	Apply(logger lager.Logger, cfg NetworkConfig, pid int) error
+	LimitBandwidth(logger lager.Logger, cfg NetworkConfig, limits garden.BandwidthLimits) error
 	Destroy(cfg NetworkConfig) error
 }
 
@@ -68,6 +70,10 @@ func (c *configurer) Apply(log lager.Logger, cfg NetworkConfig, pid int) error {
 	return c.containerConfigurer.Apply(log, cfg, pid)
 }
 
+func (c *configurer) LimitBandwidth(log lager.Logger, cfg NetworkConfig, limits garden.BandwidthLimits) error {
+	return c.hostConfigurer.LimitBandwidth(log, cfg, limits)
+}
+
 func (c *configurer) DestroyBridge(log lager.Logger, cfg NetworkConfig) error {
 	return c.hostConfigurer.Destroy(cfg)
 }
@@ -6,6 +6,7 @@ import (
 	"net"
 	"os"
 
+	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/guardian/kawasaki"
 	fakes "code.cloudfoundry.org/guardian/kawasaki/kawasakifakes"
 	"code.cloudfoundry.org/guardian/kawasaki/netns"
@@ -157,6 +158,30 @@ var _ = Describe("Configurer", func() {
 		})
 	})
 
+	Describe("LimitBandwidth", func() {
+		It("should limit the bandwidth of the host configuration", func() {
+			cfg := kawasaki.NetworkConfig{
+				HostIntf: "banana",
+			}
+			limits := garden.BandwidthLimits{RateInBytesPerSecond: 10, BurstRateInBytesPerSecond: 20}
+			Expect(configurer.LimitBandwidth(logger, cfg, limits)).To(Succeed())
+
+			Expect(fakeHostConfigurer.LimitBandwidthCallCount()).To(Equal(1))
+			_, actualCfg, actualLimits := fakeHostConfigurer.LimitBandwidthArgsForCall(0)
+			Expect(actualCfg).To(Equal(cfg))
+			Expect(actualLimits).To(Equal(limits))
+		})
+
+		Context("when it fails to limit the bandwidth", func() {
+			It("should return the error", func() {
+				fakeHostConfigurer.LimitBandwidthReturns(errors.New("spiderman-error"))
+
+				err := configurer.LimitBandwidth(logger, kawasaki.NetworkConfig{}, garden.BandwidthLimits{})
+				Expect(err).To(MatchError(ContainSubstring("spiderman-error")))
+			})
+		})
+	})
+
 	Describe("DestroyBridge", func() {
 		It("should destroy the host configuration", func() {
 			cfg := kawasaki.NetworkConfig{
@@ -146,3 +146,18 @@ func (f *FakeBridge) Destroy(bridge string) error {
 	f.DestroyCalledWith = append(f.DestroyCalledWith, bridge)
 	return f.DestroyReturns
 }
+
+type FakeShaper struct {
+	ShapeCalledWith struct {
+		IntfName string
+		Limits   garden.BandwidthLimits
+	}
+
+	ShapeReturns error
+}
+
+func (f *FakeShaper) Shape(intfName string, limits garden.BandwidthLimits) error {
+	f.ShapeCalledWith.IntfName = intfName
+	f.ShapeCalledWith.Limits = limits
+	return f.ShapeReturns
+}
@@ -0,0 +1,79 @@
+package devices
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"code.cloudfoundry.org/garden"
+	"github.com/cloudfoundry/gunk/command_runner"
+)
+
+const (
+	// defaultBurstTicksPerSecond is the lowest kernel tick rate, so that a
+	// burst of rate/defaultBurstTicksPerSecond lasts at least one tick
+	defaultBurstTicksPerSecond = 100
+
+	// defaultMinBurstBytes fits the largest segment the kernel hands to the
+	// qdisc with offloads enabled, since bigger packets are always dropped
+	defaultMinBurstBytes = 64 * 1024
+)
+
+// TrafficShaper limits the bandwidth of an interface using 'tc'. Egress is
+// shaped with a token bucket filter and ingress is policed, so the same rate
+// and burst apply in both directions. A burst of zero, which tc rejects,
+// defaults to one tick's worth of the rate or 64KiB, whichever is larger.
+type TrafficShaper struct {
+	TCPath string
+	Runner command_runner.CommandRunner
+}
+
+func (s *TrafficShaper) Shape(intfName string, limits garden.BandwidthLimits) error {
+	// remove any existing limits, these fail harmlessly if none are set
+	s.tc("qdisc", "del", "dev", intfName, "root")
+	s.tc("qdisc", "del", "dev", intfName, "ingress")
+
+	if limits.RateInBytesPerSecond == 0 {
+		return nil
+	}
+
+	rate := fmt.Sprintf("%dbit", limits.RateInBytesPerSecond*8)
+	burst := fmt.Sprintf("%d", burstBytes(limits))
+
+	if err := s.tc("qdisc", "add", "dev", intfName, "root", "tbf", "rate", rate, "burst", burst, "latency", "25ms"); err != nil {
+		return err
+	}
+
+	if err := s.tc("qdisc", "add", "dev", intfName, "handle", "ffff:", "ingress"); err != nil {
+		return err
+	}
+
+	return s.tc("filter", "add", "dev", intfName, "parent", "ffff:", "protocol", "all", "prio", "1",
+		"u32", "match", "u32", "0", "0", "police", "rate", rate, "burst", burst, "drop", "flowid", ":1")
+}
+
+func burstBytes(limits garden.BandwidthLimits) uint64 {
+	if limits.BurstRateInBytesPerSecond != 0 {
+		return limits.BurstRateInBytesPerSecond
+	}
+
+	burst := limits.RateInBytesPerSecond / defaultBurstTicksPerSecond
+	if burst < defaultMinBurstBytes {
+		return defaultMinBurstBytes
+	}
+
+	return burst
+}
+
+func (s *TrafficShaper) tc(args ...string) error {
+	stderr := new(bytes.Buffer)
+	cmd := exec.Command(s.TCPath, args...)
+	cmd.Stderr = stderr
+
+	if err := s.Runner.Run(cmd); err != nil {
+		return fmt.Errorf("devices: tc %s: %s: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return nil
+}
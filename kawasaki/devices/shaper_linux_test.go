@@ -0,0 +1,113 @@
+package devices_test
+
+import (
+	"errors"
+	"os/exec"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/kawasaki/devices"
+	"github.com/cloudfoundry/gunk/command_runner/fake_command_runner"
+	. "github.com/cloudfoundry/gunk/command_runner/fake_command_runner/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("TrafficShaper", func() {
+	var (
+		runner *fake_command_runner.FakeCommandRunner
+		shaper *devices.TrafficShaper
+	)
+
+	BeforeEach(func() {
+		runner = fake_command_runner.New()
+		shaper = &devices.TrafficShaper{TCPath: "/sbin/tc", Runner: runner}
+	})
+
+	It("replaces any existing limits with egress shaping and ingress policing", func() {
+		Expect(shaper.Shape("w1abc-0", garden.BandwidthLimits{
+			RateInBytesPerSecond:      1000,
+			BurstRateInBytesPerSecond: 2000,
+		})).To(Succeed())
+
+		Expect(runner).To(HaveExecutedSerially(
+			fake_command_runner.CommandSpec{
+				Path: "/sbin/tc",
+				Args: []string{"qdisc", "del", "dev", "w1abc-0", "root"},
+			},
+			fake_command_runner.CommandSpec{
+				Path: "/sbin/tc",
+				Args: []string{"qdisc", "del", "dev", "w1abc-0", "ingress"},
+			},
+			fake_command_runner.CommandSpec{
+				Path: "/sbin/tc",
+				Args: []string{"qdisc", "add", "dev", "w1abc-0", "root", "tbf", "rate", "8000bit", "burst", "2000", "latency", "25ms"},
+			},
+			fake_command_runner.CommandSpec{
+				Path: "/sbin/tc",
+				Args: []string{"qdisc", "add", "dev", "w1abc-0", "handle", "ffff:", "ingress"},
+			},
+			fake_command_runner.CommandSpec{
+				Path: "/sbin/tc",
+				Args: []string{"filter", "add", "dev", "w1abc-0", "parent", "ffff:", "protocol", "all", "prio", "1",
+					"u32", "match", "u32", "0", "0", "police", "rate", "8000bit", "burst", "2000", "drop", "flowid", ":1"},
+			},
+		))
+	})
+
+	Context("when the rate is zero", func() {
+		It("only removes the existing limits", func() {
+			Expect(shaper.Shape("w1abc-0", garden.BandwidthLimits{})).To(Succeed())
+			Expect(runner.ExecutedCommands()).To(HaveLen(2))
+		})
+	})
+
+	Context("when the burst is zero", func() {
+		It("defaults it to one tick's worth of the rate", func() {
+			Expect(shaper.Shape("w1abc-0", garden.BandwidthLimits{RateInBytesPerSecond: 100 * 1024 * 1024})).To(Succeed())
+			Expect(runner).To(HaveExecutedSerially(fake_command_runner.CommandSpec{
+				Path: "/sbin/tc",
+				Args: []string{"qdisc", "add", "dev", "w1abc-0", "root", "tbf", "rate", "838860800bit", "burst", "1048576", "latency", "25ms"},
+			}))
+		})
+
+		It("defaults it to at least 64KiB for slow rates", func() {
+			Expect(shaper.Shape("w1abc-0", garden.BandwidthLimits{RateInBytesPerSecond: 1000})).To(Succeed())
+			Expect(runner).To(HaveExecutedSerially(fake_command_runner.CommandSpec{
+				Path: "/sbin/tc",
+				Args: []string{"filter", "add", "dev", "w1abc-0", "parent", "ffff:", "protocol", "all", "prio", "1",
+					"u32", "match", "u32", "0", "0", "police", "rate", "8000bit", "burst", "65536", "drop", "flowid", ":1"},
+			}))
+		})
+	})
+
+	Context("when removing the existing limits fails", func() {
+		BeforeEach(func() {
+			runner.WhenRunning(fake_command_runner.CommandSpec{
+				Args: []string{"qdisc", "del", "dev", "w1abc-0", "root"},
+			}, func(*exec.Cmd) error {
+				return errors.New("no qdisc")
+			})
+		})
+
+		It("carries on", func() {
+			Expect(shaper.Shape("w1abc-0", garden.BandwidthLimits{RateInBytesPerSecond: 1, BurstRateInBytesPerSecond: 1})).To(Succeed())
+		})
+	})
+
+	Context("when adding a limit fails", func() {
+		BeforeEach(func() {
+			runner.WhenRunning(fake_command_runner.CommandSpec{
+				Args: []string{"qdisc", "add", "dev", "w1abc-0", "handle", "ffff:", "ingress"},
+			}, func(cmd *exec.Cmd) error {
+				cmd.Stderr.Write([]byte("RTNETLINK answers: File exists"))
+				return errors.New("exit status 2")
+			})
+		})
+
+		It("returns an error including tc's output", func() {
+			err := shaper.Shape("w1abc-0", garden.BandwidthLimits{RateInBytesPerSecond: 1, BurstRateInBytesPerSecond: 1})
+			Expect(err).To(MatchError(ContainSubstring("exit status 2")))
+			Expect(err).To(MatchError(ContainSubstring("RTNETLINK answers: File exists")))
+		})
+	})
+})
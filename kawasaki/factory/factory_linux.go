@@ -9,9 +9,10 @@ import (
 	"code.cloudfoundry.org/guardian/kawasaki/dns"
 	"code.cloudfoundry.org/guardian/kawasaki/iptables"
 	"code.cloudfoundry.org/guardian/kawasaki/netns"
+	"github.com/cloudfoundry/gunk/command_runner/linux_command_runner"
 )
 
-func NewDefaultConfigurer(ipt *iptables.IPTablesController) kawasaki.Configurer {
+func NewDefaultConfigurer(ipt *iptables.IPTablesController, tcPath string) kawasaki.Configurer {
 	resolvConfigurer := &kawasaki.ResolvConfigurer{
 		HostsFileCompiler:  &dns.HostsFileCompiler{},
 		ResolvFileCompiler: &dns.ResolvFileCompiler{},
@@ -24,6 +25,10 @@ func NewDefaultConfigurer(ipt *iptables.IPTablesController) kawasaki.Configurer
 		Link:       &devices.Link{},
 		Bridge:     &devices.Bridge{},
 		FileOpener: netns.Opener(os.Open),
+		Shaper: &devices.TrafficShaper{
+			TCPath: tcPath,
+			Runner: linux_command_runner.New(),
+		},
 	}
 
 	containerConfigurer := &configure.Container{
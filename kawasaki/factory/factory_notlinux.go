@@ -7,6 +7,6 @@ import (
 	"code.cloudfoundry.org/guardian/kawasaki/iptables"
 )
 
-func NewDefaultConfigurer(ipt *iptables.IPTablesController) kawasaki.Configurer {
+func NewDefaultConfigurer(ipt *iptables.IPTablesController, tcPath string) kawasaki.Configurer {
 	panic("not supported on this platform")
 }
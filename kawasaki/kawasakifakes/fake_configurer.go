@@ -4,6 +4,7 @@ package kawasakifakes
 import (
 	"sync"
 
+	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/guardian/kawasaki"
 	"code.cloudfoundry.org/lager"
 )
@@ -19,6 +20,16 @@ type FakeConfigurer struct {
 	applyReturns struct {
 		result1 error
 	}
+	LimitBandwidthStub        func(log lager.Logger, cfg kawasaki.NetworkConfig, limits garden.BandwidthLimits) error
+	limitBandwidthMutex       sync.RWMutex
+	limitBandwidthArgsForCall []struct {
+		log    lager.Logger
+		cfg    kawasaki.NetworkConfig
+		limits garden.BandwidthLimits
+	}
+	limitBandwidthReturns struct {
+		result1 error
+	}
 	DestroyBridgeStub        func(log lager.Logger, cfg kawasaki.NetworkConfig) error
 	destroyBridgeMutex       sync.RWMutex
 	destroyBridgeArgsForCall []struct {
@@ -75,6 +86,40 @@ func (fake *FakeConfigurer) ApplyReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeConfigurer) LimitBandwidth(log lager.Logger, cfg kawasaki.NetworkConfig, limits garden.BandwidthLimits) error {
+	fake.limitBandwidthMutex.Lock()
+	fake.limitBandwidthArgsForCall = append(fake.limitBandwidthArgsForCall, struct {
+		log    lager.Logger
+		cfg    kawasaki.NetworkConfig
+		limits garden.BandwidthLimits
+	}{log, cfg, limits})
+	fake.recordInvocation("LimitBandwidth", []interface{}{log, cfg, limits})
+	fake.limitBandwidthMutex.Unlock()
+	if fake.LimitBandwidthStub != nil {
+		return fake.LimitBandwidthStub(log, cfg, limits)
+	}
+	return fake.limitBandwidthReturns.result1
+}
+
+func (fake *FakeConfigurer) LimitBandwidthCallCount() int {
+	fake.limitBandwidthMutex.RLock()
+	defer fake.limitBandwidthMutex.RUnlock()
+	return len(fake.limitBandwidthArgsForCall)
+}
+
+func (fake *FakeConfigurer) LimitBandwidthArgsForCall(i int) (lager.Logger, kawasaki.NetworkConfig, garden.BandwidthLimits) {
+	fake.limitBandwidthMutex.RLock()
+	defer fake.limitBandwidthMutex.RUnlock()
+	return fake.limitBandwidthArgsForCall[i].log, fake.limitBandwidthArgsForCall[i].cfg, fake.limitBandwidthArgsForCall[i].limits
+}
+
+func (fake *FakeConfigurer) LimitBandwidthReturns(result1 error) {
+	fake.LimitBandwidthStub = nil
+	fake.limitBandwidthReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeConfigurer) DestroyBridge(log lager.Logger, cfg kawasaki.NetworkConfig) error {
 	fake.destroyBridgeMutex.Lock()
 	fake.destroyBridgeArgsForCall = append(fake.destroyBridgeArgsForCall, struct {
@@ -146,6 +191,8 @@ func (fake *FakeConfigurer) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.applyMutex.RLock()
 	defer fake.applyMutex.RUnlock()
+	fake.limitBandwidthMutex.RLock()
+	defer fake.limitBandwidthMutex.RUnlock()
 	fake.destroyBridgeMutex.RLock()
 	defer fake.destroyBridgeMutex.RUnlock()
 	fake.destroyIPTablesRulesMutex.RLock()
@@ -4,6 +4,7 @@ package kawasakifakes
 import (
 	"sync"
 
+	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/guardian/kawasaki"
 	"code.cloudfoundry.org/lager"
 )
@@ -19,6 +20,16 @@ type FakeHostConfigurer struct {
 	applyReturns struct {
 		result1 error
 	}
+	LimitBandwidthStub        func(logger lager.Logger, cfg kawasaki.NetworkConfig, limits garden.BandwidthLimits) error
+	limitBandwidthMutex       sync.RWMutex
+	limitBandwidthArgsForCall []struct {
+		logger lager.Logger
+		cfg    kawasaki.NetworkConfig
+		limits garden.BandwidthLimits
+	}
+	limitBandwidthReturns struct {
+		result1 error
+	}
 	DestroyStub        func(cfg kawasaki.NetworkConfig) error
 	destroyMutex       sync.RWMutex
 	destroyArgsForCall []struct {
@@ -65,6 +76,40 @@ func (fake *FakeHostConfigurer) ApplyReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeHostConfigurer) LimitBandwidth(logger lager.Logger, cfg kawasaki.NetworkConfig, limits garden.BandwidthLimits) error {
+	fake.limitBandwidthMutex.Lock()
+	fake.limitBandwidthArgsForCall = append(fake.limitBandwidthArgsForCall, struct {
+		logger lager.Logger
+		cfg    kawasaki.NetworkConfig
+		limits garden.BandwidthLimits
+	}{logger, cfg, limits})
+	fake.recordInvocation("LimitBandwidth", []interface{}{logger, cfg, limits})
+	fake.limitBandwidthMutex.Unlock()
+	if fake.LimitBandwidthStub != nil {
+		return fake.LimitBandwidthStub(logger, cfg, limits)
+	}
+	return fake.limitBandwidthReturns.result1
+}
+
+func (fake *FakeHostConfigurer) LimitBandwidthCallCount() int {
+	fake.limitBandwidthMutex.RLock()
+	defer fake.limitBandwidthMutex.RUnlock()
+	return len(fake.limitBandwidthArgsForCall)
+}
+
+func (fake *FakeHostConfigurer) LimitBandwidthArgsForCall(i int) (lager.Logger, kawasaki.NetworkConfig, garden.BandwidthLimits) {
+	fake.limitBandwidthMutex.RLock()
+	defer fake.limitBandwidthMutex.RUnlock()
+	return fake.limitBandwidthArgsForCall[i].logger, fake.limitBandwidthArgsForCall[i].cfg, fake.limitBandwidthArgsForCall[i].limits
+}
+
+func (fake *FakeHostConfigurer) LimitBandwidthReturns(result1 error) {
+	fake.LimitBandwidthStub = nil
+	fake.limitBandwidthReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeHostConfigurer) Destroy(cfg kawasaki.NetworkConfig) error {
 	fake.destroyMutex.Lock()
 	fake.destroyArgsForCall = append(fake.destroyArgsForCall, struct {
@@ -102,6 +147,8 @@ func (fake *FakeHostConfigurer) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.applyMutex.RLock()
 	defer fake.applyMutex.RUnlock()
+	fake.limitBandwidthMutex.RLock()
+	defer fake.limitBandwidthMutex.RUnlock()
 	fake.destroyMutex.RLock()
 	defer fake.destroyMutex.RUnlock()
 	return fake.invocations
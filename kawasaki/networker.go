@@ -27,6 +27,9 @@ const iptablePrefixKey = "kawasaki.iptable-prefix"
 const iptableInstanceKey = "kawasaki.iptable-inst"
 const mtuKey = "kawasaki.mtu"
 const dnsServerKey = "kawasaki.dns-servers"
+const bandwidthRateKey = "kawasaki.bandwidth-rate"
+const bandwidthBurstKey = "kawasaki.bandwidth-burst"
+const netOutRulesKey = "kawasaki.net-out-rules"
 
 //go:generate counterfeiter . SpecParser
 
@@ -44,6 +47,7 @@ type ConfigCreator interface {
 
 type Configurer interface {
 	Apply(log lager.Logger, cfg NetworkConfig, pid int) error
+	LimitBandwidth(log lager.Logger, cfg NetworkConfig, limits garden.BandwidthLimits) error
 	DestroyBridge(log lager.Logger, cfg NetworkConfig) error
 	DestroyIPTablesRules(log lager.Logger, cfg NetworkConfig) error
 }
@@ -94,6 +98,9 @@ type Networker interface {
 	NetIn(log lager.Logger, handle string, externalPort, containerPort uint32) (uint32, uint32, error)
 	NetOut(log lager.Logger, handle string, rule garden.NetOutRule) error
 	BulkNetOut(log lager.Logger, handle string, rules []garden.NetOutRule) error
+	LimitBandwidth(log lager.Logger, handle string, limits garden.BandwidthLimits) error
+	BandwidthLimits(log lager.Logger, handle string) (garden.BandwidthLimits, error)
+	Reconfigure(log lager.Logger, handle string, pid int) error
 	Restore(log lager.Logger, handle string) error
 }
 
@@ -232,7 +239,11 @@ func (n *networker) NetOut(log lager.Logger, handle string, rule garden.NetOutRu
 		return err
 	}
 
-	return n.firewallOpener.Open(log, cfg.IPTableInstance, handle, rule)
+	if err := n.firewallOpener.Open(log, cfg.IPTableInstance, handle, rule); err != nil {
+		return err
+	}
+
+	return addNetOutRules(n.configStore, handle, []garden.NetOutRule{rule})
 }
 
 func (n *networker) BulkNetOut(log lager.Logger, handle string, rules []garden.NetOutRule) error {
@@ -241,7 +252,39 @@ func (n *networker) BulkNetOut(log lager.Logger, handle string, rules []garden.N
 		return err
 	}
 
-	return n.firewallOpener.BulkOpen(log, cfg.IPTableInstance, handle, rules)
+	if err := n.firewallOpener.BulkOpen(log, cfg.IPTableInstance, handle, rules); err != nil {
+		return err
+	}
+
+	return addNetOutRules(n.configStore, handle, rules)
+}
+
+func (n *networker) LimitBandwidth(log lager.Logger, handle string, limits garden.BandwidthLimits) error {
+	log = log.Session("limit-bandwidth", lager.Data{"handle": handle, "limits": limits})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	cfg, err := load(n.configStore, handle)
+	if err != nil {
+		log.Error("load-config-failed", err)
+		return err
+	}
+
+	if err := n.configurer.LimitBandwidth(log, cfg, limits); err != nil {
+		log.Error("configure-failed", err)
+		return err
+	}
+
+	n.configStore.Set(handle, bandwidthRateKey, strconv.FormatUint(limits.RateInBytesPerSecond, 10))
+	n.configStore.Set(handle, bandwidthBurstKey, strconv.FormatUint(limits.BurstRateInBytesPerSecond, 10))
+
+	return nil
+}
+
+func (n *networker) BandwidthLimits(log lager.Logger, handle string) (garden.BandwidthLimits, error) {
+	limits, _, err := loadBandwidthLimits(n.configStore, handle)
+	return limits, err
 }
 
 func (n *networker) Destroy(log lager.Logger, handle string) error {
@@ -278,6 +321,78 @@ func (n *networker) Destroy(log lager.Logger, handle string) error {
 	return err
 }
 
+// Reconfigure re-applies the saved network configuration of a container to a
+// new init process, e.g. one which has been restored from a checkpoint
+func (n *networker) Reconfigure(log lager.Logger, handle string, pid int) error {
+	log = log.Session("reconfigure", lager.Data{"handle": handle, "pid": pid})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	cfg, err := load(n.configStore, handle)
+	if err != nil {
+		log.Error("load-config-failed", err)
+		return err
+	}
+
+	// the previous incarnation may have left its instance chains behind
+	if err := n.configurer.DestroyIPTablesRules(log, cfg); err != nil {
+		log.Error("destroy-iptables-rules-failed", err)
+		return err
+	}
+
+	if err := n.configurer.Apply(log, cfg, pid); err != nil {
+		log.Error("apply-failed", err)
+		return err
+	}
+
+	if mappingsJson, ok := n.configStore.Get(handle, gardener.MappedPortsKey); ok {
+		mappings, err := portsFromJson(mappingsJson)
+		if err != nil {
+			return fmt.Errorf("unmarshaling port mappings %s: %v", handle, err)
+		}
+
+		for _, m := range mappings {
+			if err := n.portForwarder.Forward(PortForwarderSpec{
+				InstanceID:  cfg.IPTableInstance,
+				Handle:      handle,
+				FromPort:    m.HostPort,
+				ToPort:      m.ContainerPort,
+				ContainerIP: cfg.ContainerIP,
+				ExternalIP:  cfg.ExternalIP,
+			}); err != nil {
+				log.Error("forward-failed", err)
+				return err
+			}
+		}
+	}
+
+	netOutRules, err := loadNetOutRules(n.configStore, handle)
+	if err != nil {
+		return fmt.Errorf("loading net out rules %s: %v", handle, err)
+	}
+
+	if len(netOutRules) > 0 {
+		if err := n.firewallOpener.BulkOpen(log, cfg.IPTableInstance, handle, netOutRules); err != nil {
+			log.Error("open-failed", err)
+			return err
+		}
+	}
+
+	bandwidthLimits, limited, err := loadBandwidthLimits(n.configStore, handle)
+	if err != nil {
+		return fmt.Errorf("loading bandwidth limits %s: %v", handle, err)
+	}
+
+	if limited {
+		if err := n.configurer.LimitBandwidth(log, cfg, bandwidthLimits); err != nil {
+			return fmt.Errorf("limiting bandwidth %s: %v", handle, err)
+		}
+	}
+
+	return nil
+}
+
 func (n *networker) Restore(log lager.Logger, handle string) error {
 	networkConfig, err := load(n.configStore, handle)
 	if err != nil {
@@ -289,6 +404,17 @@ func (n *networker) Restore(log lager.Logger, handle string) error {
 		return fmt.Errorf("subnet pool removing %s: %v", handle, err)
 	}
 
+	bandwidthLimits, limited, err := loadBandwidthLimits(n.configStore, handle)
+	if err != nil {
+		return fmt.Errorf("loading bandwidth limits %s: %v", handle, err)
+	}
+
+	if limited {
+		if err := n.configurer.LimitBandwidth(log, networkConfig, bandwidthLimits); err != nil {
+			return fmt.Errorf("limiting bandwidth %s: %v", handle, err)
+		}
+	}
+
 	currentMappingsJson, ok := n.configStore.Get(handle, gardener.MappedPortsKey)
 	if !ok {
 		return nil
@@ -323,6 +449,41 @@ func AddPortMapping(logger lager.Logger, configStore ConfigStore, handle string,
 	return nil
 }
 
+// addNetOutRules records opened rules so that they can be opened again when
+// the container's network is reconfigured
+func addNetOutRules(configStore ConfigStore, handle string, rules []garden.NetOutRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+
+	currentRules, err := loadNetOutRules(configStore, handle)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(append(currentRules, rules...))
+	if err != nil {
+		return err
+	}
+
+	configStore.Set(handle, netOutRulesKey, string(b))
+	return nil
+}
+
+func loadNetOutRules(configStore ConfigStore, handle string) ([]garden.NetOutRule, error) {
+	rulesJson, ok := configStore.Get(handle, netOutRulesKey)
+	if !ok {
+		return nil, nil
+	}
+
+	var rules []garden.NetOutRule
+	if err := json.Unmarshal([]byte(rulesJson), &rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
 func getAll(config ConfigStore, handle string, key ...string) (vals []string, err error) {
 	for _, k := range key {
 		v, ok := config.Get(handle, k)
@@ -410,6 +571,33 @@ func load(config ConfigStore, handle string) (NetworkConfig, error) {
 	}, nil
 }
 
+func loadBandwidthLimits(config ConfigStore, handle string) (garden.BandwidthLimits, bool, error) {
+	rate, ok := config.Get(handle, bandwidthRateKey)
+	if !ok {
+		return garden.BandwidthLimits{}, false, nil
+	}
+
+	vals, err := getAll(config, handle, bandwidthBurstKey)
+	if err != nil {
+		return garden.BandwidthLimits{}, false, err
+	}
+
+	rateInBytesPerSecond, err := strconv.ParseUint(rate, 10, 64)
+	if err != nil {
+		return garden.BandwidthLimits{}, false, err
+	}
+
+	burstRateInBytesPerSecond, err := strconv.ParseUint(vals[0], 10, 64)
+	if err != nil {
+		return garden.BandwidthLimits{}, false, err
+	}
+
+	return garden.BandwidthLimits{
+		RateInBytesPerSecond:      rateInBytesPerSecond,
+		BurstRateInBytesPerSecond: burstRateInBytesPerSecond,
+	}, true, nil
+}
+
 type portMappingList []garden.PortMapping
 
 func (l portMappingList) toJson() string {
@@ -397,6 +397,29 @@ var _ = Describe("Networker", func() {
 			Expect(handleArg).To(Equal("some-handle"))
 			Expect(ruleArg).To(Equal(rule))
 		})
+
+		It("records the rule after the already opened ones", func() {
+			config["kawasaki.net-out-rules"] = `[{"protocol":1}]`
+			rule := garden.NetOutRule{Protocol: garden.ProtocolICMP}
+
+			Expect(networker.NetOut(lagertest.NewTestLogger(""), "some-handle", rule)).To(Succeed())
+
+			Expect(fakeConfigStore.SetCallCount()).To(Equal(1))
+			handle, name, value := fakeConfigStore.SetArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(name).To(Equal("kawasaki.net-out-rules"))
+
+			var rules []garden.NetOutRule
+			Expect(json.Unmarshal([]byte(value), &rules)).To(Succeed())
+			Expect(rules).To(Equal([]garden.NetOutRule{{Protocol: garden.ProtocolTCP}, rule}))
+		})
+
+		It("does not record a rule which failed to open", func() {
+			fakeFirewallOpener.OpenReturns(errors.New("potato"))
+			networker.NetOut(lagertest.NewTestLogger(""), "some-handle", garden.NetOutRule{})
+
+			Expect(fakeConfigStore.SetCallCount()).To(Equal(0))
+		})
 	})
 
 	Describe("BulkNetOut", func() {
@@ -414,6 +437,23 @@ var _ = Describe("Networker", func() {
 			Expect(handleArg).To(Equal("some-handle"))
 			Expect(rulesArg).To(Equal(rules))
 		})
+
+		It("records the rules", func() {
+			rules := []garden.NetOutRule{
+				{Protocol: garden.ProtocolICMP},
+				{Protocol: garden.ProtocolTCP},
+			}
+
+			Expect(networker.BulkNetOut(lagertest.NewTestLogger(""), "some-handle", rules)).To(Succeed())
+
+			Expect(fakeConfigStore.SetCallCount()).To(Equal(1))
+			_, name, value := fakeConfigStore.SetArgsForCall(0)
+			Expect(name).To(Equal("kawasaki.net-out-rules"))
+
+			var recorded []garden.NetOutRule
+			Expect(json.Unmarshal([]byte(value), &recorded)).To(Succeed())
+			Expect(recorded).To(Equal(rules))
+		})
 	})
 
 	Describe("NetIn", func() {
@@ -551,6 +591,221 @@ var _ = Describe("Networker", func() {
 		})
 	})
 
+	Describe("LimitBandwidth", func() {
+		var limits garden.BandwidthLimits
+
+		BeforeEach(func() {
+			limits = garden.BandwidthLimits{RateInBytesPerSecond: 1024, BurstRateInBytesPerSecond: 2048}
+		})
+
+		It("applies the limits to the container's network configuration", func() {
+			Expect(networker.LimitBandwidth(logger, "some-handle", limits)).To(Succeed())
+
+			Expect(fakeConfigurer.LimitBandwidthCallCount()).To(Equal(1))
+			_, cfg, actualLimits := fakeConfigurer.LimitBandwidthArgsForCall(0)
+			Expect(cfg.HostIntf).To(Equal("banana-iface"))
+			Expect(actualLimits).To(Equal(limits))
+		})
+
+		It("stores the limits in the config store", func() {
+			Expect(networker.LimitBandwidth(logger, "some-handle", limits)).To(Succeed())
+
+			Expect(fakeConfigStore.SetCallCount()).To(Equal(2))
+			handle, name, value := fakeConfigStore.SetArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(name).To(Equal("kawasaki.bandwidth-rate"))
+			Expect(value).To(Equal("1024"))
+
+			handle, name, value = fakeConfigStore.SetArgsForCall(1)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(name).To(Equal("kawasaki.bandwidth-burst"))
+			Expect(value).To(Equal("2048"))
+		})
+
+		Context("when the config couldn't be loaded", func() {
+			It("returns an error", func() {
+				config = nil
+				Expect(networker.LimitBandwidth(logger, "some-handle", limits)).To(MatchError(ContainSubstring("property not found")))
+			})
+		})
+
+		Context("when applying the limits fails", func() {
+			BeforeEach(func() {
+				fakeConfigurer.LimitBandwidthReturns(errors.New("tc-failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(networker.LimitBandwidth(logger, "some-handle", limits)).To(MatchError("tc-failed"))
+			})
+
+			It("does not store the limits", func() {
+				networker.LimitBandwidth(logger, "some-handle", limits)
+				Expect(fakeConfigStore.SetCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("BandwidthLimits", func() {
+		Context("when limits have been stored", func() {
+			BeforeEach(func() {
+				config["kawasaki.bandwidth-rate"] = "1024"
+				config["kawasaki.bandwidth-burst"] = "2048"
+			})
+
+			It("returns the stored limits", func() {
+				Expect(networker.BandwidthLimits(logger, "some-handle")).To(Equal(garden.BandwidthLimits{
+					RateInBytesPerSecond:      1024,
+					BurstRateInBytesPerSecond: 2048,
+				}))
+			})
+		})
+
+		Context("when no limits have been stored", func() {
+			It("returns empty limits", func() {
+				Expect(networker.BandwidthLimits(logger, "some-handle")).To(Equal(garden.BandwidthLimits{}))
+			})
+		})
+
+		Context("when the stored limits are not valid", func() {
+			BeforeEach(func() {
+				config["kawasaki.bandwidth-rate"] = "potato"
+				config["kawasaki.bandwidth-burst"] = "2048"
+			})
+
+			It("returns an error", func() {
+				_, err := networker.BandwidthLimits(logger, "some-handle")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("Reconfigure", func() {
+		It("clears the container's old iptables rules", func() {
+			Expect(networker.Reconfigure(logger, "some-handle", 42)).To(Succeed())
+
+			Expect(fakeConfigurer.DestroyIPTablesRulesCallCount()).To(Equal(1))
+			_, cfg := fakeConfigurer.DestroyIPTablesRulesArgsForCall(0)
+			Expect(cfg.IPTableInstance).To(Equal("table"))
+		})
+
+		It("applies the saved configuration to the given pid", func() {
+			Expect(networker.Reconfigure(logger, "some-handle", 42)).To(Succeed())
+
+			Expect(fakeConfigurer.ApplyCallCount()).To(Equal(1))
+			_, cfg, pid := fakeConfigurer.ApplyArgsForCall(0)
+			Expect(cfg.HostIntf).To(Equal("banana-iface"))
+			Expect(cfg.ContainerIP.String()).To(Equal("123.123.123.12"))
+			Expect(pid).To(Equal(42))
+		})
+
+		It("re-forwards the saved port mappings", func() {
+			Expect(networker.Reconfigure(logger, "some-handle", 42)).To(Succeed())
+
+			Expect(fakePortForwarder.ForwardCallCount()).To(Equal(1))
+			spec := fakePortForwarder.ForwardArgsForCall(0)
+			Expect(spec.Handle).To(Equal("some-handle"))
+			Expect(spec.InstanceID).To(Equal("table"))
+			Expect(spec.FromPort).To(BeEquivalentTo(60000))
+			Expect(spec.ToPort).To(BeEquivalentTo(8080))
+		})
+
+		It("does not acquire any new resources", func() {
+			Expect(networker.Reconfigure(logger, "some-handle", 42)).To(Succeed())
+			Expect(fakeSubnetPool.AcquireCallCount()).To(Equal(0))
+			Expect(fakePortPool.AcquireCallCount()).To(Equal(0))
+		})
+
+		It("does not open any rules when none were recorded", func() {
+			Expect(networker.Reconfigure(logger, "some-handle", 42)).To(Succeed())
+			Expect(fakeFirewallOpener.BulkOpenCallCount()).To(Equal(0))
+		})
+
+		Context("when net out rules were recorded", func() {
+			BeforeEach(func() {
+				config["kawasaki.net-out-rules"] = `[{"protocol":1},{"protocol":3}]`
+			})
+
+			It("re-opens them", func() {
+				Expect(networker.Reconfigure(logger, "some-handle", 42)).To(Succeed())
+
+				Expect(fakeFirewallOpener.BulkOpenCallCount()).To(Equal(1))
+				_, chain, handle, rules := fakeFirewallOpener.BulkOpenArgsForCall(0)
+				Expect(chain).To(Equal("table"))
+				Expect(handle).To(Equal("some-handle"))
+				Expect(rules).To(Equal([]garden.NetOutRule{
+					{Protocol: garden.ProtocolTCP},
+					{Protocol: garden.ProtocolICMP},
+				}))
+			})
+
+			Context("and opening them fails", func() {
+				BeforeEach(func() {
+					fakeFirewallOpener.BulkOpenReturns(errors.New("open-failed"))
+				})
+
+				It("returns the error", func() {
+					Expect(networker.Reconfigure(logger, "some-handle", 42)).To(MatchError("open-failed"))
+				})
+			})
+		})
+
+		Context("when bandwidth limits were stored", func() {
+			BeforeEach(func() {
+				config["kawasaki.bandwidth-rate"] = "1024"
+				config["kawasaki.bandwidth-burst"] = "2048"
+			})
+
+			It("re-applies the limits", func() {
+				Expect(networker.Reconfigure(logger, "some-handle", 42)).To(Succeed())
+
+				Expect(fakeConfigurer.LimitBandwidthCallCount()).To(Equal(1))
+				_, _, limits := fakeConfigurer.LimitBandwidthArgsForCall(0)
+				Expect(limits).To(Equal(garden.BandwidthLimits{
+					RateInBytesPerSecond:      1024,
+					BurstRateInBytesPerSecond: 2048,
+				}))
+			})
+		})
+
+		Context("when the config couldn't be loaded", func() {
+			It("returns an error", func() {
+				config = nil
+				Expect(networker.Reconfigure(logger, "some-handle", 42)).To(MatchError(ContainSubstring("property not found")))
+			})
+		})
+
+		Context("when clearing the old iptables rules fails", func() {
+			BeforeEach(func() {
+				fakeConfigurer.DestroyIPTablesRulesReturns(errors.New("iptables-failed"))
+			})
+
+			It("returns the error without applying the configuration", func() {
+				Expect(networker.Reconfigure(logger, "some-handle", 42)).To(MatchError("iptables-failed"))
+				Expect(fakeConfigurer.ApplyCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when applying the configuration fails", func() {
+			BeforeEach(func() {
+				fakeConfigurer.ApplyReturns(errors.New("apply-failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(networker.Reconfigure(logger, "some-handle", 42)).To(MatchError("apply-failed"))
+			})
+		})
+
+		Context("when forwarding a port fails", func() {
+			BeforeEach(func() {
+				fakePortForwarder.ForwardReturns(errors.New("forward-failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(networker.Reconfigure(logger, "some-handle", 42)).To(MatchError("forward-failed"))
+			})
+		})
+	})
+
 	Describe("Restore", func() {
 		It("removes the subnet from the the subnet pool", func() {
 			Expect(networker.Restore(logger, "some-handle")).To(Succeed())
@@ -583,6 +838,40 @@ var _ = Describe("Networker", func() {
 			})
 		})
 
+		It("does not limit the bandwidth when no limits were stored", func() {
+			Expect(networker.Restore(logger, "some-handle")).To(Succeed())
+			Expect(fakeConfigurer.LimitBandwidthCallCount()).To(Equal(0))
+		})
+
+		Context("when bandwidth limits were stored", func() {
+			BeforeEach(func() {
+				config["kawasaki.bandwidth-rate"] = "1024"
+				config["kawasaki.bandwidth-burst"] = "2048"
+			})
+
+			It("re-applies the limits", func() {
+				Expect(networker.Restore(logger, "some-handle")).To(Succeed())
+
+				Expect(fakeConfigurer.LimitBandwidthCallCount()).To(Equal(1))
+				_, cfg, limits := fakeConfigurer.LimitBandwidthArgsForCall(0)
+				Expect(cfg.HostIntf).To(Equal("banana-iface"))
+				Expect(limits).To(Equal(garden.BandwidthLimits{
+					RateInBytesPerSecond:      1024,
+					BurstRateInBytesPerSecond: 2048,
+				}))
+			})
+
+			Context("when re-applying the limits fails", func() {
+				BeforeEach(func() {
+					fakeConfigurer.LimitBandwidthReturns(errors.New("tc-failed"))
+				})
+
+				It("returns an appropriate error", func() {
+					Expect(networker.Restore(logger, "some-handle")).To(MatchError("limiting bandwidth some-handle: tc-failed"))
+				})
+			})
+		})
+
 		Context("when there are no port mappings", func() {
 			BeforeEach(func() {
 				delete(config, gardener.MappedPortsKey)
@@ -12,7 +12,9 @@ import (
 	"github.com/tedsuo/ifrit/http_server"
 )
 
-func StartDebugServer(address string, sink *lager.ReconfigurableSink, metrics Metrics) (ifrit.Process, error) {
+// StartDebugServer serves pprof, expvars and any extra handlers, which are
+// keyed by the path they are mounted on.
+func StartDebugServer(address string, sink *lager.ReconfigurableSink, metrics Metrics, handlers map[string]http.Handler) (ifrit.Process, error) {
 	expvar.Publish("numCPUS", expvar.Func(func() interface{} {
 		return metrics.NumCPU()
 	}))
@@ -33,7 +35,7 @@ func StartDebugServer(address string, sink *lager.ReconfigurableSink, metrics Me
 		return metrics.DepotDirs()
 	}))
 
-	server := http_server.New(address, handler(sink))
+	server := http_server.New(address, handler(sink, handlers))
 	p := ifrit.Invoke(server)
 	select {
 	case <-p.Ready():
@@ -43,9 +45,14 @@ func StartDebugServer(address string, sink *lager.ReconfigurableSink, metrics Me
 	return p, nil
 }
 
-func handler(sink *lager.ReconfigurableSink) http.Handler {
+func handler(sink *lager.ReconfigurableSink, handlers map[string]http.Handler) http.Handler {
 	pprofHandler := debugserver.Handler(sink)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h, ok := handlers[r.URL.Path]; ok {
+			h.ServeHTTP(w, r)
+			return
+		}
+
 		if strings.HasPrefix(r.URL.Path, "/debug/vars") {
 			http.DefaultServeMux.ServeHTTP(w, r)
 			return
@@ -2,6 +2,7 @@ package metrics_test
 
 import (
 	"expvar"
+	"io/ioutil"
 	"net/http"
 	"os"
 
@@ -31,7 +32,11 @@ var _ = Describe("Debug", func() {
 		fakeMetrics.DepotDirsReturns(3)
 
 		sink := lager.NewReconfigurableSink(lager.NewWriterSink(GinkgoWriter, lager.DEBUG), lager.DEBUG)
-		serverProc, err = metrics.StartDebugServer("127.0.0.1:5123", sink, fakeMetrics)
+		serverProc, err = metrics.StartDebugServer("127.0.0.1:5123", sink, fakeMetrics, map[string]http.Handler{
+			"/debug/potato": http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte("potato"))
+			}),
+		})
 		Expect(err).ToNot(HaveOccurred())
 	})
 
@@ -51,5 +56,16 @@ var _ = Describe("Debug", func() {
 		Expect(expvar.Get("depotDirs").String()).To(Equal("3"))
 		Expect(expvar.Get("numCPUS").String()).To(Equal("11"))
 		Expect(expvar.Get("numGoRoutines").String()).To(Equal("888"))
+
+		By("serving the extra handlers on their paths")
+		resp, err = http.Get("http://127.0.0.1:5123/debug/potato")
+		Expect(err).ToNot(HaveOccurred())
+
+		defer resp.Body.Close()
+		Expect(resp.StatusCode).To(Equal(http.StatusOK))
+
+		body, err := ioutil.ReadAll(resp.Body)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(body)).To(Equal("potato"))
 	})
 })
@@ -73,5 +73,12 @@ func (m *metrics) DepotDirs() int {
 		return -1
 	}
 
-	return len(entries)
+	dirs := 0
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs++
+		}
+	}
+
+	return dirs
 }
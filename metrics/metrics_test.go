@@ -58,6 +58,11 @@ var _ = Describe("Metrics", func() {
 		Expect(m.DepotDirs()).To(Equal(3))
 	})
 
+	It("does not count files in the depot as depot dirs", func() {
+		Expect(ioutil.WriteFile(filepath.Join(depotPath, ".health-check123"), []byte("ok"), 0600)).To(Succeed())
+		Expect(m.DepotDirs()).To(Equal(3))
+	})
+
 	Context("when the backing store path is empty", func() {
 		It("reports BackingStores as -1 without doing any funny business", func() {
 			m := metrics.NewMetrics(logger, "", depotPath)
@@ -0,0 +1,31 @@
+package metrics
+
+import (
+	"code.cloudfoundry.org/guardian/gardener"
+	dropsonde_metrics "github.com/cloudfoundry/dropsonde/metrics"
+)
+
+type Bytes string
+
+func (name Bytes) Send(value int64) {
+	dropsonde_metrics.SendValue(string(name), float64(value), "bytes")
+}
+
+const (
+	processMaxRSS    = Bytes("ProcessMaxRSS")
+	processUserCPU   = Duration("ProcessUserCPU")
+	processSystemCPU = Duration("ProcessSystemCPU")
+	processWallClock = Duration("ProcessWallClock")
+)
+
+// ProcessUsageEmitter sends the resources used by each container process to
+// metron when it finishes. The metrics are not tagged with the process, which
+// the process-exited event carries along with the same usage.
+type ProcessUsageEmitter struct{}
+
+func (ProcessUsageEmitter) EmitProcessUsage(usage gardener.ResourceUsage) {
+	processMaxRSS.Send(usage.MaxRSSBytes)
+	processUserCPU.Send(usage.UserCPU)
+	processSystemCPU.Send(usage.SystemCPU)
+	processWallClock.Send(usage.WallClock)
+}
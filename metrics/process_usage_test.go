@@ -0,0 +1,36 @@
+package metrics_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/metrics"
+	"github.com/cloudfoundry/dropsonde/metric_sender/fake"
+	dropsonde_metrics "github.com/cloudfoundry/dropsonde/metrics"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProcessUsageEmitter", func() {
+	var sender *fake.FakeMetricSender
+
+	BeforeEach(func() {
+		sender = fake.NewFakeMetricSender()
+		dropsonde_metrics.Initialize(sender, nil)
+	})
+
+	It("emits the resources used by the process", func() {
+		metrics.ProcessUsageEmitter{}.EmitProcessUsage(gardener.ResourceUsage{
+			MaxRSSBytes: 2048,
+			UserCPU:     time.Second,
+			SystemCPU:   2 * time.Second,
+			WallClock:   time.Minute,
+		})
+
+		Expect(sender.GetValue("ProcessMaxRSS")).To(Equal(fake.Metric{Value: 2048, Unit: "bytes"}))
+		Expect(sender.GetValue("ProcessUserCPU")).To(Equal(fake.Metric{Value: float64(time.Second), Unit: "nanos"}))
+		Expect(sender.GetValue("ProcessSystemCPU")).To(Equal(fake.Metric{Value: float64(2 * time.Second), Unit: "nanos"}))
+		Expect(sender.GetValue("ProcessWallClock")).To(Equal(fake.Metric{Value: float64(time.Minute), Unit: "nanos"}))
+	})
+})
@@ -3,6 +3,7 @@ package netplugin
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
 	"net"
@@ -217,6 +218,18 @@ func (p *externalBinaryNetworker) BulkNetOut(log lager.Logger, handle string, ru
 	return p.exec(log, "bulk-net-out", handle, inputs, nil)
 }
 
+func (p *externalBinaryNetworker) LimitBandwidth(log lager.Logger, handle string, limits garden.BandwidthLimits) error {
+	return errors.New("bandwidth limits are not supported by the external networker")
+}
+
+func (p *externalBinaryNetworker) BandwidthLimits(log lager.Logger, handle string) (garden.BandwidthLimits, error) {
+	return garden.BandwidthLimits{}, nil
+}
+
+func (p *externalBinaryNetworker) Reconfigure(log lager.Logger, handle string, pid int) error {
+	return errors.New("reconfiguring networks is not supported by the external networker")
+}
+
 func (p *externalBinaryNetworker) exec(log lager.Logger, action, handle string,
 	inputData interface{}, outputData interface{}) error {
 
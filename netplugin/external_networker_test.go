@@ -494,6 +494,35 @@ var _ = Describe("ExternalNetworker", func() {
 			Expect(logger).To(gbytes.Say("result.*some-stderr-bytes"))
 		})
 	})
+
+	Describe("LimitBandwidth", func() {
+		It("returns an error", func() {
+			err := plugin.LimitBandwidth(logger, "my-handle", garden.BandwidthLimits{RateInBytesPerSecond: 1024})
+			Expect(err).To(MatchError("bandwidth limits are not supported by the external networker"))
+		})
+
+		It("does not invoke the plugin", func() {
+			plugin.LimitBandwidth(logger, "my-handle", garden.BandwidthLimits{RateInBytesPerSecond: 1024})
+			Expect(fakeCommandRunner.ExecutedCommands()).To(BeEmpty())
+		})
+	})
+
+	Describe("Reconfigure", func() {
+		It("returns an error", func() {
+			Expect(plugin.Reconfigure(logger, "my-handle", 42)).To(MatchError("reconfiguring networks is not supported by the external networker"))
+		})
+
+		It("does not invoke the plugin", func() {
+			plugin.Reconfigure(logger, "my-handle", 42)
+			Expect(fakeCommandRunner.ExecutedCommands()).To(BeEmpty())
+		})
+	})
+
+	Describe("BandwidthLimits", func() {
+		It("returns empty limits", func() {
+			Expect(plugin.BandwidthLimits(logger, "my-handle")).To(Equal(garden.BandwidthLimits{}))
+		})
+	})
 })
 
 func createRule(netStart, netEnd string, portStart, portEnd int) garden.NetOutRule {
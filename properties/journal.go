@@ -0,0 +1,173 @@
+package properties
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+)
+
+const (
+	opSet     = "set"
+	opRemove  = "remove"
+	opDestroy = "destroy"
+)
+
+// journalEntry is a single change to the properties, written as one line of
+// JSON so that a torn write only ever loses the last change
+type journalEntry struct {
+	Op     string `json:"op"`
+	Handle string `json:"handle"`
+	Name   string `json:"name,omitempty"`
+	Value  string `json:"value,omitempty"`
+}
+
+func journalPath(snapshotPath string) string {
+	return snapshotPath + ".journal"
+}
+
+// journal appends entries in two steps, so that the propMutex is not held
+// while they are synced to disk: entries are queued in the order they are
+// applied, with the propMutex held, and then written out together by
+// whichever writer flushes first.
+type journal struct {
+	snapshotPath string
+	compactAfter int
+
+	queueMutex sync.Mutex
+	queue      [][]byte
+	queued     uint64
+
+	// fileMutex guards the file and everything below it
+	fileMutex sync.Mutex
+	file      *os.File
+	flushed   uint64
+	entries   int
+}
+
+func openJournal(snapshotPath string, compactAfter int) (*journal, error) {
+	file, err := os.OpenFile(journalPath(snapshotPath), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &journal{
+		snapshotPath: snapshotPath,
+		file:         file,
+		compactAfter: compactAfter,
+	}, nil
+}
+
+// enqueue queues the entry to be written by flush, and returns its sequence
+// number
+func (j *journal) enqueue(entry journalEntry) (uint64, error) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return 0, err
+	}
+
+	j.queueMutex.Lock()
+	defer j.queueMutex.Unlock()
+
+	j.queue = append(j.queue, append(line, '\n'))
+	j.queued++
+	return j.queued, nil
+}
+
+// flush writes every queued entry and syncs them to disk before returning,
+// unless the entry numbered seq has already been flushed by another writer.
+// It reports whether the journal should now be compacted into a snapshot.
+func (j *journal) flush(seq uint64) (bool, error) {
+	j.fileMutex.Lock()
+	defer j.fileMutex.Unlock()
+
+	if j.flushed >= seq {
+		return false, nil
+	}
+
+	j.queueMutex.Lock()
+	queue, last := j.queue, j.queued
+	j.queue = nil
+	j.queueMutex.Unlock()
+
+	// the entries are gone from the queue whether or not they are written, so
+	// an error is left to the caller to recover from with a snapshot
+	j.flushed = last
+
+	var lines []byte
+	for _, line := range queue {
+		lines = append(lines, line...)
+	}
+
+	if _, err := j.file.Write(lines); err != nil {
+		return false, err
+	}
+
+	if err := j.file.Sync(); err != nil {
+		return false, err
+	}
+
+	j.entries += len(queue)
+	return j.compactAfter > 0 && j.entries >= j.compactAfter, nil
+}
+
+// truncate empties the journal once its entries are part of a snapshot. It
+// must be called with the propMutex held, so that the snapshot contains every
+// queued entry and they can be dropped.
+func (j *journal) truncate() error {
+	j.fileMutex.Lock()
+	defer j.fileMutex.Unlock()
+
+	j.queueMutex.Lock()
+	j.queue = nil
+	j.flushed = j.queued
+	j.queueMutex.Unlock()
+
+	if err := j.file.Truncate(0); err != nil {
+		return err
+	}
+
+	if err := j.file.Sync(); err != nil {
+		return err
+	}
+
+	j.entries = 0
+	return nil
+}
+
+func (j *journal) close() error {
+	j.fileMutex.Lock()
+	defer j.fileMutex.Unlock()
+
+	return j.file.Close()
+}
+
+// replayJournal applies the entries journalled next to the snapshot. It stops
+// at the first entry which is incomplete or cannot be decoded, since that can
+// only be the result of a crash part way through a write.
+func (m *Manager) replayJournal(snapshotPath string) error {
+	file, err := os.Open(journalPath(snapshotPath))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil {
+			// a final line without a newline was torn, and EOF means we are done
+			return nil
+		}
+
+		var entry journalEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return nil
+		}
+
+		m.apply(entry)
+	}
+}
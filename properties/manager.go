@@ -6,45 +6,68 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
 )
 
 type Manager struct {
 	propMutex sync.RWMutex
 	prop      map[string]map[string]string
+	index     index
+
+	journal *journal
+	log     lager.Logger
 }
 
 func NewManager() *Manager {
 	return &Manager{
-		prop: make(map[string]map[string]string),
+		prop:  make(map[string]map[string]string),
+		index: make(index),
 	}
 }
 
 func (m *Manager) DestroyKeySpace(handle string) error {
 	m.propMutex.Lock()
-	defer m.propMutex.Unlock()
-
-	delete(m.prop, handle)
+	entry := journalEntry{Op: opDestroy, Handle: handle}
+	m.apply(entry)
+	record := m.enqueue(entry)
+	m.propMutex.Unlock()
 
+	record()
 	return nil
 }
 
 func (m *Manager) MarshalJSON() ([]byte, error) {
+	m.propMutex.RLock()
+	defer m.propMutex.RUnlock()
+
 	return json.Marshal(m.prop)
 }
 
 func (m *Manager) UnmarshalJSON(data []byte) error {
-	return json.Unmarshal(data, &(m.prop))
+	var prop map[string]map[string]string
+	if err := json.Unmarshal(data, &prop); err != nil {
+		return err
+	}
+
+	m.prop = make(map[string]map[string]string)
+	m.index = make(index)
+	for handle, props := range prop {
+		for name, value := range props {
+			m.apply(journalEntry{Op: opSet, Handle: handle, Name: name, Value: value})
+		}
+	}
+
+	return nil
 }
 
 func (m *Manager) Set(handle string, name string, value string) {
 	m.propMutex.Lock()
-	defer m.propMutex.Unlock()
-
-	if _, ok := m.prop[handle]; !ok {
-		m.prop[handle] = make(map[string]string)
-	}
+	entry := journalEntry{Op: opSet, Handle: handle, Name: name, Value: value}
+	m.apply(entry)
+	record := m.enqueue(entry)
+	m.propMutex.Unlock()
 
-	m.prop[handle][name] = value
+	record()
 }
 
 func (m *Manager) All(handle string) (garden.Properties, error) {
@@ -69,25 +92,58 @@ func (m *Manager) Get(handle string, name string) (string, bool) {
 
 func (m *Manager) Remove(handle string, name string) error {
 	m.propMutex.Lock()
-	defer m.propMutex.Unlock()
-
 	if _, exists := m.prop[handle][name]; !exists {
+		m.propMutex.Unlock()
 		return NoSuchPropertyError{
 			Message: fmt.Sprintf("cannot Remove %s:%s", handle, name),
 		}
 	}
 
-	delete(m.prop[handle], name)
+	entry := journalEntry{Op: opRemove, Handle: handle, Name: name}
+	m.apply(entry)
+	record := m.enqueue(entry)
+	m.propMutex.Unlock()
 
+	record()
 	return nil
 }
 
+// MatchesAll reports whether the container matches every query in props
 func (m *Manager) MatchesAll(handle string, props garden.Properties) bool {
+	matching, err := m.Filter([]string{handle}, props)
+	return err == nil && len(matching) == 1
+}
+
+// Filter returns the handles, in order, of the containers which match every
+// query in props. See ParseQueries for the syntax.
+func (m *Manager) Filter(handles []string, props garden.Properties) ([]string, error) {
+	queries := ParseQueries(props)
+
 	m.propMutex.RLock()
 	defer m.propMutex.RUnlock()
 
-	for key, val := range props {
-		if m.prop[handle][key] != val {
+	var included, excluded []map[string]struct{}
+	for _, query := range queries {
+		if query.Op == OpNot {
+			excluded = append(excluded, m.index.matching(query))
+		} else {
+			included = append(included, m.index.matching(query))
+		}
+	}
+
+	var matching []string
+	for _, handle := range handles {
+		if inAll(handle, included) && !inAny(handle, excluded) {
+			matching = append(matching, handle)
+		}
+	}
+
+	return matching, nil
+}
+
+func inAll(handle string, sets []map[string]struct{}) bool {
+	for _, set := range sets {
+		if _, ok := set[handle]; !ok {
 			return false
 		}
 	}
@@ -95,6 +151,95 @@ func (m *Manager) MatchesAll(handle string, props garden.Properties) bool {
 	return true
 }
 
+func inAny(handle string, sets []map[string]struct{}) bool {
+	for _, set := range sets {
+		if _, ok := set[handle]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Close stops journalling changes
+func (m *Manager) Close() error {
+	m.propMutex.Lock()
+	defer m.propMutex.Unlock()
+
+	if m.journal == nil {
+		return nil
+	}
+
+	err := m.journal.close()
+	m.journal = nil
+	return err
+}
+
+// enqueue queues a change to be journalled, and must be called with the
+// propMutex held so that changes are journalled in the order they are
+// applied. The returned function writes it out, and is called once the
+// propMutex is released so that readers do not wait for the journal to be
+// synced. If the change cannot be journalled a snapshot is written instead,
+// which also happens every time the journal fills up.
+func (m *Manager) enqueue(entry journalEntry) func() {
+	j := m.journal
+	if j == nil {
+		return func() {}
+	}
+
+	seq, err := j.enqueue(entry)
+	return func() {
+		compact := false
+		if err == nil {
+			compact, err = j.flush(seq)
+		}
+		if err != nil {
+			m.log.Error("failed-to-journal", err, lager.Data{"handle": entry.Handle, "op": entry.Op})
+		}
+
+		if err == nil && !compact {
+			return
+		}
+
+		m.propMutex.Lock()
+		defer m.propMutex.Unlock()
+
+		if m.journal != j {
+			return
+		}
+
+		if err := m.snapshot(j.snapshotPath); err != nil {
+			m.log.Error("failed-to-compact", err, lager.Data{"path": j.snapshotPath})
+		}
+	}
+}
+
+// apply makes a change to the properties and the index, and must be called
+// with the propMutex held
+func (m *Manager) apply(entry journalEntry) {
+	switch entry.Op {
+	case opSet:
+		if _, ok := m.prop[entry.Handle]; !ok {
+			m.prop[entry.Handle] = make(map[string]string)
+		}
+		if old, ok := m.prop[entry.Handle][entry.Name]; ok {
+			m.index.remove(entry.Handle, entry.Name, old)
+		}
+		m.prop[entry.Handle][entry.Name] = entry.Value
+		m.index.add(entry.Handle, entry.Name, entry.Value)
+	case opRemove:
+		if old, ok := m.prop[entry.Handle][entry.Name]; ok {
+			m.index.remove(entry.Handle, entry.Name, old)
+		}
+		delete(m.prop[entry.Handle], entry.Name)
+	case opDestroy:
+		for name, old := range m.prop[entry.Handle] {
+			m.index.remove(entry.Handle, name, old)
+		}
+		delete(m.prop, entry.Handle)
+	}
+}
+
 type NoSuchPropertyError struct {
 	Message string
 }
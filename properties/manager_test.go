@@ -150,6 +150,108 @@ var _ = Describe("Properties", func() {
 		})
 	})
 
+	Describe("Filter", func() {
+		var handles []string
+
+		BeforeEach(func() {
+			handles = []string{"fred", "wilma", "barney"}
+
+			propertyManager.Set("fred", "family", "flintstone")
+			propertyManager.Set("fred", "job", "crane-operator")
+			propertyManager.Set("wilma", "family", "flintstone")
+			propertyManager.Set("barney", "family", "rubble")
+			propertyManager.Set("barney", "job", "crane-oiler")
+		})
+
+		filter := func(props garden.Properties) []string {
+			matching, err := propertyManager.Filter(handles, props)
+			Expect(err).NotTo(HaveOccurred())
+			return matching
+		}
+
+		It("returns every handle when there are no queries", func() {
+			Expect(filter(garden.Properties{})).To(Equal(handles))
+		})
+
+		It("matches exact values", func() {
+			Expect(filter(garden.Properties{"family": "flintstone"})).To(Equal([]string{"fred", "wilma"}))
+		})
+
+		It("matches prefixes", func() {
+			Expect(filter(garden.Properties{"job[prefix]": "crane-o"})).To(Equal([]string{"fred", "barney"}))
+		})
+
+		It("matches properties which exist", func() {
+			Expect(filter(garden.Properties{"job[exists]": ""})).To(Equal([]string{"fred", "barney"}))
+		})
+
+		It("matches values which are not equal, including missing ones", func() {
+			Expect(filter(garden.Properties{"job[not]": "crane-oiler"})).To(Equal([]string{"fred", "wilma"}))
+		})
+
+		It("matches any of a set of values", func() {
+			Expect(filter(garden.Properties{"job[in]": "crane-oiler,crane-operator"})).To(Equal([]string{"fred", "barney"}))
+		})
+
+		It("matches nothing when the property is not set on any container", func() {
+			Expect(filter(garden.Properties{"pet": "dino"})).To(BeEmpty())
+		})
+
+		It("matches all of the queries", func() {
+			Expect(filter(garden.Properties{"family": "flintstone", "job[exists]": ""})).To(Equal([]string{"fred"}))
+		})
+
+		It("matches nothing when only some of the queries match", func() {
+			Expect(filter(garden.Properties{"family": "flintstone", "job": "crane-operator", "pet": "dino"})).To(BeEmpty())
+		})
+
+		It("matches exact values of names which end in an operator when escaped", func() {
+			propertyManager.Set("wilma", "ports[in]", "8080")
+			Expect(filter(garden.Properties{"ports[in][eq]": "8080"})).To(Equal([]string{"wilma"}))
+		})
+
+		It("only returns handles it was asked about", func() {
+			matching, err := propertyManager.Filter([]string{"wilma"}, garden.Properties{"family": "flintstone"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matching).To(Equal([]string{"wilma"}))
+		})
+
+		It("stops matching old values once they change", func() {
+			propertyManager.Set("fred", "family", "rubble")
+			Expect(filter(garden.Properties{"family": "flintstone"})).To(Equal([]string{"wilma"}))
+		})
+
+		It("stops matching removed properties", func() {
+			Expect(propertyManager.Remove("fred", "job")).To(Succeed())
+			Expect(filter(garden.Properties{"job[exists]": ""})).To(Equal([]string{"barney"}))
+		})
+
+		It("stops matching destroyed key spaces", func() {
+			Expect(propertyManager.DestroyKeySpace("wilma")).To(Succeed())
+			Expect(filter(garden.Properties{"family": "flintstone"})).To(Equal([]string{"fred"}))
+		})
+
+		It("matches properties restored from JSON", func() {
+			data, err := json.Marshal(propertyManager)
+			Expect(err).NotTo(HaveOccurred())
+
+			var restored properties.Manager
+			Expect(json.Unmarshal(data, &restored)).To(Succeed())
+
+			matching, err := restored.Filter(handles, garden.Properties{"job[prefix]": "crane"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matching).To(Equal([]string{"fred", "barney"}))
+		})
+
+		Context("when the bracketed suffix is not an operator", func() {
+			It("treats it as part of the name", func() {
+				propertyManager.Set("wilma", "ports[tcp]", "8080")
+				Expect(filter(garden.Properties{"ports[tcp]": "8080"})).To(Equal([]string{"wilma"}))
+				Expect(filter(garden.Properties{"job[like]": "crane"})).To(BeEmpty())
+			})
+		})
+	})
+
 	Describe("MarshalJSON", func() {
 		It("can be saved and restored from JSON", func() {
 			mgr := properties.NewManager()
@@ -0,0 +1,123 @@
+package properties
+
+import (
+	"strings"
+
+	"code.cloudfoundry.org/garden"
+)
+
+// Queries are written as garden.Properties. A plain name matches containers
+// whose property has exactly that value; a name followed by an operator in
+// square brackets, e.g. "network[prefix]", matches as follows:
+//
+//	name[not]     the property is missing or has a different value
+//	name[prefix]  the property starts with the value
+//	name[exists]  the property is set to anything; the value is ignored
+//	name[in]      the property is one of a comma-separated list of values
+//	name[eq]      the property has exactly the value, as for a plain name
+//
+// Any other bracketed suffix is part of the name, so "ports[tcp]" matches the
+// property "ports[tcp]" exactly. The last operator is only needed for names
+// which end in an operator, e.g. "ports[in][eq]" matches "ports[in]" exactly.
+const (
+	OpEqual  = "eq"
+	OpNot    = "not"
+	OpPrefix = "prefix"
+	OpExists = "exists"
+	OpIn     = "in"
+)
+
+type Query struct {
+	Name   string
+	Op     string
+	Values []string
+}
+
+func ParseQueries(props garden.Properties) []Query {
+	var queries []Query
+	for key, value := range props {
+		queries = append(queries, parseQuery(key, value))
+	}
+
+	return queries
+}
+
+func parseQuery(key, value string) Query {
+	open := strings.LastIndex(key, "[")
+	if open <= 0 || !strings.HasSuffix(key, "]") {
+		return Query{Name: key, Op: OpEqual, Values: []string{value}}
+	}
+
+	query := Query{Name: key[:open], Op: key[open+1 : len(key)-1]}
+	switch query.Op {
+	case OpEqual, OpNot, OpPrefix:
+		query.Values = []string{value}
+	case OpExists:
+	case OpIn:
+		query.Values = strings.Split(value, ",")
+	default:
+		// the brackets are part of the name, e.g. "ports[tcp]"
+		return Query{Name: key, Op: OpEqual, Values: []string{value}}
+	}
+
+	return query
+}
+
+// index maps each property name and value to the handles which have it, so
+// that queries do not need to look at every container
+type index map[string]map[string]map[string]struct{}
+
+func (i index) add(handle, name, value string) {
+	if _, ok := i[name]; !ok {
+		i[name] = make(map[string]map[string]struct{})
+	}
+	if _, ok := i[name][value]; !ok {
+		i[name][value] = make(map[string]struct{})
+	}
+
+	i[name][value][handle] = struct{}{}
+}
+
+func (i index) remove(handle, name, value string) {
+	delete(i[name][value], handle)
+
+	if len(i[name][value]) == 0 {
+		delete(i[name], value)
+	}
+	if len(i[name]) == 0 {
+		delete(i, name)
+	}
+}
+
+// matching returns the handles which the query selects, or the handles which
+// it rules out for OpNot
+func (i index) matching(query Query) map[string]struct{} {
+	handles := make(map[string]struct{})
+	for value, withValue := range i[query.Name] {
+		if !query.selects(value) {
+			continue
+		}
+
+		for handle := range withValue {
+			handles[handle] = struct{}{}
+		}
+	}
+
+	return handles
+}
+
+func (q Query) selects(value string) bool {
+	switch q.Op {
+	case OpPrefix:
+		return strings.HasPrefix(value, q.Values[0])
+	case OpExists:
+		return true
+	default:
+		for _, v := range q.Values {
+			if v == value {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -2,28 +2,171 @@ package properties
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/lager"
 )
 
+// Load reads the snapshot at path and replays any changes journalled since
+// it was written. If the snapshot cannot be decoded, e.g. because it was
+// truncated, every container it holds in full is kept and the journal is
+// replayed on top of them.
 func Load(path string) (*Manager, error) {
-	f, err := os.Open(path)
+	mgr, _, err := load(path)
+	return mgr, err
+}
+
+// Open loads the properties at path and journals every later change next to
+// it, so that changes survive a crash. The journal is compacted into a new
+// snapshot every compactAfter changes, or never if compactAfter is 0.
+func Open(log lager.Logger, path string, compactAfter int) (*Manager, error) {
+	mgr, snapshotErr, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr.log = log.Session("properties")
+	if snapshotErr != nil {
+		mgr.log.Error("failed-to-decode-snapshot", snapshotErr, lager.Data{"path": path, "moved-to": corruptPath(path)})
+
+		// keep what is left of it, since the fresh snapshot replaces it below
+		if err := os.Rename(path, corruptPath(path)); err != nil {
+			return nil, err
+		}
+	}
+
+	mgr.journal, err = openJournal(path, compactAfter)
 	if err != nil {
-		return NewManager(), nil
+		return nil, err
 	}
 
-	var mgr Manager
-	if err := json.NewDecoder(f).Decode(&mgr); err != nil {
+	// start from a fresh snapshot, so that a torn entry left at the end of the
+	// journal by a crash cannot corrupt the entries appended after it
+	if err := Save(path, mgr); err != nil {
+		mgr.Close()
 		return nil, err
 	}
 
-	return &mgr, nil
+	return mgr, nil
 }
 
+func corruptPath(path string) string {
+	return path + ".corrupt"
+}
+
+// load returns the error decoding the snapshot separately, since the journal
+// can still be replayed on top of what was decoded
+func load(path string) (*Manager, error, error) {
+	mgr := NewManager()
+
+	var snapshotErr error
+	f, err := os.Open(path)
+	if err == nil {
+		defer f.Close()
+		snapshotErr = mgr.decodeSnapshot(f)
+	}
+
+	if err := mgr.replayJournal(path); err != nil {
+		return nil, nil, err
+	}
+
+	return mgr, snapshotErr, nil
+}
+
+// decodeSnapshot decodes the snapshot one container at a time, so that a
+// snapshot which was cut short, e.g. by a crash while an older version saved
+// it in place, still yields every container which it holds in full
+func (m *Manager) decodeSnapshot(r io.Reader) error {
+	decoder := json.NewDecoder(r)
+
+	token, err := decoder.Token()
+	if err != nil || token == nil {
+		return err
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("properties: expected the snapshot to be an object, got %v", token)
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+
+		var props map[string]string
+		if err := decoder.Decode(&props); err != nil {
+			return err
+		}
+
+		handle := token.(string)
+		for name, value := range props {
+			m.apply(journalEntry{Op: opSet, Handle: handle, Name: name, Value: value})
+		}
+	}
+
+	_, err = decoder.Token()
+	return err
+}
+
+// Save atomically replaces the snapshot at path. If the manager journals to
+// the same path the journal is emptied, since the snapshot now contains it.
 func Save(path string, mgr *Manager) error {
-	f, err := os.Create(path)
+	mgr.propMutex.Lock()
+	defer mgr.propMutex.Unlock()
+
+	return mgr.snapshot(path)
+}
+
+// snapshot must be called with the propMutex held
+func (m *Manager) snapshot(path string) error {
+	if err := writeAtomically(path, m.prop); err != nil {
+		return err
+	}
+
+	if m.journal != nil && m.journal.snapshotPath == path {
+		return m.journal.truncate()
+	}
+
+	return nil
+}
+
+func writeAtomically(path string, value interface{}) error {
+	dir := filepath.Dir(path)
+
+	tmp, err := ioutil.TempFile(dir, filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := json.NewEncoder(tmp).Encode(value); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	d, err := os.Open(dir)
 	if err != nil {
 		return err
 	}
+	defer d.Close()
 
-	return json.NewEncoder(f).Encode(mgr)
+	return d.Sync()
 }
@@ -4,8 +4,12 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"strconv"
+	"sync"
 
+	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/guardian/properties"
+	"code.cloudfoundry.org/lager/lagertest"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -45,15 +49,209 @@ var _ = Describe("SaveLoad", func() {
 		Expect(val).To(Equal("baz"))
 	})
 
-	It("returns an error when decoding fails", func() {
+	It("keeps every container held in full by a truncated file, and replays the journal on top", func() {
+		Expect(ioutil.WriteFile(path.Join(propPath, "props.json"), []byte(`{"complete":{"name":"value"},"torn":{"name":"val`), 0655)).To(Succeed())
+		Expect(ioutil.WriteFile(path.Join(propPath, "props.json.journal"), []byte(`{"op":"set","handle":"complete","name":"other","value":"journalled"}`+"\n"), 0600)).To(Succeed())
+
+		mgr, err := properties.Load(path.Join(propPath, "props.json"))
+		Expect(err).NotTo(HaveOccurred())
+
+		props, err := mgr.All("complete")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(props).To(Equal(garden.Properties{"name": "value", "other": "journalled"}))
+
+		props, err = mgr.All("torn")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(props).To(BeEmpty())
+	})
+
+	It("replays the journal on its own when the file cannot be decoded", func() {
 		Expect(ioutil.WriteFile(path.Join(propPath, "props.json"), []byte("{teest: banana"), 0655)).To(Succeed())
+		Expect(ioutil.WriteFile(path.Join(propPath, "props.json.journal"), []byte(`{"op":"set","handle":"handle","name":"name","value":"value"}`+"\n"), 0600)).To(Succeed())
+
+		mgr, err := properties.Load(path.Join(propPath, "props.json"))
+		Expect(err).NotTo(HaveOccurred())
 
-		_, err := properties.Load(path.Join(propPath, "props.json"))
-		Expect(err).To(HaveOccurred())
+		props, err := mgr.All("handle")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(props).To(Equal(garden.Properties{"name": "value"}))
 	})
 
 	It("returns an error when cannot write to the file", func() {
 		mgr := properties.NewManager()
 		Expect(properties.Save("/path/to/non/existing.json", mgr)).To(HaveOccurred())
 	})
+
+	It("replaces the file atomically, leaving no temporary files behind", func() {
+		mgr := properties.NewManager()
+		mgr.Set("foo", "bar", "baz")
+		Expect(properties.Save(path.Join(propPath, "props.json"), mgr)).To(Succeed())
+
+		files, err := ioutil.ReadDir(propPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+		Expect(files[0].Name()).To(Equal("props.json"))
+	})
+
+	Describe("Open", func() {
+		var (
+			propsFile string
+			mgr       *properties.Manager
+		)
+
+		BeforeEach(func() {
+			propsFile = path.Join(propPath, "props.json")
+
+			var err error
+			mgr, err = properties.Open(lagertest.NewTestLogger("test"), propsFile, 0)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(mgr.Close()).To(Succeed())
+		})
+
+		It("persists changes without being saved", func() {
+			mgr.Set("handle", "gone", "soon")
+			mgr.Set("handle", "kept", "value")
+			Expect(mgr.Remove("handle", "gone")).To(Succeed())
+			mgr.Set("destroyed", "name", "value")
+			Expect(mgr.DestroyKeySpace("destroyed")).To(Succeed())
+
+			recovered, err := properties.Load(propsFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			props, err := recovered.All("handle")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(props).To(Equal(garden.Properties{"kept": "value"}))
+
+			props, err = recovered.All("destroyed")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(props).To(BeEmpty())
+		})
+
+		It("journals concurrent changes in the order they are applied", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 20; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer GinkgoRecover()
+					defer wg.Done()
+
+					mgr.Set("handle", "shared", strconv.Itoa(i))
+					mgr.Set("handle", strconv.Itoa(i), "value")
+				}(i)
+			}
+			wg.Wait()
+
+			recovered, err := properties.Load(propsFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			expected, err := mgr.All("handle")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recovered.All("handle")).To(Equal(expected))
+		})
+
+		It("replays what it can when the journal was torn by a crash", func() {
+			mgr.Set("handle", "complete", "value")
+
+			journal, err := os.OpenFile(propsFile+".journal", os.O_WRONLY|os.O_APPEND, 0)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = journal.Write([]byte(`{"op":"set","handle":"handle","na`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(journal.Close()).To(Succeed())
+
+			recovered, err := properties.Load(propsFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			props, err := recovered.All("handle")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(props).To(Equal(garden.Properties{"complete": "value"}))
+		})
+
+		It("starts a fresh journal, so later changes survive a torn entry", func() {
+			Expect(mgr.Close()).To(Succeed())
+			Expect(ioutil.WriteFile(propsFile+".journal", []byte(`{"op":"set","han`), 0600)).To(Succeed())
+
+			var err error
+			mgr, err = properties.Open(lagertest.NewTestLogger("test"), propsFile, 0)
+			Expect(err).NotTo(HaveOccurred())
+			mgr.Set("handle", "after", "crash")
+
+			recovered, err := properties.Load(propsFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			val, ok := recovered.Get("handle", "after")
+			Expect(ok).To(BeTrue())
+			Expect(val).To(Equal("crash"))
+		})
+
+		Context("when the file cannot be decoded", func() {
+			BeforeEach(func() {
+				Expect(mgr.Close()).To(Succeed())
+				Expect(ioutil.WriteFile(propsFile, []byte(`{"complete":{"name":"value"},"torn":{"na`), 0600)).To(Succeed())
+
+				var err error
+				mgr, err = properties.Open(lagertest.NewTestLogger("test"), propsFile, 0)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("moves it aside before writing a fresh one", func() {
+				contents, err := ioutil.ReadFile(propsFile + ".corrupt")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(contents)).To(Equal(`{"complete":{"name":"value"},"torn":{"na`))
+
+				recovered, err := properties.Load(propsFile)
+				Expect(err).NotTo(HaveOccurred())
+				val, ok := recovered.Get("complete", "name")
+				Expect(ok).To(BeTrue())
+				Expect(val).To(Equal("value"))
+			})
+		})
+
+		It("empties the journal when saved", func() {
+			mgr.Set("handle", "name", "value")
+			Expect(properties.Save(propsFile, mgr)).To(Succeed())
+
+			Expect(propsFile + ".journal").To(BeAnExistingFile())
+			contents, err := ioutil.ReadFile(propsFile + ".journal")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(contents).To(BeEmpty())
+
+			recovered, err := properties.Load(propsFile)
+			Expect(err).NotTo(HaveOccurred())
+			_, ok := recovered.Get("handle", "name")
+			Expect(ok).To(BeTrue())
+		})
+
+		Context("when the journal fills up", func() {
+			BeforeEach(func() {
+				Expect(mgr.Close()).To(Succeed())
+
+				var err error
+				mgr, err = properties.Open(lagertest.NewTestLogger("test"), propsFile, 2)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("compacts it into the file", func() {
+				mgr.Set("handle", "first", "1")
+				contents, err := ioutil.ReadFile(propsFile + ".journal")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(contents).NotTo(BeEmpty())
+
+				mgr.Set("handle", "second", "2")
+				contents, err = ioutil.ReadFile(propsFile + ".journal")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(contents).To(BeEmpty())
+
+				Expect(os.Remove(propsFile + ".journal")).To(Succeed())
+				recovered, err := properties.Load(propsFile)
+				Expect(err).NotTo(HaveOccurred())
+
+				props, err := recovered.All("handle")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(props).To(Equal(garden.Properties{"first": "1", "second": "2"}))
+			})
+		})
+	})
 })
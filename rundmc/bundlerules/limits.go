@@ -1,6 +1,7 @@
 package bundlerules
 
 import (
+	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/guardian/gardener"
 	"code.cloudfoundry.org/guardian/rundmc/goci"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -14,10 +15,25 @@ type Limits struct {
 }
 
 func (l Limits) Apply(bndl goci.Bndl, spec gardener.DesiredContainerSpec) goci.Bndl {
-	limit := uint64(spec.Limits.Memory.LimitInBytes)
-	bndl = bndl.WithMemoryLimit(specs.LinuxMemory{Limit: &limit, Swap: &limit})
+	bndl = bndl.WithMemoryLimit(l.Memory(spec.Limits.Memory))
+	bndl = bndl.WithCPUShares(l.CPU(spec.Limits.CPU))
 
-	shares := uint64(spec.Limits.CPU.LimitInShares)
+	pids := int64(spec.Limits.Pid.Max)
+	return bndl.WithPidLimit(specs.LinuxPids{Limit: pids})
+}
+
+// Memory returns the memory cgroup settings for the given limits. Swap is
+// limited to the same value so that containers cannot exceed their limit by swapping.
+// On cgroup2 runc converts this combined limit into a swap.max of zero.
+func (l Limits) Memory(limits garden.MemoryLimits) specs.LinuxMemory {
+	limit := uint64(limits.LimitInBytes)
+	return specs.LinuxMemory{Limit: &limit, Swap: &limit}
+}
+
+// CPU returns the cpu cgroup settings for the given limits. When a quota per
+// share is configured the container is also given a hard CFS quota.
+func (l Limits) CPU(limits garden.CPULimits) specs.LinuxCPU {
+	shares := uint64(limits.LimitInShares)
 	cpuSpec := specs.LinuxCPU{Shares: &shares}
 	if l.CpuQuotaPerShare > 0 && shares > 0 {
 		cpuSpec.Period = &CpuPeriod
@@ -28,8 +44,6 @@ func (l Limits) Apply(bndl goci.Bndl, spec gardener.DesiredContainerSpec) goci.B
 		}
 		cpuSpec.Quota = &quota
 	}
-	bndl = bndl.WithCPUShares(cpuSpec)
 
-	pids := int64(spec.Limits.Pid.Max)
-	return bndl.WithPidLimit(specs.LinuxPids{Limit: pids})
+	return cpuSpec
 }
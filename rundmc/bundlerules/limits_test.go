@@ -124,4 +124,24 @@ var _ = Describe("LimitsRule", func() {
 		Expect(*(newBndl.Resources().Memory.Limit)).To(BeNumerically("==", 4096))
 		Expect(newBndl.Resources().Devices).To(Equal(bndl.Resources().Devices))
 	})
+
+	Describe("CPU", func() {
+		It("calculates the same shares and quota as the bundle rule", func() {
+			limits := bundlerules.Limits{CpuQuotaPerShare: 10}
+			cpu := limits.CPU(garden.CPULimits{LimitInShares: 512})
+
+			Expect(*cpu.Shares).To(BeNumerically("==", 512))
+			Expect(*cpu.Period).To(BeNumerically("==", 100000))
+			Expect(*cpu.Quota).To(BeNumerically("==", 5120))
+		})
+	})
+
+	Describe("Memory", func() {
+		It("limits memory and swap to the same value", func() {
+			memory := bundlerules.Limits{}.Memory(garden.MemoryLimits{LimitInBytes: 2048})
+
+			Expect(*memory.Limit).To(BeNumerically("==", 2048))
+			Expect(*memory.Swap).To(BeNumerically("==", 2048))
+		})
+	})
 })
@@ -1,8 +1,11 @@
 package rundmc
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
+	"time"
 
 	specs "github.com/opencontainers/runtime-spec/specs-go"
 
@@ -12,6 +15,7 @@ import (
 	"code.cloudfoundry.org/guardian/rundmc/goci"
 	"code.cloudfoundry.org/guardian/rundmc/runrunc"
 	"code.cloudfoundry.org/lager"
+	"github.com/pivotal-golang/clock"
 )
 
 //go:generate counterfeiter . Depot
@@ -22,6 +26,9 @@ import (
 //go:generate counterfeiter . BundleLoader
 //go:generate counterfeiter . Stopper
 //go:generate counterfeiter . StateStore
+//go:generate counterfeiter . ResourceLimits
+//go:generate counterfeiter . Retrier
+//go:generate counterfeiter . ProcessLister
 
 type Depot interface {
 	Create(log lager.Logger, handle string, bundle depot.BundleSaver) error
@@ -40,13 +47,18 @@ type BundleLoader interface {
 
 type OCIRuntime interface {
 	Create(log lager.Logger, bundlePath, id string, io garden.ProcessIO) error
-	Exec(log lager.Logger, id, bundlePath string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error)
-	Attach(log lager.Logger, id, bundlePath, processId string, io garden.ProcessIO) (garden.Process, error)
-	Kill(log lager.Logger, bundlePath string) error
-	Delete(log lager.Logger, bundlePath string) error
+	Exec(log lager.Logger, bundlePath, id string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error)
+	Attach(log lager.Logger, bundlePath, id, processId string, replay *gardener.OutputOffsets, io garden.ProcessIO) (garden.Process, error)
+	Kill(log lager.Logger, handle string) error
+	Delete(log lager.Logger, handle string) error
 	State(log lager.Logger, id string) (runrunc.State, error)
 	Stats(log lager.Logger, id string) (gardener.ActualContainerMetrics, error)
 	WatchEvents(log lager.Logger, id string, eventsNotifier runrunc.EventsNotifier) error
+	UpdateResources(log lager.Logger, id string, resources specs.LinuxResources) error
+	Pause(log lager.Logger, id string) error
+	Resume(log lager.Logger, id string) error
+	Checkpoint(log lager.Logger, id, imagePath string) error
+	Restore(log lager.Logger, bundlePath, id, imagePath string) error
 }
 
 type NstarRunner interface {
@@ -61,35 +73,74 @@ type Stopper interface {
 type EventStore interface {
 	OnEvent(id string, event string) error
 	Events(id string) []string
+	Query(id string, query EventQuery) EventHistory
 }
 
 type StateStore interface {
 	StoreStopped(handle string)
 	IsStopped(handle string) bool
+	StorePaused(handle string)
+	StoreResumed(handle string)
+	IsPaused(handle string) bool
 }
 
+// ProcessLister lists the processes which have been run in a container, from
+// the processes directory of its bundle
+type ProcessLister interface {
+	List(processesPath string) ([]gardener.ProcessInfo, error)
+}
+
+type Retrier interface {
+	Run(work func() error) error
+}
+
+// NewRetrier returns a retrier for a single caller, since retriers with
+// jittered backoff cannot be shared between goroutines
+type NewRetrier func() Retrier
+
+type ResourceLimits interface {
+	Memory(limits garden.MemoryLimits) specs.LinuxMemory
+	CPU(limits garden.CPULimits) specs.LinuxCPU
+}
+
+// CheckpointDir is the directory, within a container's bundle, which holds the
+// CRIU image of its most recent checkpoint
+const CheckpointDir = "checkpoint"
+
+// ErrContainerPaused is returned when an operation which needs the container's
+// processes to be running is attempted on a paused container
+var ErrContainerPaused = errors.New("container is paused")
+
 // Containerizer knows how to manage a depot of container bundles
 type Containerizer struct {
-	depot   Depot
-	bundler BundleGenerator
-	loader  BundleLoader
-	runtime OCIRuntime
-	stopper Stopper
-	nstar   NstarRunner
-	events  EventStore
-	states  StateStore
+	depot      Depot
+	bundler    BundleGenerator
+	loader     BundleLoader
+	runtime    OCIRuntime
+	stopper    Stopper
+	nstar      NstarRunner
+	events     EventStore
+	states     StateStore
+	limits     ResourceLimits
+	newRetrier NewRetrier
+	clock      clock.Clock
+	processes  ProcessLister
 }
 
-func New(depot Depot, bundler BundleGenerator, runtime OCIRuntime, loader BundleLoader, nstarRunner NstarRunner, stopper Stopper, events EventStore, states StateStore) *Containerizer {
+func New(depot Depot, bundler BundleGenerator, runtime OCIRuntime, loader BundleLoader, nstarRunner NstarRunner, stopper Stopper, events EventStore, states StateStore, limits ResourceLimits, newWatchRetrier NewRetrier, clock clock.Clock, processes ProcessLister) *Containerizer {
 	return &Containerizer{
-		depot:   depot,
-		bundler: bundler,
-		runtime: runtime,
-		loader:  loader,
-		nstar:   nstarRunner,
-		stopper: stopper,
-		events:  events,
-		states:  states,
+		depot:      depot,
+		bundler:    bundler,
+		runtime:    runtime,
+		loader:     loader,
+		nstar:      nstarRunner,
+		stopper:    stopper,
+		events:     events,
+		states:     states,
+		limits:     limits,
+		newRetrier: newWatchRetrier,
+		clock:      clock,
+		processes:  processes,
 	}
 }
 
@@ -111,18 +162,83 @@ func (c *Containerizer) Create(log lager.Logger, spec gardener.DesiredContainerS
 		return err
 	}
 
+	if selector, ok := c.runtime.(RuntimeSelector); ok {
+		if err := selector.Select(log, path, spec.Runtime); err != nil {
+			log.Error("select-runtime-failed", err)
+			return err
+		}
+	} else if spec.Runtime != "" {
+		return fmt.Errorf("runtime selection is not supported: %s", spec.Runtime)
+	}
+
 	if err = c.runtime.Create(log, path, spec.Handle, garden.ProcessIO{}); err != nil {
 		log.Error("runtime-create-failed", err)
 		return err
 	}
 
+	c.watchEvents(log, spec.Handle)
+	return nil
+}
+
+// WatchEvents starts watching the container for events (e.g. OOMs) in the
+// background, e.g. after the container has been restored on startup
+func (c *Containerizer) WatchEvents(log lager.Logger, handle string) {
+	c.watchEvents(log.Session("watch-events", lager.Data{"handle": handle}), handle)
+}
+
+// healthyWatchDuration is how long a watcher must run before it dies for the
+// death not to count towards giving up, so that containers which live for a
+// long time are not given up on after a few deaths spread over their lives
+const healthyWatchDuration = time.Minute
+
+// watchEvents restarts the watcher with backoff for as long as the container
+// is running, since the watcher process may die underneath it
+func (c *Containerizer) watchEvents(log lager.Logger, handle string) {
+	retrier := c.newRetrier()
 	go func() {
-		if err := c.runtime.WatchEvents(log, spec.Handle, c.events); err != nil {
-			log.Error("watch-failed", err)
+		for {
+			healthy := false
+			err := retrier.Run(func() error {
+				started := c.clock.Now()
+				err := c.runtime.WatchEvents(log, handle, c.events)
+
+				state, stateErr := c.runtime.State(log, handle)
+				if stateErr != nil {
+					log.Info("container-gone-stopped-watching", lager.Data{"error": stateErr.Error()})
+					return nil
+				}
+
+				if state.Status == runrunc.StoppedStatus {
+					log.Info("container-stopped-stopped-watching")
+					return nil
+				}
+
+				if err == nil {
+					err = errors.New("watcher exited")
+				}
+
+				log.Error("watch-failed", err)
+
+				if c.clock.Since(started) >= healthyWatchDuration {
+					healthy = true
+					return nil
+				}
+
+				return err
+			})
+
+			if err != nil {
+				log.Error("watch-gave-up", err)
+				return
+			}
+
+			if !healthy {
+				return
+			}
+
+			retrier = c.newRetrier()
 		}
 	}()
-
-	return nil
 }
 
 // Run runs a process inside a running container
@@ -132,6 +248,10 @@ func (c *Containerizer) Run(log lager.Logger, handle string, spec garden.Process
 	log.Info("started")
 	defer log.Info("finished")
 
+	if c.states.IsPaused(handle) {
+		return nil, ErrContainerPaused
+	}
+
 	path, err := c.depot.Lookup(log, handle)
 	if err != nil {
 		log.Error("lookup-failed", err)
@@ -142,8 +262,16 @@ func (c *Containerizer) Run(log lager.Logger, handle string, spec garden.Process
 }
 
 func (c *Containerizer) Attach(log lager.Logger, handle string, processID string, io garden.ProcessIO) (garden.Process, error) {
-	log = log.Session("attach", lager.Data{"handle": handle, "process-id": processID})
+	return c.attach(log.Session("attach", lager.Data{"handle": handle, "process-id": processID}), handle, processID, nil, io)
+}
+
+// AttachReplaying attaches to a process after replaying its logged output
+// from the given offsets
+func (c *Containerizer) AttachReplaying(log lager.Logger, handle string, processID string, from gardener.OutputOffsets, io garden.ProcessIO) (garden.Process, error) {
+	return c.attach(log.Session("attach-replaying", lager.Data{"handle": handle, "process-id": processID, "from": from}), handle, processID, &from, io)
+}
 
+func (c *Containerizer) attach(log lager.Logger, handle string, processID string, replay *gardener.OutputOffsets, io garden.ProcessIO) (garden.Process, error) {
 	log.Info("started")
 	defer log.Info("finished")
 
@@ -153,7 +281,7 @@ func (c *Containerizer) Attach(log lager.Logger, handle string, processID string
 		return nil, err
 	}
 
-	return c.runtime.Attach(log, path, handle, processID, io)
+	return c.runtime.Attach(log, path, handle, processID, replay, io)
 }
 
 // StreamIn streams files in to the container
@@ -163,6 +291,10 @@ func (c *Containerizer) StreamIn(log lager.Logger, handle string, spec garden.St
 	log.Info("started")
 	defer log.Info("finished")
 
+	if c.states.IsPaused(handle) {
+		return ErrContainerPaused
+	}
+
 	state, err := c.runtime.State(log, handle)
 	if err != nil {
 		log.Error("check-pid-failed", err)
@@ -212,15 +344,105 @@ func (c *Containerizer) Stop(log lager.Logger, handle string, kill bool) error {
 		return fmt.Errorf("stop: pid not found for container: %s", err)
 	}
 
+	// frozen processes only act on signals once they are thawed
+	paused := c.states.IsPaused(handle)
+	if paused {
+		if err := c.runtime.Resume(log, handle); err != nil {
+			log.Error("runtime-resume-failed", err)
+			return fmt.Errorf("stop: %s", err)
+		}
+	}
+
 	if err = c.stopper.StopAll(log, handle, []int{state.Pid}, kill); err != nil {
 		log.Error("stop-all-failed", err, lager.Data{"pid": state.Pid})
 		return fmt.Errorf("stop: %s", err)
 	}
 
+	if paused {
+		c.states.StoreResumed(handle)
+	}
+
 	c.states.StoreStopped(handle)
 	return nil
 }
 
+// Pause freezes all of the processes in the container
+func (c *Containerizer) Pause(log lager.Logger, handle string) error {
+	log = log.Session("pause", lager.Data{"handle": handle})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	if err := c.runtime.Pause(log, handle); err != nil {
+		log.Error("runtime-pause-failed", err)
+		return fmt.Errorf("pause: %s", err)
+	}
+
+	c.states.StorePaused(handle)
+	return nil
+}
+
+// Resume thaws all of the processes in a paused container
+func (c *Containerizer) Resume(log lager.Logger, handle string) error {
+	log = log.Session("resume", lager.Data{"handle": handle})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	if err := c.runtime.Resume(log, handle); err != nil {
+		log.Error("runtime-resume-failed", err)
+		return fmt.Errorf("resume: %s", err)
+	}
+
+	c.states.StoreResumed(handle)
+	return nil
+}
+
+// Checkpoint dumps the state of the container's processes to an image
+// directory in its bundle, stopping the container
+func (c *Containerizer) Checkpoint(log lager.Logger, handle string) error {
+	log = log.Session("checkpoint", lager.Data{"handle": handle})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	bundlePath, err := c.depot.Lookup(log, handle)
+	if err != nil {
+		log.Error("lookup-failed", err)
+		return err
+	}
+
+	if err := c.runtime.Checkpoint(log, handle, filepath.Join(bundlePath, CheckpointDir)); err != nil {
+		log.Error("runtime-checkpoint-failed", err)
+		return fmt.Errorf("checkpoint: %s", err)
+	}
+
+	return nil
+}
+
+// RestoreCheckpoint recreates the container from the image directory written
+// by Checkpoint and resumes watching it for events
+func (c *Containerizer) RestoreCheckpoint(log lager.Logger, handle string) error {
+	log = log.Session("restore-checkpoint", lager.Data{"handle": handle})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	bundlePath, err := c.depot.Lookup(log, handle)
+	if err != nil {
+		log.Error("lookup-failed", err)
+		return err
+	}
+
+	if err := c.runtime.Restore(log, bundlePath, handle, filepath.Join(bundlePath, CheckpointDir)); err != nil {
+		log.Error("runtime-restore-failed", err)
+		return fmt.Errorf("restore checkpoint: %s", err)
+	}
+
+	c.watchEvents(log, handle)
+	return nil
+}
+
 // Destroy deletes the container and the bundle directory
 func (c *Containerizer) Destroy(log lager.Logger, handle string) error {
 	log = log.Session("destroy", lager.Data{"handle": handle})
@@ -238,6 +460,19 @@ func (c *Containerizer) Destroy(log lager.Logger, handle string) error {
 		"state": state,
 	})
 
+	if state.Status == runrunc.PausedStatus {
+		// a paused container cannot be deleted, so thaw it first
+		if err := c.runtime.Resume(log, handle); err != nil {
+			log.Error("resume-failed", err)
+			return err
+		}
+
+		if state, err = c.runtime.State(log, handle); err != nil {
+			log.Info("state-failed-skipping-delete", lager.Data{"error": err.Error()})
+			return nil
+		}
+	}
+
 	if state.Status == runrunc.CreatedStatus || state.Status == runrunc.StoppedStatus {
 		if err := c.runtime.Delete(log, handle); err != nil {
 			log.Error("delete-failed", err)
@@ -269,6 +504,19 @@ func (c *Containerizer) Info(log lager.Logger, handle string) (gardener.ActualCo
 		return gardener.ActualContainerSpec{}, err
 	}
 
+	// the rest of the info is still useful when the processes cannot be listed
+	processes, err := c.processes.List(filepath.Join(bundlePath, "processes"))
+	if err != nil {
+		log.Error("list-processes-failed", err, lager.Data{"handle": handle})
+	}
+
+	processIDs := []string{}
+	for _, process := range processes {
+		if process.ExitStatus == nil {
+			processIDs = append(processIDs, process.ID)
+		}
+	}
+
 	privileged := true
 	for _, ns := range bundle.Namespaces() {
 		if ns.Type == specs.UserNamespace {
@@ -282,7 +530,9 @@ func (c *Containerizer) Info(log lager.Logger, handle string) (gardener.ActualCo
 		BundlePath: bundlePath,
 		RootFSPath: bundle.RootFS(),
 		Events:     c.events.Events(handle),
+		ProcessIDs: processIDs,
 		Stopped:    c.states.IsStopped(handle),
+		Paused:     c.states.IsPaused(handle),
 		Limits: garden.Limits{
 			CPU: garden.CPULimits{
 				LimitInShares: *bundle.Resources().CPU.Shares,
@@ -295,6 +545,60 @@ func (c *Containerizer) Info(log lager.Logger, handle string) (gardener.ActualCo
 	}, nil
 }
 
+// LimitMemory updates the memory limit of a running container and records the
+// new limit in its bundle
+func (c *Containerizer) LimitMemory(log lager.Logger, handle string, limits garden.MemoryLimits) error {
+	log = log.Session("limit-memory", lager.Data{"handle": handle, "limit": limits.LimitInBytes})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	memory := c.limits.Memory(limits)
+	return c.updateResources(log, handle, specs.LinuxResources{Memory: &memory}, func(bundle goci.Bndl) goci.Bndl {
+		return bundle.WithMemoryLimit(memory)
+	})
+}
+
+// LimitCPU updates the cpu shares (and quota, if configured) of a running
+// container and records the new limits in its bundle
+func (c *Containerizer) LimitCPU(log lager.Logger, handle string, limits garden.CPULimits) error {
+	log = log.Session("limit-cpu", lager.Data{"handle": handle, "shares": limits.LimitInShares})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	cpu := c.limits.CPU(limits)
+	return c.updateResources(log, handle, specs.LinuxResources{CPU: &cpu}, func(bundle goci.Bndl) goci.Bndl {
+		return bundle.WithCPUShares(cpu)
+	})
+}
+
+func (c *Containerizer) updateResources(log lager.Logger, handle string, resources specs.LinuxResources, updateBundle func(goci.Bndl) goci.Bndl) error {
+	bundlePath, err := c.depot.Lookup(log, handle)
+	if err != nil {
+		log.Error("lookup-failed", err)
+		return err
+	}
+
+	bundle, err := c.loader.Load(bundlePath)
+	if err != nil {
+		log.Error("load-bundle-failed", err)
+		return err
+	}
+
+	if err := c.runtime.UpdateResources(log, handle, resources); err != nil {
+		log.Error("update-resources-failed", err)
+		return fmt.Errorf("update resources: %s", err)
+	}
+
+	if err := updateBundle(bundle).Save(bundlePath); err != nil {
+		log.Error("save-bundle-failed", err)
+		return err
+	}
+
+	return nil
+}
+
 func (c *Containerizer) Metrics(log lager.Logger, handle string) (gardener.ActualContainerMetrics, error) {
 	return c.runtime.Stats(log, handle)
 }
@@ -303,3 +607,19 @@ func (c *Containerizer) Metrics(log lager.Logger, handle string) (gardener.Actua
 func (c *Containerizer) Handles() ([]string, error) {
 	return c.depot.Handles()
 }
+
+// Events returns the recorded event history of a container, filtered by query
+func (c *Containerizer) Events(handle string, query EventQuery) EventHistory {
+	return c.events.Query(handle, query)
+}
+
+// Processes lists the processes which have been run in a container, including
+// those which have exited but have not yet been waited for
+func (c *Containerizer) Processes(log lager.Logger, handle string) ([]gardener.ProcessInfo, error) {
+	bundlePath, err := c.depot.Lookup(log, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.processes.List(filepath.Join(bundlePath, "processes"))
+}
@@ -2,7 +2,10 @@ package rundmc_test
 
 import (
 	"errors"
+	"io/ioutil"
 	"os"
+	"path/filepath"
+	"sync/atomic"
 	"time"
 
 	"code.cloudfoundry.org/garden"
@@ -17,6 +20,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/pivotal-golang/clock/fakeclock"
 )
 
 var _ = Describe("Rundmc", func() {
@@ -29,6 +33,11 @@ var _ = Describe("Rundmc", func() {
 		fakeStopper      *fakes.FakeStopper
 		fakeEventStore   *fakes.FakeEventStore
 		fakeStateStore   *fakes.FakeStateStore
+		fakeLimits       *fakes.FakeResourceLimits
+		fakeRetrier      *fakes.FakeRetrier
+		retriersCreated  int32
+		fakeClock        *fakeclock.FakeClock
+		fakeProcesses    *fakes.FakeProcessLister
 
 		logger        lager.Logger
 		containerizer *rundmc.Containerizer
@@ -43,13 +52,25 @@ var _ = Describe("Rundmc", func() {
 		fakeStopper = new(fakes.FakeStopper)
 		fakeEventStore = new(fakes.FakeEventStore)
 		fakeStateStore = new(fakes.FakeStateStore)
+		fakeLimits = new(fakes.FakeResourceLimits)
+		fakeRetrier = new(fakes.FakeRetrier)
+		retriersCreated = 0
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+		fakeProcesses = new(fakes.FakeProcessLister)
 		logger = lagertest.NewTestLogger("test")
 
+		fakeRetrier.RunStub = func(work func() error) error {
+			return work()
+		}
+
 		fakeDepot.LookupStub = func(_ lager.Logger, handle string) (string, error) {
 			return "/path/to/" + handle, nil
 		}
 
-		containerizer = rundmc.New(fakeDepot, fakeBundler, fakeOCIRuntime, fakeBundleLoader, fakeNstarRunner, fakeStopper, fakeEventStore, fakeStateStore)
+		containerizer = rundmc.New(fakeDepot, fakeBundler, fakeOCIRuntime, fakeBundleLoader, fakeNstarRunner, fakeStopper, fakeEventStore, fakeStateStore, fakeLimits, func() rundmc.Retrier {
+			atomic.AddInt32(&retriersCreated, 1)
+			return fakeRetrier
+		}, fakeClock, fakeProcesses)
 	})
 
 	Describe("Create", func() {
@@ -108,6 +129,17 @@ var _ = Describe("Rundmc", func() {
 			})
 		})
 
+		Context("when a runtime is requested but the runtime does not support selection", func() {
+			It("should return an error without creating the container", func() {
+				Expect(containerizer.Create(logger, gardener.DesiredContainerSpec{
+					Handle:  "exuberant!",
+					Runtime: "crun",
+				})).NotTo(Succeed())
+
+				Expect(fakeOCIRuntime.CreateCallCount()).To(Equal(0))
+			})
+		})
+
 		It("should watch for events in a goroutine", func() {
 			fakeOCIRuntime.WatchEventsStub = func(_ lager.Logger, _ string, _ runrunc.EventsNotifier) error {
 				time.Sleep(10 * time.Second)
@@ -135,6 +167,107 @@ var _ = Describe("Rundmc", func() {
 		})
 	})
 
+	Describe("WatchEvents", func() {
+		var testLogger *lagertest.TestLogger
+
+		BeforeEach(func() {
+			testLogger = lagertest.NewTestLogger("test")
+			logger = testLogger
+
+			fakeRetrier.RunStub = func(work func() error) (err error) {
+				for i := 0; i < 3; i++ {
+					if err = work(); err == nil {
+						return nil
+					}
+				}
+
+				return err
+			}
+		})
+
+		It("watches the container for events in the background", func() {
+			containerizer.WatchEvents(logger, "some-handle")
+
+			Eventually(fakeOCIRuntime.WatchEventsCallCount).Should(BeNumerically(">=", 1))
+			_, handle, eventsNotifier := fakeOCIRuntime.WatchEventsArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(eventsNotifier).To(Equal(fakeEventStore))
+		})
+
+		It("gives each watcher its own retrier", func() {
+			containerizer.WatchEvents(logger, "some-handle")
+			containerizer.WatchEvents(logger, "other-handle")
+
+			Expect(atomic.LoadInt32(&retriersCreated)).To(Equal(int32(2)))
+		})
+
+		Context("when the watcher exits while the container still exists", func() {
+			It("restarts the watcher using the retrier", func() {
+				containerizer.WatchEvents(logger, "some-handle")
+
+				Eventually(fakeOCIRuntime.WatchEventsCallCount).Should(Equal(3))
+				Consistently(fakeOCIRuntime.WatchEventsCallCount).Should(Equal(3))
+			})
+
+			It("logs when it gives up", func() {
+				fakeOCIRuntime.WatchEventsReturns(errors.New("events-exploded"))
+				containerizer.WatchEvents(logger, "some-handle")
+
+				Eventually(testLogger).Should(gbytes.Say("watch-gave-up"))
+				Expect(testLogger).To(gbytes.Say("events-exploded"))
+			})
+		})
+
+		Context("when the watcher dies after running healthily for a while", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.WatchEventsStub = func(lager.Logger, string, runrunc.EventsNotifier) error {
+					if fakeOCIRuntime.WatchEventsCallCount() == 1 {
+						fakeClock.Increment(2 * time.Minute)
+					}
+
+					return errors.New("watcher-died")
+				}
+			})
+
+			It("restarts it with a fresh retrier, rather than counting the death towards giving up", func() {
+				containerizer.WatchEvents(logger, "some-handle")
+
+				Eventually(fakeOCIRuntime.WatchEventsCallCount).Should(Equal(4))
+				Consistently(fakeOCIRuntime.WatchEventsCallCount).Should(Equal(4))
+				Expect(atomic.LoadInt32(&retriersCreated)).To(Equal(int32(2)))
+			})
+		})
+
+		Context("when the watcher exits because the container's init process has exited", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.StateReturns(runrunc.State{Status: runrunc.StoppedStatus}, nil)
+			})
+
+			It("does not restart the watcher or give up", func() {
+				containerizer.WatchEvents(logger, "some-handle")
+
+				Eventually(fakeOCIRuntime.WatchEventsCallCount).Should(Equal(1))
+				Consistently(fakeOCIRuntime.WatchEventsCallCount).Should(Equal(1))
+				Consistently(testLogger).ShouldNot(gbytes.Say("watch-failed"))
+				Expect(testLogger).NotTo(gbytes.Say("watch-gave-up"))
+			})
+		})
+
+		Context("when the watcher exits because the container has gone away", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.StateReturns(runrunc.State{}, errors.New("container does not exist"))
+			})
+
+			It("does not restart the watcher", func() {
+				containerizer.WatchEvents(logger, "some-handle")
+
+				Eventually(fakeOCIRuntime.WatchEventsCallCount).Should(Equal(1))
+				Consistently(fakeOCIRuntime.WatchEventsCallCount).Should(Equal(1))
+				Consistently(testLogger).ShouldNot(gbytes.Say("watch-gave-up"))
+			})
+		})
+	})
+
 	Describe("Run", func() {
 		It("should ask the execer to exec a process in the container", func() {
 			containerizer.Run(logger, "some-handle", garden.ProcessSpec{Path: "hello"}, garden.ProcessIO{})
@@ -159,6 +292,23 @@ var _ = Describe("Rundmc", func() {
 				Expect(fakeOCIRuntime.ExecCallCount()).To(Equal(0))
 			})
 		})
+
+		Context("when the container is paused", func() {
+			BeforeEach(func() {
+				fakeStateStore.IsPausedReturns(true)
+			})
+
+			It("returns ErrContainerPaused", func() {
+				_, err := containerizer.Run(logger, "some-handle", garden.ProcessSpec{}, garden.ProcessIO{})
+				Expect(err).To(Equal(rundmc.ErrContainerPaused))
+				Expect(fakeStateStore.IsPausedArgsForCall(0)).To(Equal("some-handle"))
+			})
+
+			It("does not attempt to exec the process", func() {
+				containerizer.Run(logger, "some-handle", garden.ProcessSpec{}, garden.ProcessIO{})
+				Expect(fakeOCIRuntime.ExecCallCount()).To(Equal(0))
+			})
+		})
 	})
 
 	Describe("Attach", func() {
@@ -166,10 +316,11 @@ var _ = Describe("Rundmc", func() {
 			containerizer.Attach(logger, "some-handle", "123", garden.ProcessIO{})
 			Expect(fakeOCIRuntime.AttachCallCount()).To(Equal(1))
 
-			_, path, id, processId, _ := fakeOCIRuntime.AttachArgsForCall(0)
+			_, path, id, processId, replay, _ := fakeOCIRuntime.AttachArgsForCall(0)
 			Expect(path).To(Equal("/path/to/some-handle"))
 			Expect(id).To(Equal("some-handle"))
 			Expect(processId).To(Equal("123"))
+			Expect(replay).To(BeNil())
 		})
 
 		Context("when looking up the container fails", func() {
@@ -187,6 +338,19 @@ var _ = Describe("Rundmc", func() {
 		})
 	})
 
+	Describe("AttachReplaying", func() {
+		It("should ask the execer to attach a process, replaying its output from the offsets", func() {
+			containerizer.AttachReplaying(logger, "some-handle", "123", gardener.OutputOffsets{Stdout: 4, Stderr: 2}, garden.ProcessIO{})
+			Expect(fakeOCIRuntime.AttachCallCount()).To(Equal(1))
+
+			_, path, id, processId, replay, _ := fakeOCIRuntime.AttachArgsForCall(0)
+			Expect(path).To(Equal("/path/to/some-handle"))
+			Expect(id).To(Equal("some-handle"))
+			Expect(processId).To(Equal("123"))
+			Expect(replay).To(Equal(&gardener.OutputOffsets{Stdout: 4, Stderr: 2}))
+		})
+	})
+
 	Describe("StreamIn", func() {
 		It("should execute the NSTar command with the container PID", func() {
 			fakeOCIRuntime.StateReturns(runrunc.State{
@@ -216,6 +380,21 @@ var _ = Describe("Rundmc", func() {
 			fakeNstarRunner.StreamInReturns(errors.New("failed"))
 			Expect(containerizer.StreamIn(logger, "some-handle", garden.StreamInSpec{})).To(MatchError("stream-in: nstar: failed"))
 		})
+
+		Context("when the container is paused", func() {
+			BeforeEach(func() {
+				fakeStateStore.IsPausedReturns(true)
+			})
+
+			It("returns ErrContainerPaused", func() {
+				Expect(containerizer.StreamIn(logger, "some-handle", garden.StreamInSpec{})).To(Equal(rundmc.ErrContainerPaused))
+			})
+
+			It("does not stream anything in", func() {
+				containerizer.StreamIn(logger, "some-handle", garden.StreamInSpec{})
+				Expect(fakeNstarRunner.StreamInCallCount()).To(Equal(0))
+			})
+		})
 	})
 
 	Describe("StreamOut", func() {
@@ -292,6 +471,48 @@ var _ = Describe("Rundmc", func() {
 			})
 		})
 
+		Context("when the container is paused", func() {
+			BeforeEach(func() {
+				fakeStateStore.IsPausedReturns(true)
+				fakeOCIRuntime.ResumeStub = func(lager.Logger, string) error {
+					Expect(fakeStopper.StopAllCallCount()).To(Equal(0))
+					return nil
+				}
+			})
+
+			It("resumes it before stopping the processes, so that they act on the signals", func() {
+				Expect(containerizer.Stop(logger, "some-handle", true)).To(Succeed())
+
+				Expect(fakeOCIRuntime.ResumeCallCount()).To(Equal(1))
+				_, handle := fakeOCIRuntime.ResumeArgsForCall(0)
+				Expect(handle).To(Equal("some-handle"))
+				Expect(fakeStopper.StopAllCallCount()).To(Equal(1))
+			})
+
+			It("clears the paused state once the processes are stopped", func() {
+				Expect(containerizer.Stop(logger, "some-handle", true)).To(Succeed())
+
+				Expect(fakeStateStore.StoreResumedCallCount()).To(Equal(1))
+				Expect(fakeStateStore.StoreResumedArgsForCall(0)).To(Equal("some-handle"))
+				Expect(fakeStateStore.StoreStoppedCallCount()).To(Equal(1))
+			})
+
+			Context("when resuming fails", func() {
+				BeforeEach(func() {
+					fakeOCIRuntime.ResumeStub = nil
+					fakeOCIRuntime.ResumeReturns(errors.New("frozen-solid"))
+				})
+
+				It("does not stop the processes or change the state", func() {
+					Expect(containerizer.Stop(logger, "some-handle", true)).To(MatchError(ContainSubstring("frozen-solid")))
+
+					Expect(fakeStopper.StopAllCallCount()).To(Equal(0))
+					Expect(fakeStateStore.StoreResumedCallCount()).To(Equal(0))
+					Expect(fakeStateStore.StoreStoppedCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when the stop fails", func() {
 			BeforeEach(func() {
 				fakeStopper.StopAllReturns(errors.New("boom"))
@@ -319,6 +540,149 @@ var _ = Describe("Rundmc", func() {
 		})
 	})
 
+	Describe("Pause", func() {
+		It("asks the runtime to pause the container", func() {
+			Expect(containerizer.Pause(logger, "some-handle")).To(Succeed())
+
+			Expect(fakeOCIRuntime.PauseCallCount()).To(Equal(1))
+			_, id := fakeOCIRuntime.PauseArgsForCall(0)
+			Expect(id).To(Equal("some-handle"))
+		})
+
+		It("records the paused state", func() {
+			Expect(containerizer.Pause(logger, "some-handle")).To(Succeed())
+
+			Expect(fakeStateStore.StorePausedCallCount()).To(Equal(1))
+			Expect(fakeStateStore.StorePausedArgsForCall(0)).To(Equal("some-handle"))
+		})
+
+		Context("when pausing fails", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.PauseReturns(errors.New("frozen-solid"))
+			})
+
+			It("returns the error", func() {
+				Expect(containerizer.Pause(logger, "some-handle")).To(MatchError("pause: frozen-solid"))
+			})
+
+			It("does not record the paused state", func() {
+				containerizer.Pause(logger, "some-handle")
+				Expect(fakeStateStore.StorePausedCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Resume", func() {
+		It("asks the runtime to resume the container", func() {
+			Expect(containerizer.Resume(logger, "some-handle")).To(Succeed())
+
+			Expect(fakeOCIRuntime.ResumeCallCount()).To(Equal(1))
+			_, id := fakeOCIRuntime.ResumeArgsForCall(0)
+			Expect(id).To(Equal("some-handle"))
+		})
+
+		It("clears the paused state", func() {
+			Expect(containerizer.Resume(logger, "some-handle")).To(Succeed())
+
+			Expect(fakeStateStore.StoreResumedCallCount()).To(Equal(1))
+			Expect(fakeStateStore.StoreResumedArgsForCall(0)).To(Equal("some-handle"))
+		})
+
+		Context("when resuming fails", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.ResumeReturns(errors.New("still-frozen"))
+			})
+
+			It("returns the error", func() {
+				Expect(containerizer.Resume(logger, "some-handle")).To(MatchError("resume: still-frozen"))
+			})
+
+			It("does not clear the paused state", func() {
+				containerizer.Resume(logger, "some-handle")
+				Expect(fakeStateStore.StoreResumedCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("Checkpoint", func() {
+		It("asks the runtime to checkpoint the container into the bundle's checkpoint directory", func() {
+			Expect(containerizer.Checkpoint(logger, "some-handle")).To(Succeed())
+
+			Expect(fakeOCIRuntime.CheckpointCallCount()).To(Equal(1))
+			_, id, imagePath := fakeOCIRuntime.CheckpointArgsForCall(0)
+			Expect(id).To(Equal("some-handle"))
+			Expect(imagePath).To(Equal("/path/to/some-handle/checkpoint"))
+		})
+
+		Context("when looking up the container fails", func() {
+			BeforeEach(func() {
+				fakeDepot.LookupReturns("", errors.New("blam"))
+			})
+
+			It("returns the error without checkpointing", func() {
+				Expect(containerizer.Checkpoint(logger, "some-handle")).To(MatchError("blam"))
+				Expect(fakeOCIRuntime.CheckpointCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when checkpointing fails", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.CheckpointReturns(errors.New("criu-exploded"))
+			})
+
+			It("returns the error", func() {
+				Expect(containerizer.Checkpoint(logger, "some-handle")).To(MatchError("checkpoint: criu-exploded"))
+			})
+		})
+	})
+
+	Describe("RestoreCheckpoint", func() {
+		It("asks the runtime to restore the container from the bundle's checkpoint directory", func() {
+			Expect(containerizer.RestoreCheckpoint(logger, "some-handle")).To(Succeed())
+
+			Expect(fakeOCIRuntime.RestoreCallCount()).To(Equal(1))
+			_, bundlePath, id, imagePath := fakeOCIRuntime.RestoreArgsForCall(0)
+			Expect(bundlePath).To(Equal("/path/to/some-handle"))
+			Expect(id).To(Equal("some-handle"))
+			Expect(imagePath).To(Equal("/path/to/some-handle/checkpoint"))
+		})
+
+		It("watches the restored container for events", func() {
+			Expect(containerizer.RestoreCheckpoint(logger, "some-handle")).To(Succeed())
+
+			Eventually(fakeOCIRuntime.WatchEventsCallCount).Should(Equal(1))
+			_, handle, eventsNotifier := fakeOCIRuntime.WatchEventsArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(eventsNotifier).To(Equal(fakeEventStore))
+		})
+
+		Context("when looking up the container fails", func() {
+			BeforeEach(func() {
+				fakeDepot.LookupReturns("", errors.New("blam"))
+			})
+
+			It("returns the error without restoring", func() {
+				Expect(containerizer.RestoreCheckpoint(logger, "some-handle")).To(MatchError("blam"))
+				Expect(fakeOCIRuntime.RestoreCallCount()).To(Equal(0))
+			})
+		})
+
+		Context("when restoring fails", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.RestoreReturns(errors.New("criu-exploded"))
+			})
+
+			It("returns the error", func() {
+				Expect(containerizer.RestoreCheckpoint(logger, "some-handle")).To(MatchError("restore checkpoint: criu-exploded"))
+			})
+
+			It("does not watch for events", func() {
+				containerizer.RestoreCheckpoint(logger, "some-handle")
+				Consistently(fakeOCIRuntime.WatchEventsCallCount).Should(Equal(0))
+			})
+		})
+	})
+
 	Describe("Destroy", func() {
 		Context("when getting state fails", func() {
 			BeforeEach(func() {
@@ -373,6 +737,39 @@ var _ = Describe("Rundmc", func() {
 			})
 		})
 
+		Context("when the container is paused", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.StateStub = func(_ lager.Logger, _ string) (runrunc.State, error) {
+					if fakeOCIRuntime.ResumeCallCount() == 0 {
+						return runrunc.State{Status: "paused"}, nil
+					}
+
+					return runrunc.State{Status: "created"}, nil
+				}
+			})
+
+			It("resumes the container before deleting it", func() {
+				Expect(containerizer.Destroy(logger, "some-handle")).To(Succeed())
+
+				Expect(fakeOCIRuntime.ResumeCallCount()).To(Equal(1))
+				_, id := fakeOCIRuntime.ResumeArgsForCall(0)
+				Expect(id).To(Equal("some-handle"))
+
+				Expect(fakeOCIRuntime.DeleteCallCount()).To(Equal(1))
+			})
+
+			Context("when resuming fails", func() {
+				BeforeEach(func() {
+					fakeOCIRuntime.ResumeReturns(errors.New("still-frozen"))
+				})
+
+				It("returns the error without deleting", func() {
+					Expect(containerizer.Destroy(logger, "some-handle")).To(MatchError("still-frozen"))
+					Expect(fakeOCIRuntime.DeleteCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		Context("when state that should not result in a delete", func() {
 			BeforeEach(func() {
 				fakeOCIRuntime.StateReturns(runrunc.State{
@@ -463,6 +860,42 @@ var _ = Describe("Rundmc", func() {
 			Expect(actualSpec.Pid).To(Equal(42))
 		})
 
+		It("should return the ActualContainerSpec with the IDs of the running processes", func() {
+			exitStatus := 0
+			fakeProcesses.ListReturns([]gardener.ProcessInfo{
+				{ID: "running"},
+				{ID: "exited", ExitStatus: &exitStatus},
+			}, nil)
+
+			actualSpec, err := containerizer.Info(logger, "some-handle")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualSpec.ProcessIDs).To(Equal([]string{"running"}))
+
+			Expect(fakeProcesses.ListArgsForCall(0)).To(Equal("/path/to/some-handle/processes"))
+		})
+
+		Context("when listing the processes fails", func() {
+			var testLogger *lagertest.TestLogger
+
+			BeforeEach(func() {
+				testLogger = lagertest.NewTestLogger("test")
+				logger = testLogger
+				fakeProcesses.ListReturns(nil, errors.New("batman-error"))
+			})
+
+			It("should return the rest of the ActualContainerSpec without any process IDs", func() {
+				actualSpec, err := containerizer.Info(logger, "some-handle")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actualSpec.Pid).To(Equal(42))
+				Expect(actualSpec.ProcessIDs).To(BeEmpty())
+			})
+
+			It("should log the error", func() {
+				containerizer.Info(logger, "some-handle")
+				Expect(testLogger).To(gbytes.Say("list-processes-failed.*batman-error"))
+			})
+		})
+
 		Context("when looking up the bundle path fails", func() {
 			It("should return the error", func() {
 				fakeDepot.LookupReturns("", errors.New("spiderman-error"))
@@ -501,6 +934,14 @@ var _ = Describe("Rundmc", func() {
 			Expect(actualSpec.Stopped).To(Equal(true))
 		})
 
+		It("should return the paused state from the state store", func() {
+			fakeStateStore.IsPausedReturns(true)
+
+			actualSpec, err := containerizer.Info(logger, "some-handle")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actualSpec.Paused).To(BeTrue())
+		})
+
 		It("should return the ActualContainerSpec with privileged by default", func() {
 			actualSpec, err := containerizer.Info(logger, "some-handle")
 			Expect(err).NotTo(HaveOccurred())
@@ -531,6 +972,132 @@ var _ = Describe("Rundmc", func() {
 		})
 	})
 
+	Describe("updating limits", func() {
+		var bundlePath string
+
+		BeforeEach(func() {
+			var err error
+			bundlePath, err = ioutil.TempDir("", "limits")
+			Expect(err).NotTo(HaveOccurred())
+
+			fakeDepot.LookupReturns(bundlePath, nil)
+			fakeBundleLoader.LoadReturns(goci.Bundle().WithHostname("some-hostname"), nil)
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(bundlePath)).To(Succeed())
+		})
+
+		Describe("LimitMemory", func() {
+			BeforeEach(func() {
+				limit := uint64(1024)
+				fakeLimits.MemoryReturns(specs.LinuxMemory{Limit: &limit, Swap: &limit})
+			})
+
+			It("translates the garden limits to memory cgroup settings", func() {
+				Expect(containerizer.LimitMemory(logger, "some-handle", garden.MemoryLimits{LimitInBytes: 1024})).To(Succeed())
+
+				Expect(fakeLimits.MemoryCallCount()).To(Equal(1))
+				Expect(fakeLimits.MemoryArgsForCall(0)).To(Equal(garden.MemoryLimits{LimitInBytes: 1024}))
+			})
+
+			It("updates the memory and swap limits of the running container", func() {
+				Expect(containerizer.LimitMemory(logger, "some-handle", garden.MemoryLimits{LimitInBytes: 1024})).To(Succeed())
+
+				Expect(fakeOCIRuntime.UpdateResourcesCallCount()).To(Equal(1))
+				_, id, resources := fakeOCIRuntime.UpdateResourcesArgsForCall(0)
+				Expect(id).To(Equal("some-handle"))
+				Expect(*resources.Memory.Limit).To(BeEquivalentTo(1024))
+				Expect(*resources.Memory.Swap).To(BeEquivalentTo(1024))
+				Expect(resources.CPU).To(BeNil())
+			})
+
+			It("saves the new limit in the bundle", func() {
+				Expect(containerizer.LimitMemory(logger, "some-handle", garden.MemoryLimits{LimitInBytes: 1024})).To(Succeed())
+
+				bundle, err := (&goci.BndlLoader{}).Load(bundlePath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bundle.Hostname()).To(Equal("some-hostname"))
+				Expect(*bundle.Resources().Memory.Limit).To(BeEquivalentTo(1024))
+			})
+		})
+
+		Describe("LimitCPU", func() {
+			BeforeEach(func() {
+				var shares, quota, period uint64 = 512, 5120, 100000
+				fakeLimits.CPUReturns(specs.LinuxCPU{Shares: &shares, Quota: &quota, Period: &period})
+			})
+
+			It("translates the garden limits to cpu cgroup settings", func() {
+				Expect(containerizer.LimitCPU(logger, "some-handle", garden.CPULimits{LimitInShares: 512})).To(Succeed())
+
+				Expect(fakeLimits.CPUCallCount()).To(Equal(1))
+				Expect(fakeLimits.CPUArgsForCall(0)).To(Equal(garden.CPULimits{LimitInShares: 512}))
+			})
+
+			It("updates the cpu shares and quota of the running container", func() {
+				Expect(containerizer.LimitCPU(logger, "some-handle", garden.CPULimits{LimitInShares: 512})).To(Succeed())
+
+				Expect(fakeOCIRuntime.UpdateResourcesCallCount()).To(Equal(1))
+				_, id, resources := fakeOCIRuntime.UpdateResourcesArgsForCall(0)
+				Expect(id).To(Equal("some-handle"))
+				Expect(*resources.CPU.Shares).To(BeEquivalentTo(512))
+				Expect(*resources.CPU.Quota).To(BeEquivalentTo(5120))
+				Expect(*resources.CPU.Period).To(BeEquivalentTo(100000))
+				Expect(resources.Memory).To(BeNil())
+			})
+
+			It("saves the new limits in the bundle", func() {
+				Expect(containerizer.LimitCPU(logger, "some-handle", garden.CPULimits{LimitInShares: 512})).To(Succeed())
+
+				bundle, err := (&goci.BndlLoader{}).Load(bundlePath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(bundle.Hostname()).To(Equal("some-hostname"))
+				Expect(*bundle.Resources().CPU.Shares).To(BeEquivalentTo(512))
+				Expect(*bundle.Resources().CPU.Quota).To(BeEquivalentTo(5120))
+			})
+		})
+
+		Context("when looking up the bundle fails", func() {
+			BeforeEach(func() {
+				fakeDepot.LookupReturns("", errors.New("spiderman-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(containerizer.LimitMemory(logger, "some-handle", garden.MemoryLimits{})).To(MatchError("spiderman-error"))
+				Expect(containerizer.LimitCPU(logger, "some-handle", garden.CPULimits{})).To(MatchError("spiderman-error"))
+			})
+		})
+
+		Context("when loading the bundle fails", func() {
+			BeforeEach(func() {
+				fakeBundleLoader.LoadReturns(goci.Bndl{}, errors.New("aquaman-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(containerizer.LimitMemory(logger, "some-handle", garden.MemoryLimits{})).To(MatchError("aquaman-error"))
+				Expect(containerizer.LimitCPU(logger, "some-handle", garden.CPULimits{})).To(MatchError("aquaman-error"))
+			})
+		})
+
+		Context("when updating the runtime fails", func() {
+			BeforeEach(func() {
+				fakeOCIRuntime.UpdateResourcesReturns(errors.New("batman-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(containerizer.LimitMemory(logger, "some-handle", garden.MemoryLimits{})).To(MatchError(ContainSubstring("batman-error")))
+				Expect(containerizer.LimitCPU(logger, "some-handle", garden.CPULimits{})).To(MatchError(ContainSubstring("batman-error")))
+			})
+
+			It("does not change the limits in the bundle", func() {
+				containerizer.LimitMemory(logger, "some-handle", garden.MemoryLimits{})
+				containerizer.LimitCPU(logger, "some-handle", garden.CPULimits{})
+				Expect(filepath.Join(bundlePath, "config.json")).NotTo(BeAnExistingFile())
+			})
+		})
+	})
+
 	Describe("Metrics", func() {
 		It("returns the CPU metrics", func() {
 			metrics := gardener.ActualContainerMetrics{
@@ -581,6 +1148,45 @@ var _ = Describe("Rundmc", func() {
 			})
 		})
 	})
+
+	Describe("Events", func() {
+		It("should query the event store", func() {
+			query := rundmc.EventQuery{Type: "Out of memory", Limit: 3}
+			fakeEventStore.QueryReturns(rundmc.EventHistory{
+				Total:  7,
+				Events: []rundmc.Event{{Type: "Out of memory", Source: "runc"}},
+			})
+
+			history := containerizer.Events("some-handle", query)
+			Expect(history.Total).To(BeEquivalentTo(7))
+			Expect(history.Events).To(ConsistOf(rundmc.Event{Type: "Out of memory", Source: "runc"}))
+
+			Expect(fakeEventStore.QueryCallCount()).To(Equal(1))
+			handle, actualQuery := fakeEventStore.QueryArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(actualQuery).To(Equal(query))
+		})
+	})
+
+	Describe("Processes", func() {
+		It("should list the processes in the container's bundle", func() {
+			fakeProcesses.ListReturns([]gardener.ProcessInfo{{ID: "some-process"}}, nil)
+
+			processes, err := containerizer.Processes(logger, "some-handle")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processes).To(Equal([]gardener.ProcessInfo{{ID: "some-process"}}))
+
+			Expect(fakeProcesses.ListArgsForCall(0)).To(Equal("/path/to/some-handle/processes"))
+		})
+
+		Context("when looking up the bundle path fails", func() {
+			It("should return the error", func() {
+				fakeDepot.LookupReturns("", errors.New("spiderman-error"))
+				_, err := containerizer.Processes(logger, "some-handle")
+				Expect(err).To(MatchError("spiderman-error"))
+			})
+		})
+	})
 })
 
 func arg2(_ lager.Logger, i interface{}) interface{} {
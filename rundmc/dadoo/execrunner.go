@@ -14,8 +14,10 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
 	"code.cloudfoundry.org/guardian/rundmc/runrunc"
 	"code.cloudfoundry.org/lager"
 	"github.com/cloudfoundry/gunk/command_runner"
@@ -30,18 +32,32 @@ type PidGetter interface {
 type ExecRunner struct {
 	dadooPath     string
 	runcPath      string
+	runcArgs      []string
 	processIDGen  runrunc.UidGenerator
 	pidGetter     PidGetter
 	commandRunner command_runner.CommandRunner
+
+	// how long to keep the exit status of a process once it has been waited
+	// for, or 0 to forget it straight away
+	tombstoneRetention time.Duration
+
+	// whether, and how much of, process output is logged for replay
+	outputLogLimits OutputLogLimits
+
+	exits *sharedExits
 }
 
-func NewExecRunner(dadooPath, runcPath string, processIDGen runrunc.UidGenerator, pidGetter PidGetter, commandRunner command_runner.CommandRunner) *ExecRunner {
+func NewExecRunner(dadooPath, runcPath string, runcArgs []string, processIDGen runrunc.UidGenerator, pidGetter PidGetter, commandRunner command_runner.CommandRunner, tombstoneRetention time.Duration, outputLogLimits OutputLogLimits) *ExecRunner {
 	return &ExecRunner{
-		dadooPath:     dadooPath,
-		runcPath:      runcPath,
-		processIDGen:  processIDGen,
-		pidGetter:     pidGetter,
-		commandRunner: commandRunner,
+		dadooPath:          dadooPath,
+		runcPath:           runcPath,
+		runcArgs:           runcArgs,
+		processIDGen:       processIDGen,
+		pidGetter:          pidGetter,
+		commandRunner:      commandRunner,
+		tombstoneRetention: tombstoneRetention,
+		outputLogLimits:    outputLogLimits,
+		exits:              newSharedExits(),
 	}
 }
 
@@ -51,6 +67,10 @@ func (d *ExecRunner) Run(log lager.Logger, spec *runrunc.PreparedSpec, bundlePat
 	log.Info("start")
 	defer log.Info("done")
 
+	if err := sweepTombstones(processesPath, d.tombstoneRetention); err != nil {
+		log.Error("sweep-tombstones-failed", err)
+	}
+
 	processID := d.processIDGen.Generate()
 
 	processPath := filepath.Join(processesPath, processID)
@@ -77,19 +97,35 @@ func (d *ExecRunner) Run(log lager.Logger, spec *runrunc.PreparedSpec, bundlePat
 	defer logr.Close()
 	defer syncr.Close()
 
-	process := newProcess(processID, processPath, filepath.Join(processPath, "pidfile"), d.pidGetter)
+	process := newProcess(processID, processPath, filepath.Join(processPath, "pidfile"), d.pidGetter, d.tombstoneRetention, d.exits)
 	process.mkfifos()
 	if err != nil {
 		return nil, err
 	}
 
-	var cmd *exec.Cmd
+	outputLogged := d.outputLogLimits.Enabled()
+	if err := writeProcessMeta(processPath, spec, tty != nil, outputLogged); err != nil {
+		return nil, err
+	}
+
+	var args []string
 	if tty != nil {
-		cmd = exec.Command(d.dadooPath, "-tty", "exec", d.runcPath, processPath, handle)
-	} else {
-		cmd = exec.Command(d.dadooPath, "exec", d.runcPath, processPath, handle)
+		args = append(args, "-tty")
+	}
+
+	if outputLogged {
+		args = append(args,
+			"-output-log-segment-bytes", strconv.FormatInt(d.outputLogLimits.SegmentBytes, 10),
+			"-output-log-segments", strconv.Itoa(d.outputLogLimits.Segments),
+		)
+	}
+
+	for _, arg := range d.runcArgs {
+		args = append(args, "-runtime-arg", arg)
 	}
 
+	cmd := exec.Command(d.dadooPath, append(args, "exec", d.runcPath, processPath, handle)...)
+
 	dadooLogFilePath := filepath.Join(bundlePath, fmt.Sprintf("dadoo.%s.log", processID))
 	dadooLogFile, err := os.Create(dadooLogFilePath)
 	if err != nil {
@@ -156,38 +192,56 @@ func (d *ExecRunner) Run(log lager.Logger, spec *runrunc.PreparedSpec, bundlePat
 	return process, nil
 }
 
-func (d *ExecRunner) Attach(log lager.Logger, processID string, io garden.ProcessIO, processesPath string) (garden.Process, error) {
+// Attach attaches to a process. If replay is set, the output which the process
+// has logged since those offsets is streamed before its live output, so that
+// clients can catch up on what they missed while they were detached.
+func (d *ExecRunner) Attach(log lager.Logger, processID string, replay *gardener.OutputOffsets, io garden.ProcessIO, processesPath string) (garden.Process, error) {
 	processPath := filepath.Join(processesPath, processID)
-	process := newProcess(processID, processPath, filepath.Join(processPath, "pidfile"), d.pidGetter)
-	if err := process.attach(io); err != nil {
-		return nil, err
+
+	t, buried := readTombstone(processPath)
+	if buried && t.expired(d.tombstoneRetention) {
+		os.RemoveAll(processPath)
+		return nil, fmt.Errorf("process %s exited at %s and its exit status is no longer kept", processID, t.FinishedAt)
 	}
 
-	return process, nil
-}
+	if replay != nil {
+		if meta, ok := readProcessMeta(processPath); !ok || !meta.OutputLogged {
+			return nil, fmt.Errorf("cannot replay the output of process %s, since it is not logged", processID)
+		}
+	}
 
-type osSignal garden.Signal
+	if buried {
+		return newExitedProcess(processID, processPath, t.ExitStatus, replay, io), nil
+	}
 
-func (s osSignal) OsSignal() syscall.Signal {
-	switch garden.Signal(s) {
-	case garden.SignalTerminate:
-		return syscall.SIGTERM
-	default:
-		return syscall.SIGKILL
+	// containers which run no more processes would otherwise keep their
+	// tombstones forever
+	if err := sweepTombstones(processesPath, d.tombstoneRetention); err != nil {
+		log.Error("sweep-tombstones-failed", err)
+	}
+
+	process := newProcess(processID, processPath, filepath.Join(processPath, "pidfile"), d.pidGetter, d.tombstoneRetention, d.exits)
+	if err := process.attach(io, replay); err != nil {
+		return nil, err
 	}
+
+	return process, nil
 }
 
 type process struct {
 	id                                           string
+	dir                                          string
 	stdin, stdout, stderr, exit, winsz, exitcode string
 	ioWg                                         *sync.WaitGroup
 	winszCh                                      chan garden.WindowSize
-	cleanup                                      func() error
+	cleanup                                      func(exitStatus int, usage *gardener.ResourceUsage) error
+
+	exits *sharedExits
 
 	*signaller
 }
 
-func newProcess(id, dir string, pidFilePath string, pidGetter PidGetter) *process {
+func newProcess(id, dir string, pidFilePath string, pidGetter PidGetter, tombstoneRetention time.Duration, exits *sharedExits) *process {
 	stdin, stdout, stderr, winsz, exit, exitcode := filepath.Join(dir, "stdin"),
 		filepath.Join(dir, "stdout"),
 		filepath.Join(dir, "stderr"),
@@ -197,6 +251,7 @@ func newProcess(id, dir string, pidFilePath string, pidGetter PidGetter) *proces
 
 	return &process{
 		id:       id,
+		dir:      dir,
 		stdin:    stdin,
 		stdout:   stdout,
 		stderr:   stderr,
@@ -205,7 +260,16 @@ func newProcess(id, dir string, pidFilePath string, pidGetter PidGetter) *proces
 		exitcode: exitcode,
 		ioWg:     &sync.WaitGroup{},
 		winszCh:  make(chan garden.WindowSize, 5),
-		cleanup: func() error {
+		exits:    exits,
+		cleanup: func(exitStatus int, usage *gardener.ResourceUsage) error {
+			if err := buryProcess(dir, exitStatus, usage); err != nil {
+				return err
+			}
+
+			if tombstoneRetention > 0 {
+				return nil
+			}
+
 			return os.RemoveAll(dir)
 		},
 		signaller: &signaller{
@@ -257,7 +321,7 @@ func openNonBlocking(fileName string) (*os.File, error) {
 	return file, nil
 }
 
-func (p process) streamData(pio garden.ProcessIO, stdin, stdout, stderr *os.File) {
+func (p process) streamData(pio garden.ProcessIO, stdin *os.File, stdout, stderr io.ReadCloser) {
 	if pio.Stdin != nil {
 		go func() {
 			io.Copy(stdin, pio.Stdin)
@@ -284,31 +348,238 @@ func (p process) streamData(pio garden.ProcessIO, stdin, stdout, stderr *os.File
 	}
 }
 
-func (p process) attach(pio garden.ProcessIO) error {
+func (p process) attach(pio garden.ProcessIO, replay *gardener.OutputOffsets) error {
+	// clients which only watch for the exit, as the server does after a
+	// restart, leave the fifos to clients which stream
+	if replay == nil && pio.Stdin == nil && pio.Stdout == nil && pio.Stderr == nil {
+		return nil
+	}
+
 	stdin, stdout, stderr, err := p.openPipes(pio)
 	if err != nil {
 		return err
 	}
 
-	p.streamData(pio, stdin, stdout, stderr)
+	if replay == nil {
+		p.streamData(pio, stdin, stdout, stderr)
+		return nil
+	}
+
+	replayedStdout, err := p.replaying("stdout", stdout, replay.Stdout)
+	if err != nil {
+		return err
+	}
+
+	replayedStderr, err := p.replaying("stderr", stderr, replay.Stderr)
+	if err != nil {
+		return err
+	}
+
+	p.streamData(pio, stdin, replayedStdout, replayedStderr)
+	return nil
+}
+
+// replaying returns a reader of the stream's logged output from the offset,
+// followed by its live output from the fifo. Output still buffered in the
+// fifo is discarded first, since it has already been logged, and the log is
+// replayed up to where it ended after that. Output which dadoo is writing at
+// that moment may be both replayed and read live.
+func (p process) replaying(stream string, fifo *os.File, offset int64) (io.ReadCloser, error) {
+	if err := drain(fifo); err != nil {
+		return nil, err
+	}
+
+	log := OutputLog{Dir: p.dir, Stream: stream}
+	end, err := log.End()
+	if err != nil {
+		return nil, err
+	}
+
+	logged := log.Reader(offset, end)
+	return replayReader{
+		Reader:  io.MultiReader(logged, fifo),
+		closers: []io.Closer{logged, fifo},
+	}, nil
+}
+
+type replayReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+func (r replayReader) Close() error {
+	for _, c := range r.closers {
+		c.Close()
+	}
 
 	return nil
 }
 
+// drain discards whatever is buffered in a fifo, without waiting for more
+func drain(fifo *os.File) error {
+	fd := int(fifo.Fd())
+	if err := syscall.SetNonblock(fd, true); err != nil {
+		return err
+	}
+	defer syscall.SetNonblock(fd, false)
+
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := syscall.Read(fd, buf)
+		if err == syscall.EAGAIN {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+	}
+}
+
 func (p process) Wait() (int, error) {
+	exit := p.exits.join(p.dir)
+	defer p.exits.leave(p.dir)
+
+	// another waiter may already have buried the process
+	if t, ok := readTombstone(p.dir); ok {
+		return t.ExitStatus, nil
+	}
+
+	if err := p.awaitExitPipe(); err != nil {
+		return 1, err
+	}
+
+	p.ioWg.Wait()
+
+	// only one of any concurrent waiters cleans up, and they all see its result
+	return exit.finish(p.finish)
+}
+
+func (p process) finish() (int, error) {
+	if t, ok := readTombstone(p.dir); ok {
+		return t.ExitStatus, nil
+	}
+
+	code, err := p.readExitCode()
+	if err != nil {
+		return 1, err
+	}
+
+	if err := p.cleanup(code, p.readUsage()); err != nil {
+		return 1, err
+	}
+
+	return code, nil
+}
+
+// sharedExits tracks the processes which are being waited for, so that
+// concurrent waiters for the same process share a single clean up
+type sharedExits struct {
+	mu    sync.Mutex
+	exits map[string]*sharedExit
+}
+
+type sharedExit struct {
+	waiters    int
+	once       sync.Once
+	exitStatus int
+	err        error
+}
+
+func newSharedExits() *sharedExits {
+	return &sharedExits{exits: make(map[string]*sharedExit)}
+}
+
+func (s *sharedExits) join(processPath string) *sharedExit {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exit, ok := s.exits[processPath]
+	if !ok {
+		exit = &sharedExit{}
+		s.exits[processPath] = exit
+	}
+
+	exit.waiters++
+	return exit
+}
+
+func (s *sharedExits) leave(processPath string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	exit := s.exits[processPath]
+	exit.waiters--
+	if exit.waiters == 0 {
+		delete(s.exits, processPath)
+	}
+}
+
+func (e *sharedExit) finish(fn func() (int, error)) (int, error) {
+	e.once.Do(func() {
+		e.exitStatus, e.err = fn()
+	})
+
+	return e.exitStatus, e.err
+}
+
+// WaitForExit returns the exit status of the process, and the resources it
+// used if dadoo recorded them, once it has exited. It neither waits for the
+// output to be streamed nor cleans up the process, so it can be used to watch
+// processes which clients will also wait for.
+func (p process) WaitForExit() (int, *gardener.ResourceUsage, error) {
+	if t, ok := readTombstone(p.dir); ok {
+		return t.ExitStatus, t.Usage, nil
+	}
+
+	if err := p.awaitExitPipe(); err != nil {
+		return 1, nil, err
+	}
+
+	code, err := p.readExitCode()
+	if err != nil {
+		return 1, nil, err
+	}
+
+	return code, p.readUsage(), nil
+}
+
+// readUsage returns the resources used by the process, or nil if they were
+// not recorded, as older versions of dadoo only wrote the exitcode file
+func (p process) readUsage() *gardener.ResourceUsage {
+	if exit, ok := readExitFile(p.dir); ok {
+		return &exit.Usage
+	}
+
+	if t, ok := readTombstone(p.dir); ok {
+		return t.Usage
+	}
+
+	return nil
+}
+
+// awaitExitPipe returns once dadoo has exited and so closed the exit pipe
+func (p process) awaitExitPipe() error {
 	// open non-blocking incase exit pipe is already closed
 	exit, err := openNonBlocking(p.exit)
 	if err != nil {
-		return 1, err
+		return err
 	}
 	defer exit.Close()
 
 	buf := make([]byte, 1)
 	exit.Read(buf)
+	return nil
+}
 
-	p.ioWg.Wait()
-
+func (p process) readExitCode() (int, error) {
 	if _, err := os.Stat(p.exitcode); os.IsNotExist(err) {
+		if t, ok := readTombstone(p.dir); ok {
+			return t.ExitStatus, nil
+		}
+
 		return 1, fmt.Errorf("could not find the exitcode file for the process: %s", err.Error())
 	}
 
@@ -326,10 +597,6 @@ func (p process) Wait() (int, error) {
 		return 1, fmt.Errorf("failed to parse exit code: %s", err.Error())
 	}
 
-	if err := p.cleanup(); err != nil {
-		return 1, err
-	}
-
 	return code, nil
 }
 
@@ -386,6 +653,11 @@ type signaller struct {
 }
 
 func (s *signaller) Signal(signal garden.Signal) error {
+	sig, err := osSignal(signal).OsSignal()
+	if err != nil {
+		return err
+	}
+
 	pid, err := s.pidGetter.Pid(s.pidFilePath)
 	if err != nil {
 		return errors.New(fmt.Sprintf("fetching-pid: %s", err))
@@ -396,7 +668,7 @@ func (s *signaller) Signal(signal garden.Signal) error {
 		return errors.New(fmt.Sprintf("finding-process: %s", err))
 	}
 
-	return process.Signal(osSignal(signal).OsSignal())
+	return process.Signal(sig)
 }
 
 func copyDadooLogsToGuardianLogger(dadooLogFilePath string, logger lager.Logger) error {
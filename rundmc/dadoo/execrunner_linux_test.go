@@ -12,9 +12,12 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
 	"code.cloudfoundry.org/guardian/rundmc/dadoo"
 	dadoofakes "code.cloudfoundry.org/guardian/rundmc/dadoo/dadoofakes"
 	"code.cloudfoundry.org/guardian/rundmc/runrunc"
@@ -23,6 +26,7 @@ import (
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/cloudfoundry/gunk/command_runner/fake_command_runner"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/onsi/gomega/gexec"
@@ -45,6 +49,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 		dadooPanicsBeforeReportingRuncExitCode bool
 		dadooWritesLogs                        string
 		dadooWritesExitCode                    []byte
+		dadooWritesExitFile                    []byte
 		log                                    *lagertest.TestLogger
 		receiveWinSize                         func(*os.File)
 		closeExitPipeCh                        chan struct{}
@@ -70,13 +75,14 @@ var _ = Describe("Dadoo ExecRunner", func() {
 		processPath = filepath.Join(bundlePath, "the-process")
 		pidPath = filepath.Join(processPath, "0.pid")
 
-		runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner)
+		runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", nil, fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, 0, dadoo.OutputLogLimits{})
 		log = lagertest.NewTestLogger("test")
 
 		runcReturns = 0
 		dadooReturns = nil
 		dadooPanicsBeforeReportingRuncExitCode = false
 		dadooWritesExitCode = []byte("0")
+		dadooWritesExitFile = nil
 		dadooWritesLogs = `time="2016-03-02T13:56:38Z" level=warning msg="signal: potato"
 				time="2016-03-02T13:56:38Z" level=error msg="fork/exec POTATO: no such file or directory"
 				time="2016-03-02T13:56:38Z" level=fatal msg="Container start failed: [10] System error: fork/exec POTATO: no such file or directory"`
@@ -85,6 +91,8 @@ var _ = Describe("Dadoo ExecRunner", func() {
 		dadooFlags.Bool("tty", false, "")
 		dadooFlags.Int("rows", 0, "")
 		dadooFlags.Int("cols", 0, "")
+		outputLogSegmentBytes := dadooFlags.Int64("output-log-segment-bytes", 0, "")
+		dadooFlags.Int("output-log-segments", 0, "")
 
 		receiveWinSize = func(_ *os.File) {}
 
@@ -117,7 +125,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 			fmt.Fprintln(cmd.Stderr, "dadoo stderr")
 
 			// dadoo would not error - simulate dadoo operation
-			go func(cmd *exec.Cmd, dadooPanicsBeforeReportingRuncExitCode bool, exitCode []byte, logs []byte, closeExitPipeCh chan struct{}, recvWinSz func(*os.File), stderrContents string) {
+			go func(cmd *exec.Cmd, dadooPanicsBeforeReportingRuncExitCode bool, exitCode, exitFile []byte, logs []byte, closeExitPipeCh chan struct{}, recvWinSz func(*os.File), stderrContents string) {
 				defer GinkgoRecover()
 
 				// parse flags to get bundle dir argument so we can open stdin/out/err pipes
@@ -133,6 +141,11 @@ var _ = Describe("Dadoo ExecRunner", func() {
 				// handle window size
 				go recvWinSz(winsz)
 
+				// log output, if asked to, as well as writing it to the pipes
+				if *outputLogSegmentBytes > 0 {
+					Expect(ioutil.WriteFile(filepath.Join(processDir, "stdout.0.log"), []byte("hello stdout"), 0600)).To(Succeed())
+				}
+
 				// write stderr
 				_, err = se.WriteString(stderrContents)
 				Expect(err).NotTo(HaveOccurred())
@@ -149,6 +162,10 @@ var _ = Describe("Dadoo ExecRunner", func() {
 					Expect(err).NotTo(HaveOccurred())
 				}
 				fd3.Close()
+				// write how the actual process exited to $procesdir/exit.json
+				if exitFile != nil {
+					Expect(ioutil.WriteFile(filepath.Join(processDir, "exit.json"), exitFile, 0600)).To(Succeed())
+				}
 				// write exit code of actual process to $procesdir/exitcode file
 				if exitCode != nil {
 					Expect(ioutil.WriteFile(filepath.Join(processDir, "exitcode"), []byte(exitCode), 0600)).To(Succeed())
@@ -168,7 +185,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 				// close streams
 				Expect(so.Close()).To(Succeed())
 				Expect(se.Close()).To(Succeed())
-			}(cmd, dadooPanicsBeforeReportingRuncExitCode, dadooWritesExitCode, []byte(dadooWritesLogs), closeExitPipeCh, receiveWinSize, stderrContents)
+			}(cmd, dadooPanicsBeforeReportingRuncExitCode, dadooWritesExitCode, dadooWritesExitFile, []byte(dadooWritesLogs), closeExitPipeCh, receiveWinSize, stderrContents)
 
 			return nil
 		})
@@ -213,6 +230,22 @@ var _ = Describe("Dadoo ExecRunner", func() {
 			})
 		})
 
+		Context("when the runtime has global arguments", func() {
+			It("passes each of them to dadoo", func() {
+				runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", []string{"--root", "/run/other"}, fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, 0, dadoo.OutputLogLimits{})
+				runner.Run(log, &runrunc.PreparedSpec{}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+
+				Expect(fakeCommandRunner.StartedCommands()[0].Args).To(
+					Equal([]string{
+						"path-to-dadoo",
+						"-runtime-arg", "--root",
+						"-runtime-arg", "/run/other",
+						"exec", "path-to-runc", filepath.Join(processPath, processID), "some-handle",
+					}),
+				)
+			})
+		})
+
 		It("does not block on dadoo returning before returning", func() {
 			waitBlocks := make(chan struct{})
 			defer close(waitBlocks)
@@ -242,6 +275,20 @@ var _ = Describe("Dadoo ExecRunner", func() {
 			Expect(string(receivedStdinContents)).NotTo(ContainSubstring(`HostUID`))
 		})
 
+		It("records how the process was run so that it can be listed", func() {
+			_, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}, Username: "alice"}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+			Expect(err).NotTo(HaveOccurred())
+
+			processes, err := dadoo.ProcessLister{}.List(processPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processes).To(HaveLen(1))
+			Expect(processes[0].ID).To(Equal(processID))
+			Expect(processes[0].Args).To(Equal([]string{"Banana", "rama"}))
+			Expect(processes[0].User).To(Equal("alice"))
+			Expect(processes[0].TTY).To(BeFalse())
+			Expect(processes[0].StartedAt).NotTo(BeZero())
+		})
+
 		It("cleans up the processes dir after Wait returns", func() {
 			process, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
 			Expect(err).NotTo(HaveOccurred())
@@ -254,6 +301,207 @@ var _ = Describe("Dadoo ExecRunner", func() {
 			Expect(filepath.Join(processPath, processID)).NotTo(BeAnExistingFile())
 		})
 
+		Context("when output logging is enabled", func() {
+			BeforeEach(func() {
+				runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", nil, fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, time.Minute, dadoo.OutputLogLimits{SegmentBytes: 1024, Segments: 3})
+			})
+
+			It("tells dadoo to log the output", func() {
+				runner.Run(log, &runrunc.PreparedSpec{}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+
+				Expect(fakeCommandRunner.StartedCommands()[0].Args).To(
+					Equal([]string{
+						"path-to-dadoo",
+						"-output-log-segment-bytes", "1024",
+						"-output-log-segments", "3",
+						"exec", "path-to-runc", filepath.Join(processPath, processID), "some-handle",
+					}),
+				)
+			})
+
+			It("streams the live output from the pipes", func() {
+				stdout := gbytes.NewBuffer()
+				process, err := runner.Run(log, &runrunc.PreparedSpec{}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{Stdout: stdout})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(process.Wait()).To(Equal(0))
+				Expect(string(stdout.Contents())).To(Equal("hello stdout"))
+			})
+
+			It("keeps the logs in the tombstone, for later attachers to replay", func() {
+				process, err := runner.Run(log, &runrunc.PreparedSpec{}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(process.Wait()).To(Equal(0))
+
+				Expect(filepath.Join(processPath, processID, "stdout.0.log")).To(BeAnExistingFile())
+
+				stdout := gbytes.NewBuffer()
+				attached, err := runner.Attach(log, processID, &gardener.OutputOffsets{Stdout: 6}, garden.ProcessIO{Stdout: stdout}, processPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(attached.Wait()).To(Equal(0))
+				Expect(string(stdout.Contents())).To(Equal("stdout"))
+			})
+		})
+
+		Context("when exit statuses are retained", func() {
+			BeforeEach(func() {
+				runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", nil, fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, time.Minute, dadoo.OutputLogLimits{})
+				dadooWritesExitCode = []byte("42")
+			})
+
+			It("leaves a tombstone after Wait returns", func() {
+				process, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(process.Wait()).To(Equal(42))
+
+				files, err := ioutil.ReadDir(filepath.Join(processPath, processID))
+				Expect(err).NotTo(HaveOccurred())
+
+				var names []string
+				for _, file := range files {
+					names = append(names, file.Name())
+				}
+				Expect(names).To(ConsistOf("meta.json", "tombstone.json"))
+			})
+
+			It("returns the exit code to every later Wait", func() {
+				process, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(process.Wait()).To(Equal(42))
+				Expect(process.Wait()).To(Equal(42))
+			})
+
+			It("returns the exit code to later attachers", func() {
+				process, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(process.Wait()).To(Equal(42))
+
+				attached, err := runner.Attach(log, processID, nil, garden.ProcessIO{}, processPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(attached.ID()).To(Equal(processID))
+				Expect(attached.Wait()).To(Equal(42))
+				Expect(attached.Signal(garden.SignalKill)).To(MatchError(ContainSubstring("already exited")))
+			})
+
+			Context("and the tombstone has expired", func() {
+				var buried string
+
+				BeforeEach(func() {
+					buried = filepath.Join(processPath, "buried-process")
+					Expect(os.MkdirAll(buried, 0700)).To(Succeed())
+					Expect(ioutil.WriteFile(filepath.Join(buried, "tombstone.json"), []byte(`{"exit_status":3,"finished_at":"2017-01-02T03:04:05Z"}`), 0600)).To(Succeed())
+				})
+
+				It("fails to attach and removes the process", func() {
+					_, err := runner.Attach(log, "buried-process", nil, garden.ProcessIO{}, processPath)
+					Expect(err).To(MatchError(ContainSubstring("no longer kept")))
+					Expect(buried).NotTo(BeAnExistingFile())
+				})
+
+				It("is swept away when another process is run", func() {
+					_, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(buried).NotTo(BeAnExistingFile())
+				})
+
+				It("is swept away when another process is attached to", func() {
+					_, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+
+					buriedLater := filepath.Join(processPath, "buried-later")
+					Expect(os.MkdirAll(buriedLater, 0700)).To(Succeed())
+					Expect(ioutil.WriteFile(filepath.Join(buriedLater, "tombstone.json"), []byte(`{"exit_status":3,"finished_at":"2017-01-02T03:04:05Z"}`), 0600)).To(Succeed())
+
+					_, err = runner.Attach(log, processID, nil, garden.ProcessIO{}, processPath)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(buriedLater).NotTo(BeAnExistingFile())
+				})
+			})
+		})
+
+		Context("when dadoo records the resources used by the process", func() {
+			BeforeEach(func() {
+				dadooWritesExitCode = []byte("42")
+				dadooWritesExitFile = []byte(`{"exit_status":42,"usage":{"max_rss_bytes":2048,"user_cpu_ns":1000,"system_cpu_ns":2000,"wall_clock_ns":3000}}`)
+			})
+
+			expectedUsage := gardener.ResourceUsage{
+				MaxRSSBytes: 2048,
+				UserCPU:     1000,
+				SystemCPU:   2000,
+				WallClock:   3000,
+			}
+
+			It("reports them when the process exits", func() {
+				process, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+				Expect(err).NotTo(HaveOccurred())
+
+				exitStatus, usage, err := process.(gardener.ExitWatcher).WaitForExit()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(exitStatus).To(Equal(42))
+				Expect(usage).To(Equal(&expectedUsage))
+			})
+
+			It("buries them once however many clients wait", func() {
+				runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", nil, fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, time.Minute, dadoo.OutputLogLimits{})
+				process, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+				Expect(err).NotTo(HaveOccurred())
+
+				// a second runner, as after a restart, does not share waiters with the first
+				otherRunner := dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", nil, fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, time.Minute, dadoo.OutputLogLimits{})
+				attached, err := otherRunner.Attach(log, processID, nil, garden.ProcessIO{}, processPath)
+				Expect(err).NotTo(HaveOccurred())
+
+				var wg sync.WaitGroup
+				for _, p := range []garden.Process{process, attached} {
+					wg.Add(1)
+					go func(p garden.Process) {
+						defer GinkgoRecover()
+						defer wg.Done()
+						Expect(p.Wait()).To(Equal(42))
+					}(p)
+				}
+				wg.Wait()
+
+				processes, err := dadoo.ProcessLister{}.List(processPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(processes).To(HaveLen(1))
+				Expect(processes[0].Usage).To(Equal(&expectedUsage))
+			})
+
+			It("keeps them in the tombstone so that they can be listed", func() {
+				runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", nil, fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, time.Minute, dadoo.OutputLogLimits{})
+				process, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(process.Wait()).To(Equal(42))
+				Expect(process.Wait()).To(Equal(42))
+
+				attached, err := runner.Attach(log, processID, nil, garden.ProcessIO{}, processPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(attached.Wait()).To(Equal(42))
+
+				processes, err := dadoo.ProcessLister{}.List(processPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(processes).To(HaveLen(1))
+				Expect(processes[0].Usage).To(Equal(&expectedUsage))
+			})
+		})
+
+		It("reports no resource usage when dadoo did not record it", func() {
+			process, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, usage, err := process.(gardener.ExitWatcher).WaitForExit()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage).To(BeNil())
+		})
+
 		Context("when spawning dadoo fails", func() {
 			It("returns a nice error", func() {
 				dadooReturns = errors.New("boom")
@@ -471,6 +719,38 @@ var _ = Describe("Dadoo ExecRunner", func() {
 					})
 				})
 
+				DescribeTable("sending other signals",
+					func(signal garden.Signal, name string, exitCode int) {
+						cmd := exec.Command("sh", "-c", fmt.Sprintf("trap 'exit %d' %s; while true; do echo trapping; sleep 1; done", exitCode, name))
+						sess, err := gexec.Start(cmd, GinkgoWriter, GinkgoWriter)
+						Expect(err).NotTo(HaveOccurred())
+						Eventually(sess).Should(gbytes.Say("trapping"))
+
+						process, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"echo", ""}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+						Expect(err).NotTo(HaveOccurred())
+
+						fakePidGetter.PidReturns(cmd.Process.Pid, nil)
+						Expect(process.Signal(signal)).To(Succeed())
+
+						Eventually(sess, "5s").Should(gexec.Exit(exitCode))
+					},
+					Entry("HUP", gardener.SignalHangup, "HUP", 42),
+					Entry("INT", gardener.SignalInterrupt, "INT", 43),
+					Entry("QUIT", gardener.SignalQuit, "QUIT", 44),
+					Entry("USR1", gardener.SignalUser1, "USR1", 45),
+					Entry("USR2", gardener.SignalUser2, "USR2", 46),
+				)
+
+				Context("when the signal is not supported", func() {
+					It("rejects it rather than killing the process", func() {
+						process, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"echo", ""}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(process.Signal(garden.Signal(99))).To(MatchError(dadoo.UnsupportedSignalError{Signal: garden.Signal(99)}))
+						Expect(fakePidGetter.PidCallCount()).To(Equal(0))
+					})
+				})
+
 				Context("when os.Signal returns an error", func() {
 					BeforeEach(func() {
 						fakePidGetter.PidReturns(0, nil)
@@ -507,6 +787,37 @@ var _ = Describe("Dadoo ExecRunner", func() {
 					})
 				})
 
+				Context("when several callers wait at once", func() {
+					BeforeEach(func() {
+						closeExitPipeCh = make(chan struct{})
+						dadooWritesExitCode = []byte("42")
+					})
+
+					It("returns the exit code to all of them, even though the process is not buried", func() {
+						process, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+						Expect(err).NotTo(HaveOccurred())
+
+						results := make(chan int, 3)
+						errs := make(chan error, 3)
+						for i := 0; i < 3; i++ {
+							go func() {
+								code, err := process.Wait()
+								results <- code
+								errs <- err
+							}()
+						}
+
+						Consistently(results).ShouldNot(Receive())
+						close(closeExitPipeCh)
+
+						for i := 0; i < 3; i++ {
+							Eventually(errs).Should(Receive(BeNil()))
+							Eventually(results).Should(Receive(Equal(42)))
+						}
+						Expect(filepath.Join(processPath, processID)).NotTo(BeAnExistingFile())
+					})
+				})
+
 				It("returns the exit code of the dadoo process", func() {
 					dadooWritesExitCode = []byte("42")
 
@@ -552,6 +863,55 @@ var _ = Describe("Dadoo ExecRunner", func() {
 					})
 				})
 			})
+
+			Describe("WaitForExit", func() {
+				It("returns the exit code without cleaning up the process", func() {
+					dadooWritesExitCode = []byte("42")
+
+					process, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(process.(gardener.ExitWatcher).WaitForExit()).To(Equal(42))
+					Expect(filepath.Join(processPath, processID)).To(BeADirectory())
+
+					Expect(process.Wait()).To(Equal(42))
+					Expect(filepath.Join(processPath, processID)).NotTo(BeAnExistingFile())
+				})
+
+				It("returns the resources used by the process, if dadoo recorded them", func() {
+					dadooWritesExitCode = []byte("42")
+					dadooWritesExitFile = []byte(`{"exit_status":42,"usage":{"max_rss_bytes":2048}}`)
+
+					process, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+					Expect(err).NotTo(HaveOccurred())
+
+					exitStatus, usage, err := process.(gardener.ExitWatcher).WaitForExit()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(exitStatus).To(Equal(42))
+					Expect(usage).To(Equal(&gardener.ResourceUsage{MaxRSSBytes: 2048}))
+				})
+
+				Context("when the process does not exit immediately", func() {
+					BeforeEach(func() {
+						closeExitPipeCh = make(chan struct{})
+					})
+
+					It("does not return until the exit pipe is closed", func() {
+						process, err := runner.Run(log, &runrunc.PreparedSpec{Process: specs.Process{Args: []string{"Banana", "rama"}}}, bundlePath, processPath, "some-handle", nil, garden.ProcessIO{})
+						Expect(err).NotTo(HaveOccurred())
+
+						done := make(chan struct{})
+						go func() {
+							process.(gardener.ExitWatcher).WaitForExit()
+							close(done)
+						}()
+
+						Consistently(done).ShouldNot(BeClosed())
+						close(closeExitPipeCh)
+						Eventually(done).Should(BeClosed())
+					})
+				})
+			})
 		})
 
 		It("can get stdout/err from the spawned process via named pipes", func() {
@@ -621,7 +981,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 		Context("when dadoo has already exited", func() {
 			It("returns the process", func() {
 				out := gbytes.NewBuffer()
-				process, err := runner.Attach(log, "some-process-id", garden.ProcessIO{
+				process, err := runner.Attach(log, "some-process-id", nil, garden.ProcessIO{
 					Stdout: out,
 				}, processPath)
 				Expect(err).NotTo(HaveOccurred())
@@ -629,6 +989,75 @@ var _ = Describe("Dadoo ExecRunner", func() {
 			})
 		})
 
+		It("leaves the fifos to other clients when it streams nothing", func() {
+			_, err := runner.Attach(log, "some-process-id", nil, garden.ProcessIO{}, processPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			// opening a fifo to write without blocking fails while it has no readers
+			_, err = os.OpenFile(filepath.Join(processPath, "some-process-id", "stdout"), os.O_WRONLY|syscall.O_NONBLOCK, 0)
+			Expect(err).To(MatchError(ContainSubstring("no such device or address")))
+		})
+
+		It("signals the process using its pidfile, as after a restart", func() {
+			process, err := runner.Attach(log, "some-process-id", nil, garden.ProcessIO{}, processPath)
+			Expect(err).NotTo(HaveOccurred())
+
+			fakePidGetter.PidReturns(0, errors.New("no pid"))
+			Expect(process.Signal(gardener.SignalHangup)).To(MatchError("fetching-pid: no pid"))
+			Expect(fakePidGetter.PidArgsForCall(0)).To(Equal(filepath.Join(processPath, "some-process-id", "pidfile")))
+		})
+
+		Context("when the output is to be replayed", func() {
+			It("fails if the process's output is not logged", func() {
+				_, err := runner.Attach(log, "some-process-id", &gardener.OutputOffsets{Stdout: 6}, garden.ProcessIO{}, processPath)
+				Expect(err).To(MatchError(ContainSubstring("not logged")))
+			})
+
+			Context("and the process's output is logged", func() {
+				BeforeEach(func() {
+					dir := filepath.Join(processPath, "some-process-id")
+					Expect(ioutil.WriteFile(filepath.Join(dir, "meta.json"), []byte(`{"output_logged":true}`), 0600)).To(Succeed())
+					Expect(ioutil.WriteFile(filepath.Join(dir, "stdout.0.log"), []byte("hello stdout"), 0600)).To(Succeed())
+					Expect(ioutil.WriteFile(filepath.Join(dir, "stderr.0.log"), []byte("hello stderr"), 0600)).To(Succeed())
+				})
+
+				It("replays stdout and stderr from their offsets", func() {
+					stdout, stderr := gbytes.NewBuffer(), gbytes.NewBuffer()
+					_, err := runner.Attach(log, "some-process-id", &gardener.OutputOffsets{Stdout: 0, Stderr: 6}, garden.ProcessIO{Stdout: stdout, Stderr: stderr}, processPath)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(func() string { return string(stdout.Contents()) }).Should(Equal("hello stdout"))
+					Eventually(func() string { return string(stderr.Contents()) }).Should(Equal("stderr"))
+				})
+
+				Context("and the process has been buried", func() {
+					BeforeEach(func() {
+						Expect(ioutil.WriteFile(filepath.Join(processPath, "some-process-id", "tombstone.json"), []byte(`{"exit_status":3,"finished_at":"`+time.Now().Format(time.RFC3339)+`"}`), 0600)).To(Succeed())
+						runner = dadoo.NewExecRunner("path-to-dadoo", "path-to-runc", nil, fakeProcessIDGenerator, fakePidGetter, fakeCommandRunner, time.Minute, dadoo.OutputLogLimits{})
+					})
+
+					It("replays the output before returning the exit status", func() {
+						stdout, stderr := gbytes.NewBuffer(), gbytes.NewBuffer()
+						process, err := runner.Attach(log, "some-process-id", &gardener.OutputOffsets{Stdout: 6, Stderr: 0}, garden.ProcessIO{Stdout: stdout, Stderr: stderr}, processPath)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(process.Wait()).To(Equal(3))
+						Expect(string(stdout.Contents())).To(Equal("stdout"))
+						Expect(string(stderr.Contents())).To(Equal("hello stderr"))
+					})
+
+					It("streams nothing when no replay is asked for", func() {
+						stdout := gbytes.NewBuffer()
+						process, err := runner.Attach(log, "some-process-id", nil, garden.ProcessIO{Stdout: stdout}, processPath)
+						Expect(err).NotTo(HaveOccurred())
+
+						Expect(process.Wait()).To(Equal(3))
+						Expect(stdout.Contents()).To(BeEmpty())
+					})
+				})
+			})
+		})
+
 		Context("when dadoo is running", func() {
 
 			var stdin, stdout, stderr, exit *os.File
@@ -676,7 +1105,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 					outBuf = gbytes.NewBuffer()
 					errBuf = gbytes.NewBuffer()
 
-					_, err := runner.Attach(log, "some-process-id", garden.ProcessIO{
+					_, err := runner.Attach(log, "some-process-id", nil, garden.ProcessIO{
 						Stdout: outBuf,
 						Stderr: errBuf,
 					}, processPath)
@@ -712,7 +1141,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 				})
 
 				It("reports the correct pid", func() {
-					process, err := runner.Attach(log, "some-process-id", garden.ProcessIO{}, processPath)
+					process, err := runner.Attach(log, "some-process-id", nil, garden.ProcessIO{}, processPath)
 					Expect(err).NotTo(HaveOccurred())
 
 					Expect(process.ID()).To(Equal("some-process-id"))
@@ -720,7 +1149,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 
 				It("reattaches to the stdout output", func() {
 					outBuf := gbytes.NewBuffer()
-					_, err := runner.Attach(log, "some-process-id", garden.ProcessIO{
+					_, err := runner.Attach(log, "some-process-id", nil, garden.ProcessIO{
 						Stdout: outBuf,
 					}, processPath)
 					Expect(err).NotTo(HaveOccurred())
@@ -730,7 +1159,7 @@ var _ = Describe("Dadoo ExecRunner", func() {
 
 				It("reattaches to the stderr output", func() {
 					errBuf := gbytes.NewBuffer()
-					_, err := runner.Attach(log, "some-process-id", garden.ProcessIO{
+					_, err := runner.Attach(log, "some-process-id", nil, garden.ProcessIO{
 						Stderr: errBuf,
 					}, processPath)
 					Expect(err).NotTo(HaveOccurred())
@@ -738,8 +1167,32 @@ var _ = Describe("Dadoo ExecRunner", func() {
 					Eventually(errBuf).Should(gbytes.Say("tomato"))
 				})
 
+				Context("and the output is logged", func() {
+					BeforeEach(func() {
+						dir := filepath.Join(processPath, "some-process-id")
+						Expect(ioutil.WriteFile(filepath.Join(dir, "meta.json"), []byte(`{"output_logged":true}`), 0600)).To(Succeed())
+						Expect(ioutil.WriteFile(filepath.Join(dir, "stdout.0.log"), []byte("potato"), 0600)).To(Succeed())
+						Expect(ioutil.WriteFile(filepath.Join(dir, "stderr.0.log"), []byte("tomato"), 0600)).To(Succeed())
+					})
+
+					It("replays the logged output once and then streams the live output", func() {
+						outBuf := gbytes.NewBuffer()
+						_, err := runner.Attach(log, "some-process-id", &gardener.OutputOffsets{Stdout: 2}, garden.ProcessIO{
+							Stdout: outBuf,
+						}, processPath)
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(func() string { return string(outBuf.Contents()) }).Should(Equal("tato"))
+
+						_, err = stdout.WriteString(" salad")
+						Expect(err).NotTo(HaveOccurred())
+
+						Eventually(func() string { return string(outBuf.Contents()) }).Should(Equal("tato salad"))
+					})
+				})
+
 				It("reattaches to the stdin", func() {
-					_, err := runner.Attach(log, "some-process-id", garden.ProcessIO{
+					_, err := runner.Attach(log, "some-process-id", nil, garden.ProcessIO{
 						Stdin: strings.NewReader("hello stdin"),
 					}, processPath)
 					Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,54 @@
+package dadoo
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+)
+
+const exitFile = "exit.json"
+
+// ExitFile is written by dadoo to the process directory when the process
+// exits, just before the exitcode file
+type ExitFile struct {
+	ExitStatus int                    `json:"exit_status"`
+	Usage      gardener.ResourceUsage `json:"usage"`
+}
+
+// NewResourceUsage converts the rusage reported by wait4, in which the max RSS
+// is in kilobytes
+func NewResourceUsage(rusage syscall.Rusage, wallClock time.Duration) gardener.ResourceUsage {
+	return gardener.ResourceUsage{
+		MaxRSSBytes: rusage.Maxrss * 1024,
+		UserCPU:     time.Duration(rusage.Utime.Nano()),
+		SystemCPU:   time.Duration(rusage.Stime.Nano()),
+		WallClock:   wallClock,
+	}
+}
+
+func WriteExitFile(processPath string, exit ExitFile) error {
+	contents, err := json.Marshal(exit)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(processPath, exitFile), contents, 0600)
+}
+
+func readExitFile(processPath string) (ExitFile, bool) {
+	contents, err := ioutil.ReadFile(filepath.Join(processPath, exitFile))
+	if err != nil {
+		return ExitFile{}, false
+	}
+
+	var exit ExitFile
+	if err := json.Unmarshal(contents, &exit); err != nil {
+		return ExitFile{}, false
+	}
+
+	return exit, true
+}
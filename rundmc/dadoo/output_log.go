@@ -0,0 +1,285 @@
+package dadoo
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// OutputLogLimits caps the logs of process output which dadoo keeps in each
+// process directory. Logging is disabled when SegmentBytes is 0.
+type OutputLogLimits struct {
+	// Size at which a log is rotated into a new segment
+	SegmentBytes int64
+
+	// Number of segments to keep for each stream, older ones are deleted
+	Segments int
+}
+
+func (l OutputLogLimits) Enabled() bool {
+	return l.SegmentBytes > 0
+}
+
+// OutputLog is the log of one of a process's output streams. It is written as
+// segments named after the offset in the stream at which they start, e.g.
+// stdout.1048576.log, so that readers can find any offset which has not yet
+// been rotated away.
+type OutputLog struct {
+	Dir    string
+	Stream string
+}
+
+type segment struct {
+	start int64
+	path  string
+}
+
+func (l OutputLog) segmentPath(start int64) string {
+	return filepath.Join(l.Dir, fmt.Sprintf("%s.%d.log", l.Stream, start))
+}
+
+func (l OutputLog) segments() ([]segment, error) {
+	files, err := ioutil.ReadDir(l.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []segment
+	for _, file := range files {
+		name := file.Name()
+		if !strings.HasPrefix(name, l.Stream+".") || !strings.HasSuffix(name, ".log") {
+			continue
+		}
+
+		start, err := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(name, l.Stream+"."), ".log"), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		segments = append(segments, segment{start: start, path: filepath.Join(l.Dir, name)})
+	}
+
+	sort.Sort(byStart(segments))
+	return segments, nil
+}
+
+// End returns the offset just after the last byte which has been logged
+func (l OutputLog) End() (int64, error) {
+	segments, err := l.segments()
+	if err != nil || len(segments) == 0 {
+		return 0, err
+	}
+
+	last := segments[len(segments)-1]
+	info, err := os.Stat(last.path)
+	if err != nil {
+		return 0, err
+	}
+
+	return last.start + info.Size(), nil
+}
+
+type byStart []segment
+
+func (s byStart) Len() int           { return len(s) }
+func (s byStart) Less(i, j int) bool { return s[i].start < s[j].start }
+func (s byStart) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
+// NewWriter starts the log of a new process
+func (l OutputLog) NewWriter(limits OutputLogLimits) (io.WriteCloser, error) {
+	w := &outputLogWriter{log: l, limits: limits}
+	if err := w.open(0); err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+type outputLogWriter struct {
+	log    OutputLog
+	limits OutputLogLimits
+
+	file    *os.File
+	start   int64
+	written int64
+}
+
+func (w *outputLogWriter) open(start int64) error {
+	file, err := os.OpenFile(w.log.segmentPath(start), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+
+	w.file = file
+	w.start = start
+	w.written = 0
+	return nil
+}
+
+func (w *outputLogWriter) Write(p []byte) (int, error) {
+	total := 0
+	for len(p) > 0 {
+		if w.written >= w.limits.SegmentBytes {
+			if err := w.rotate(); err != nil {
+				return total, err
+			}
+		}
+
+		chunk := p
+		if room := w.limits.SegmentBytes - w.written; int64(len(chunk)) > room {
+			chunk = chunk[:room]
+		}
+
+		n, err := w.file.Write(chunk)
+		total += n
+		w.written += int64(n)
+		if err != nil {
+			return total, err
+		}
+
+		p = p[n:]
+	}
+
+	return total, nil
+}
+
+// rotate starts a new segment and deletes the oldest ones beyond the limit
+func (w *outputLogWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+
+	if err := w.open(w.start + w.written); err != nil {
+		return err
+	}
+
+	segments, err := w.log.segments()
+	if err != nil {
+		return err
+	}
+
+	for len(segments) > w.limits.Segments && w.limits.Segments > 0 {
+		if err := os.Remove(segments[0].path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		segments = segments[1:]
+	}
+
+	return nil
+}
+
+func (w *outputLogWriter) Close() error {
+	return w.file.Close()
+}
+
+// Reader reads the log from offset up to, but not including, end. Reading
+// starts from the oldest segment if the offset has been rotated away.
+func (l OutputLog) Reader(offset, end int64) io.ReadCloser {
+	return &logReader{log: l, pos: offset, end: end}
+}
+
+type logReader struct {
+	log      OutputLog
+	pos, end int64
+
+	file  *os.File
+	start int64
+}
+
+func (r *logReader) Read(p []byte) (int, error) {
+	for r.pos < r.end {
+		if r.file == nil {
+			opened, err := r.openSegment()
+			if err != nil || !opened {
+				return 0, io.EOF
+			}
+		}
+
+		if remaining := r.end - r.pos; int64(len(p)) > remaining {
+			p = p[:remaining]
+		}
+
+		n, err := r.file.Read(p)
+		r.pos += int64(n)
+		if n > 0 {
+			return n, nil
+		}
+
+		r.file.Close()
+		r.file = nil
+		if err != io.EOF {
+			return 0, err
+		}
+
+		// the segment ended, so the rest is in the next one, if any
+		if !r.skipToNextSegment() {
+			return 0, io.EOF
+		}
+	}
+
+	return 0, io.EOF
+}
+
+// openSegment opens the segment which holds the current position
+func (r *logReader) openSegment() (bool, error) {
+	segments, err := r.log.segments()
+	if err != nil || len(segments) == 0 {
+		return false, err
+	}
+
+	current := segments[0]
+	if r.pos < current.start {
+		r.pos = current.start
+	}
+
+	for _, s := range segments {
+		if s.start <= r.pos {
+			current = s
+		}
+	}
+
+	file, err := os.Open(current.path)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := file.Seek(r.pos-current.start, io.SeekStart); err != nil {
+		file.Close()
+		return false, err
+	}
+
+	r.file = file
+	r.start = current.start
+	return true, nil
+}
+
+func (r *logReader) skipToNextSegment() bool {
+	segments, err := r.log.segments()
+	if err != nil {
+		return false
+	}
+
+	for _, s := range segments {
+		if s.start > r.start {
+			if s.start > r.pos {
+				r.pos = s.start
+			}
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *logReader) Close() error {
+	if r.file != nil {
+		return r.file.Close()
+	}
+
+	return nil
+}
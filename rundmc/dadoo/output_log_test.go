@@ -0,0 +1,83 @@
+package dadoo_test
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/guardian/rundmc/dadoo"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OutputLog", func() {
+	var (
+		dir       string
+		outputLog dadoo.OutputLog
+		writer    io.WriteCloser
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "outputlog")
+		Expect(err).NotTo(HaveOccurred())
+
+		outputLog = dadoo.OutputLog{Dir: dir, Stream: "stdout"}
+		writer, err = outputLog.NewWriter(dadoo.OutputLogLimits{SegmentBytes: 4, Segments: 2})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		writer.Close()
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	readAll := func(offset, end int64) string {
+		reader := outputLog.Reader(offset, end)
+		defer reader.Close()
+
+		contents, err := ioutil.ReadAll(reader)
+		Expect(err).NotTo(HaveOccurred())
+		return string(contents)
+	}
+
+	It("rotates segments at the size limit and only keeps the most recent ones", func() {
+		_, err := writer.Write([]byte("0123456789"))
+		Expect(err).NotTo(HaveOccurred())
+
+		files, err := filepath.Glob(filepath.Join(dir, "stdout.*.log"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(ConsistOf(filepath.Join(dir, "stdout.4.log"), filepath.Join(dir, "stdout.8.log")))
+
+		Expect(outputLog.End()).To(Equal(int64(10)))
+	})
+
+	It("reads from an offset across segments", func() {
+		_, err := writer.Write([]byte("0123456"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(readAll(2, 7)).To(Equal("23456"))
+	})
+
+	It("reads from the oldest segment when the offset has been rotated away", func() {
+		_, err := writer.Write([]byte("0123456789"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(readAll(1, 10)).To(Equal("456789"))
+	})
+
+	It("stops reading at the end it was given", func() {
+		_, err := writer.Write([]byte("0123456"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(readAll(1, 6)).To(Equal("12345"))
+	})
+
+	It("reads nothing when the offset is at the end", func() {
+		_, err := writer.Write([]byte("0123456"))
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(readAll(7, 7)).To(BeEmpty())
+	})
+})
@@ -0,0 +1,131 @@
+package dadoo
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/rundmc/runrunc"
+)
+
+// processMeta is written to each process directory when the process is run,
+// since none of it can be recovered from the process itself once it has
+// exited
+type processMeta struct {
+	Args      []string  `json:"args"`
+	User      string    `json:"user"`
+	TTY       bool      `json:"tty"`
+	StartedAt time.Time `json:"started_at"`
+
+	OutputLogged bool `json:"output_logged,omitempty"`
+}
+
+func writeProcessMeta(processPath string, spec *runrunc.PreparedSpec, tty, outputLogged bool) error {
+	meta, err := json.Marshal(processMeta{
+		Args:         spec.Args,
+		User:         spec.Username,
+		TTY:          tty,
+		StartedAt:    time.Now(),
+		OutputLogged: outputLogged,
+	})
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(processPath, "meta.json"), meta, 0600)
+}
+
+func readProcessMeta(processPath string) (processMeta, bool) {
+	contents, err := ioutil.ReadFile(filepath.Join(processPath, "meta.json"))
+	if err != nil {
+		return processMeta{}, false
+	}
+
+	var meta processMeta
+	if err := json.Unmarshal(contents, &meta); err != nil {
+		return processMeta{}, false
+	}
+
+	return meta, true
+}
+
+// ProcessLister lists the processes in a container from the directories in
+// which dadoo keeps their state
+type ProcessLister struct {
+	// Tombstones older than this are removed rather than listed, if it is set
+	TombstoneRetention time.Duration
+}
+
+func (l ProcessLister) List(processesPath string) ([]gardener.ProcessInfo, error) {
+	if l.TombstoneRetention > 0 {
+		if err := sweepTombstones(processesPath, l.TombstoneRetention); err != nil {
+			return nil, err
+		}
+	}
+
+	dirs, err := ioutil.ReadDir(processesPath)
+	if os.IsNotExist(err) {
+		return []gardener.ProcessInfo{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	processes := []gardener.ProcessInfo{}
+	for _, dir := range dirs {
+		if !dir.IsDir() {
+			continue
+		}
+
+		processes = append(processes, readProcess(filepath.Join(processesPath, dir.Name())))
+	}
+
+	return processes, nil
+}
+
+// readProcess reports whatever it can find in the process directory, which
+// may still be being set up, or be missing the metadata if it was created by
+// an older version of guardian
+func readProcess(processPath string) gardener.ProcessInfo {
+	info := gardener.ProcessInfo{ID: filepath.Base(processPath)}
+
+	if meta, ok := readProcessMeta(processPath); ok {
+		info.Args = meta.Args
+		info.User = meta.User
+		info.TTY = meta.TTY
+		info.StartedAt = meta.StartedAt
+	}
+
+	if pid, err := readInt(filepath.Join(processPath, "pidfile")); err == nil {
+		info.Pid = pid
+	}
+
+	if exitStatus, err := readInt(filepath.Join(processPath, "exitcode")); err == nil {
+		info.ExitStatus = &exitStatus
+	}
+
+	if exit, ok := readExitFile(processPath); ok {
+		info.Usage = &exit.Usage
+	}
+
+	if t, ok := readTombstone(processPath); ok {
+		info.ExitStatus = &t.ExitStatus
+		info.Usage = t.Usage
+	}
+
+	return info
+}
+
+func readInt(path string) (int, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(strings.TrimSpace(string(contents)))
+}
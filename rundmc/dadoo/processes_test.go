@@ -0,0 +1,106 @@
+package dadoo_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/rundmc/dadoo"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProcessLister", func() {
+	var processesPath string
+
+	BeforeEach(func() {
+		var err error
+		processesPath, err = ioutil.TempDir("", "processes")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(processesPath)).To(Succeed())
+	})
+
+	writeProcessFile := func(id, name, contents string) {
+		Expect(os.MkdirAll(filepath.Join(processesPath, id), 0700)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(processesPath, id, name), []byte(contents), 0600)).To(Succeed())
+	}
+
+	It("lists the processes with their metadata and pid", func() {
+		writeProcessFile("running", "meta.json", `{"args":["sleep","10"],"user":"alice","tty":true,"started_at":"2017-01-02T03:04:05Z"}`)
+		writeProcessFile("running", "pidfile", "1234")
+
+		processes, err := dadoo.ProcessLister{}.List(processesPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(processes).To(HaveLen(1))
+
+		process := processes[0]
+		Expect(process.ID).To(Equal("running"))
+		Expect(process.Args).To(Equal([]string{"sleep", "10"}))
+		Expect(process.User).To(Equal("alice"))
+		Expect(process.TTY).To(BeTrue())
+		Expect(process.StartedAt).To(Equal(time.Date(2017, 1, 2, 3, 4, 5, 0, time.UTC)))
+		Expect(process.Pid).To(Equal(1234))
+		Expect(process.ExitStatus).To(BeNil())
+	})
+
+	It("reports the exit status of processes which have exited", func() {
+		writeProcessFile("exited", "exitcode", "42")
+
+		processes, err := dadoo.ProcessLister{}.List(processesPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(processes).To(HaveLen(1))
+		Expect(*processes[0].ExitStatus).To(Equal(42))
+	})
+
+	It("reports the resources used by processes which have exited", func() {
+		writeProcessFile("exited", "exitcode", "42")
+		writeProcessFile("exited", "exit.json", `{"exit_status":42,"usage":{"max_rss_bytes":2048,"user_cpu_ns":1000,"system_cpu_ns":2000,"wall_clock_ns":3000}}`)
+
+		processes, err := dadoo.ProcessLister{}.List(processesPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(processes).To(HaveLen(1))
+		Expect(processes[0].Usage).To(Equal(&gardener.ResourceUsage{
+			MaxRSSBytes: 2048,
+			UserCPU:     1000,
+			SystemCPU:   2000,
+			WallClock:   3000,
+		}))
+	})
+
+	Context("when tombstones are retained", func() {
+		It("removes expired tombstones rather than listing them", func() {
+			writeProcessFile("expired", "tombstone.json", `{"exit_status":3,"finished_at":"2017-01-02T03:04:05Z"}`)
+			writeProcessFile("recent", "tombstone.json", `{"exit_status":4,"finished_at":"`+time.Now().Format(time.RFC3339)+`"}`)
+
+			processes, err := dadoo.ProcessLister{TombstoneRetention: time.Minute}.List(processesPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processes).To(HaveLen(1))
+			Expect(processes[0].ID).To(Equal("recent"))
+			Expect(filepath.Join(processesPath, "expired")).NotTo(BeAnExistingFile())
+		})
+	})
+
+	It("lists processes which have no metadata", func() {
+		writeProcessFile("old", "pidfile", "99")
+
+		processes, err := dadoo.ProcessLister{}.List(processesPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(processes).To(HaveLen(1))
+		Expect(processes[0].ID).To(Equal("old"))
+		Expect(processes[0].Pid).To(Equal(99))
+		Expect(processes[0].Args).To(BeEmpty())
+	})
+
+	Context("when the container has never run a process", func() {
+		It("returns an empty list", func() {
+			processes, err := dadoo.ProcessLister{}.List(filepath.Join(processesPath, "missing"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(processes).To(BeEmpty())
+		})
+	})
+})
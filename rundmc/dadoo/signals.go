@@ -0,0 +1,38 @@
+package dadoo
+
+import (
+	"fmt"
+	"syscall"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+)
+
+var osSignals = map[garden.Signal]syscall.Signal{
+	garden.SignalTerminate:   syscall.SIGTERM,
+	garden.SignalKill:        syscall.SIGKILL,
+	gardener.SignalHangup:    syscall.SIGHUP,
+	gardener.SignalInterrupt: syscall.SIGINT,
+	gardener.SignalQuit:      syscall.SIGQUIT,
+	gardener.SignalUser1:     syscall.SIGUSR1,
+	gardener.SignalUser2:     syscall.SIGUSR2,
+}
+
+type UnsupportedSignalError struct {
+	Signal garden.Signal
+}
+
+func (e UnsupportedSignalError) Error() string {
+	return fmt.Sprintf("unsupported signal: %d", e.Signal)
+}
+
+type osSignal garden.Signal
+
+func (s osSignal) OsSignal() (syscall.Signal, error) {
+	sig, ok := osSignals[garden.Signal(s)]
+	if !ok {
+		return 0, UnsupportedSignalError{Signal: garden.Signal(s)}
+	}
+
+	return sig, nil
+}
@@ -0,0 +1,194 @@
+package dadoo
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+)
+
+const tombstoneFile = "tombstone.json"
+
+// tombstone is left in the process directory once a process has been waited
+// for, so that later calls to Attach or Wait can still find out how it exited
+type tombstone struct {
+	ExitStatus int       `json:"exit_status"`
+	FinishedAt time.Time `json:"finished_at"`
+
+	Usage *gardener.ResourceUsage `json:"usage,omitempty"`
+}
+
+func (t tombstone) expired(retention time.Duration) bool {
+	return time.Since(t.FinishedAt) > retention
+}
+
+func readTombstone(processPath string) (tombstone, bool) {
+	contents, err := ioutil.ReadFile(filepath.Join(processPath, tombstoneFile))
+	if err != nil {
+		return tombstone{}, false
+	}
+
+	var t tombstone
+	if err := json.Unmarshal(contents, &t); err != nil {
+		return tombstone{}, false
+	}
+
+	return t, true
+}
+
+// buryProcess replaces everything in the process directory apart from its
+// metadata, pidfile and output logs with a tombstone, unless another waiter
+// has already buried it
+func buryProcess(processPath string, exitStatus int, usage *gardener.ResourceUsage) error {
+	contents, err := json.Marshal(tombstone{
+		ExitStatus: exitStatus,
+		FinishedAt: time.Now(),
+		Usage:      usage,
+	})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(processPath, tombstoneFile+".tmp")
+	if err != nil {
+		if alreadyBuried(processPath) {
+			return nil
+		}
+
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(contents)
+	tmp.Close()
+	if err != nil {
+		return err
+	}
+
+	// linking, unlike renaming, fails if the tombstone already exists
+	if err := os.Link(tmp.Name(), filepath.Join(processPath, tombstoneFile)); err != nil {
+		if os.IsExist(err) || alreadyBuried(processPath) {
+			return nil
+		}
+
+		return err
+	}
+
+	files, err := ioutil.ReadDir(processPath)
+	if err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		if file.Name() == tombstoneFile || file.Name() == "meta.json" || file.Name() == "pidfile" || strings.HasSuffix(file.Name(), ".log") {
+			continue
+		}
+
+		if err := os.Remove(filepath.Join(processPath, file.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// alreadyBuried reports whether another waiter has buried the process, or
+// removed it altogether
+func alreadyBuried(processPath string) bool {
+	if _, ok := readTombstone(processPath); ok {
+		return true
+	}
+
+	_, err := os.Stat(processPath)
+	return os.IsNotExist(err)
+}
+
+// sweepTombstones removes the directories of processes whose tombstones are
+// older than the retention period
+func sweepTombstones(processesPath string, retention time.Duration) error {
+	dirs, err := ioutil.ReadDir(processesPath)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, dir := range dirs {
+		processPath := filepath.Join(processesPath, dir.Name())
+		if t, ok := readTombstone(processPath); ok && t.expired(retention) {
+			if err := os.RemoveAll(processPath); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+var errProcessExited = errors.New("process has already exited")
+
+// exitedProcess is returned when attaching to a process which has been
+// buried. It replays the logged output, if asked to.
+type exitedProcess struct {
+	id         string
+	exitStatus int
+	replayWg   *sync.WaitGroup
+}
+
+func newExitedProcess(id, processPath string, exitStatus int, replay *gardener.OutputOffsets, pio garden.ProcessIO) exitedProcess {
+	p := exitedProcess{id: id, exitStatus: exitStatus, replayWg: &sync.WaitGroup{}}
+	if replay == nil {
+		return p
+	}
+
+	p.replayLog(OutputLog{Dir: processPath, Stream: "stdout"}, replay.Stdout, pio.Stdout)
+	p.replayLog(OutputLog{Dir: processPath, Stream: "stderr"}, replay.Stderr, pio.Stderr)
+	return p
+}
+
+func (p exitedProcess) replayLog(log OutputLog, offset int64, w io.Writer) {
+	if w == nil {
+		return
+	}
+
+	end, err := log.End()
+	if err != nil {
+		return
+	}
+
+	p.replayWg.Add(1)
+	go func() {
+		defer p.replayWg.Done()
+
+		r := log.Reader(offset, end)
+		defer r.Close()
+		io.Copy(w, r)
+	}()
+}
+
+func (p exitedProcess) ID() string {
+	return p.id
+}
+
+func (p exitedProcess) Wait() (int, error) {
+	p.replayWg.Wait()
+	return p.exitStatus, nil
+}
+
+func (p exitedProcess) SetTTY(garden.TTYSpec) error {
+	return errProcessExited
+}
+
+func (p exitedProcess) Signal(garden.Signal) error {
+	return fmt.Errorf("signal process %s: %s", p.id, errProcessExited)
+}
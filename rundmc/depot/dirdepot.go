@@ -85,6 +85,12 @@ func (d *DirectoryDepot) Handles() ([]string, error) {
 	}
 
 	for _, f := range fileInfos {
+		// containers are directories, so files such as the health check's
+		// probes are not containers
+		if !f.IsDir() {
+			continue
+		}
+
 		handles = append(handles, f.Name())
 	}
 	return handles, nil
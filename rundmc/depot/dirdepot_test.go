@@ -98,6 +98,11 @@ var _ = Describe("Depot", func() {
 			It("should return the handles", func() {
 				Expect(dirdepot.Handles()).To(ConsistOf("banana", "banana2"))
 			})
+
+			It("should not return files in the depot directory", func() {
+				Expect(ioutil.WriteFile(filepath.Join(depotDir, ".health-check123"), []byte("ok"), 0600)).To(Succeed())
+				Expect(dirdepot.Handles()).To(ConsistOf("banana", "banana2"))
+			})
 		})
 
 		Context("when no handles exist", func() {
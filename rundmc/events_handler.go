@@ -0,0 +1,71 @@
+package rundmc
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+//go:generate counterfeiter . EventHistories
+
+type EventHistories interface {
+	Handles() ([]string, error)
+	Events(handle string, query EventQuery) EventHistory
+}
+
+type eventsHandler struct {
+	histories EventHistories
+}
+
+// NewEventsHandler serves the event histories of containers as JSON, keyed by
+// handle. The handle, type, source, since (RFC 3339) and limit query
+// parameters narrow down the response.
+func NewEventsHandler(histories EventHistories) http.Handler {
+	return &eventsHandler{histories: histories}
+}
+
+func (h *eventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+
+	query := EventQuery{
+		Type:   params.Get("type"),
+		Source: params.Get("source"),
+	}
+
+	if since := params.Get("since"); since != "" {
+		t, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			http.Error(w, "invalid since: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		query.Since = t
+	}
+
+	if limit := params.Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			http.Error(w, "invalid limit: "+limit, http.StatusBadRequest)
+			return
+		}
+		query.Limit = n
+	}
+
+	handles := params["handle"]
+	if len(handles) == 0 {
+		var err error
+		handles, err = h.histories.Handles()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	histories := make(map[string]EventHistory, len(handles))
+	for _, handle := range handles {
+		histories[handle] = h.histories.Events(handle, query)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(histories)
+}
@@ -0,0 +1,116 @@
+package rundmc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"code.cloudfoundry.org/guardian/rundmc"
+	fakes "code.cloudfoundry.org/guardian/rundmc/rundmcfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EventsHandler", func() {
+	var (
+		fakeHistories *fakes.FakeEventHistories
+		recorder      *httptest.ResponseRecorder
+		path          string
+	)
+
+	BeforeEach(func() {
+		fakeHistories = new(fakes.FakeEventHistories)
+		fakeHistories.HandlesReturns([]string{"foo", "bar"}, nil)
+		fakeHistories.EventsStub = func(handle string, query rundmc.EventQuery) rundmc.EventHistory {
+			return rundmc.EventHistory{
+				Total:  1,
+				Events: []rundmc.Event{{Type: handle + "-event", Source: "runc"}},
+			}
+		}
+
+		recorder = httptest.NewRecorder()
+		path = "/debug/events"
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequest("GET", path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		rundmc.NewEventsHandler(fakeHistories).ServeHTTP(recorder, req)
+	})
+
+	decode := func() map[string]rundmc.EventHistory {
+		var histories map[string]rundmc.EventHistory
+		Expect(json.NewDecoder(recorder.Body).Decode(&histories)).To(Succeed())
+		return histories
+	}
+
+	It("serves the history of every container as JSON", func() {
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+
+		histories := decode()
+		Expect(histories).To(HaveLen(2))
+		Expect(histories["foo"].Events[0].Type).To(Equal("foo-event"))
+		Expect(histories["bar"].Events[0].Type).To(Equal("bar-event"))
+	})
+
+	Context("when handles are given", func() {
+		BeforeEach(func() {
+			path = "/debug/events?handle=foo"
+		})
+
+		It("serves only their history", func() {
+			Expect(fakeHistories.HandlesCallCount()).To(Equal(0))
+			Expect(decode()).To(HaveLen(1))
+		})
+	})
+
+	Context("when the query is narrowed down", func() {
+		BeforeEach(func() {
+			path = "/debug/events?type=Out+of+memory&source=runc&since=2016-01-02T15:04:05Z&limit=3"
+		})
+
+		It("passes the query to the event histories", func() {
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+
+			_, query := fakeHistories.EventsArgsForCall(0)
+			Expect(query.Type).To(Equal("Out of memory"))
+			Expect(query.Source).To(Equal("runc"))
+			Expect(query.Since).To(BeTemporally("==", time.Date(2016, 1, 2, 15, 4, 5, 0, time.UTC)))
+			Expect(query.Limit).To(Equal(3))
+		})
+	})
+
+	Context("when since is not a valid time", func() {
+		BeforeEach(func() {
+			path = "/debug/events?since=yesterday"
+		})
+
+		It("responds with a bad request", func() {
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when limit is not a number", func() {
+		BeforeEach(func() {
+			path = "/debug/events?limit=potato"
+		})
+
+		It("responds with a bad request", func() {
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when listing the handles fails", func() {
+		BeforeEach(func() {
+			fakeHistories.HandlesReturns(nil, errors.New("boom"))
+		})
+
+		It("responds with an internal server error", func() {
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
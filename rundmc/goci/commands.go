@@ -13,6 +13,11 @@ func StartCommand(path, id string, detach bool, log string) *exec.Cmd {
 	return DefaultRuncBinary.StartCommand(path, id, detach, log)
 }
 
+// CreateCommand creates a create command using the default runc binary name.
+func CreateCommand(bundlePath, id, logFile, pidFilePath string) *exec.Cmd {
+	return DefaultRuncBinary.CreateCommand(bundlePath, id, logFile, pidFilePath)
+}
+
 // ExecCommand creates an exec command using the default runc binary name.
 func ExecCommand(id, processJSONPath, pidFilePath string) *exec.Cmd {
 	return DefaultRuncBinary.ExecCommand(id, processJSONPath, pidFilePath)
@@ -42,6 +47,31 @@ func EventsCommand(id string) *exec.Cmd {
 	return DefaultRuncBinary.EventsCommand(id)
 }
 
+// UpdateCommand creates a command that updates the resources of a container using the default runc binary name.
+func UpdateCommand(id, logFile string) *exec.Cmd {
+	return DefaultRuncBinary.UpdateCommand(id, logFile)
+}
+
+// PauseCommand creates a command that pauses a container using the default runc binary name.
+func PauseCommand(id, logFile string) *exec.Cmd {
+	return DefaultRuncBinary.PauseCommand(id, logFile)
+}
+
+// ResumeCommand creates a command that resumes a container using the default runc binary name.
+func ResumeCommand(id, logFile string) *exec.Cmd {
+	return DefaultRuncBinary.ResumeCommand(id, logFile)
+}
+
+// CheckpointCommand creates a command that checkpoints a container using the default runc binary name.
+func CheckpointCommand(id, imagePath, logFile string) *exec.Cmd {
+	return DefaultRuncBinary.CheckpointCommand(id, imagePath, logFile)
+}
+
+// RestoreCommand creates a command that restores a container using the default runc binary name.
+func RestoreCommand(id, bundlePath, imagePath, logFile string) *exec.Cmd {
+	return DefaultRuncBinary.RestoreCommand(id, bundlePath, imagePath, logFile)
+}
+
 // StartCommand returns an *exec.Cmd that, when run, will execute a given bundle.
 func (runc RuncBinary) StartCommand(path, id string, detach bool, log string) *exec.Cmd {
 	args := []string{"--debug", "--log", log, "start"}
@@ -56,6 +86,12 @@ func (runc RuncBinary) StartCommand(path, id string, detach bool, log string) *e
 	return cmd
 }
 
+// CreateCommand returns an *exec.Cmd that, when run, will create a container
+// from the bundle and start its init process.
+func (runc RuncBinary) CreateCommand(bundlePath, id, logFile, pidFilePath string) *exec.Cmd {
+	return exec.Command(string(runc), "--debug", "--log", logFile, "create", "--no-new-keyring", "--bundle", bundlePath, "--pid-file", pidFilePath, id)
+}
+
 // ExecCommand returns an *exec.Cmd that, when run, will execute a process spec
 // in a running container.
 func (runc RuncBinary) ExecCommand(id, processJSONPath, pidFilePath string) *exec.Cmd {
@@ -96,3 +132,114 @@ func (runc RuncBinary) StatsCommand(id, logFile string) *exec.Cmd {
 func (runc RuncBinary) DeleteCommand(id, logFile string) *exec.Cmd {
 	return exec.Command(string(runc), "--debug", "--log", logFile, "delete", id)
 }
+
+// UpdateCommand returns an *exec.Cmd that, when run, will update the resource
+// limits of the running container. The resources are read as JSON from stdin.
+func (runc RuncBinary) UpdateCommand(id, logFile string) *exec.Cmd {
+	return exec.Command(string(runc), "--debug", "--log", logFile, "update", "-r", "-", id)
+}
+
+// PauseCommand returns an *exec.Cmd that, when run, will freeze all of the
+// processes in the container.
+func (runc RuncBinary) PauseCommand(id, logFile string) *exec.Cmd {
+	return exec.Command(string(runc), "--debug", "--log", logFile, "pause", id)
+}
+
+// ResumeCommand returns an *exec.Cmd that, when run, will thaw all of the
+// processes in a paused container.
+func (runc RuncBinary) ResumeCommand(id, logFile string) *exec.Cmd {
+	return exec.Command(string(runc), "--debug", "--log", logFile, "resume", id)
+}
+
+// CheckpointCommand returns an *exec.Cmd that, when run, will dump the state of
+// the container's processes to the image path using CRIU and stop the container.
+// The network namespace is left out, since CRIU cannot dump a namespace whose
+// veth peer is outside it; it is rebuilt by the networker on restore.
+func (runc RuncBinary) CheckpointCommand(id, imagePath, logFile string) *exec.Cmd {
+	return exec.Command(string(runc), "--debug", "--log", logFile, "checkpoint", "--image-path", imagePath, "--empty-ns", "network", id)
+}
+
+// RestoreCommand returns an *exec.Cmd that, when run, will restore the
+// container's processes from a checkpoint in the image path.
+func (runc RuncBinary) RestoreCommand(id, bundlePath, imagePath, logFile string) *exec.Cmd {
+	return exec.Command(string(runc), "--debug", "--log", logFile, "restore", "--detach", "--image-path", imagePath, "--bundle", bundlePath, id)
+}
+
+// RuntimeBinary is an OCI runtime with a runc-compatible command line, which
+// is given some global arguments (e.g. --root or --systemd-cgroup) in front of
+// every command.
+type RuntimeBinary struct {
+	RuncBinary
+	Args []string
+}
+
+// StartCommand returns a start command for the runtime.
+func (r RuntimeBinary) StartCommand(path, id string, detach bool, log string) *exec.Cmd {
+	return r.withArgs(r.RuncBinary.StartCommand(path, id, detach, log))
+}
+
+// CreateCommand returns a create command for the runtime.
+func (r RuntimeBinary) CreateCommand(bundlePath, id, logFile, pidFilePath string) *exec.Cmd {
+	return r.withArgs(r.RuncBinary.CreateCommand(bundlePath, id, logFile, pidFilePath))
+}
+
+// ExecCommand returns an exec command for the runtime.
+func (r RuntimeBinary) ExecCommand(id, processJSONPath, pidFilePath string) *exec.Cmd {
+	return r.withArgs(r.RuncBinary.ExecCommand(id, processJSONPath, pidFilePath))
+}
+
+// EventsCommand returns an events command for the runtime.
+func (r RuntimeBinary) EventsCommand(id string) *exec.Cmd {
+	return r.withArgs(r.RuncBinary.EventsCommand(id))
+}
+
+// KillCommand returns a kill command for the runtime.
+func (r RuntimeBinary) KillCommand(id, signal, logFile string) *exec.Cmd {
+	return r.withArgs(r.RuncBinary.KillCommand(id, signal, logFile))
+}
+
+// StateCommand returns a state command for the runtime.
+func (r RuntimeBinary) StateCommand(id, logFile string) *exec.Cmd {
+	return r.withArgs(r.RuncBinary.StateCommand(id, logFile))
+}
+
+// StatsCommand returns a stats command for the runtime.
+func (r RuntimeBinary) StatsCommand(id, logFile string) *exec.Cmd {
+	return r.withArgs(r.RuncBinary.StatsCommand(id, logFile))
+}
+
+// DeleteCommand returns a delete command for the runtime.
+func (r RuntimeBinary) DeleteCommand(id, logFile string) *exec.Cmd {
+	return r.withArgs(r.RuncBinary.DeleteCommand(id, logFile))
+}
+
+// UpdateCommand returns an update command for the runtime.
+func (r RuntimeBinary) UpdateCommand(id, logFile string) *exec.Cmd {
+	return r.withArgs(r.RuncBinary.UpdateCommand(id, logFile))
+}
+
+// PauseCommand returns a pause command for the runtime.
+func (r RuntimeBinary) PauseCommand(id, logFile string) *exec.Cmd {
+	return r.withArgs(r.RuncBinary.PauseCommand(id, logFile))
+}
+
+// ResumeCommand returns a resume command for the runtime.
+func (r RuntimeBinary) ResumeCommand(id, logFile string) *exec.Cmd {
+	return r.withArgs(r.RuncBinary.ResumeCommand(id, logFile))
+}
+
+// CheckpointCommand returns a checkpoint command for the runtime.
+func (r RuntimeBinary) CheckpointCommand(id, imagePath, logFile string) *exec.Cmd {
+	return r.withArgs(r.RuncBinary.CheckpointCommand(id, imagePath, logFile))
+}
+
+// RestoreCommand returns a restore command for the runtime.
+func (r RuntimeBinary) RestoreCommand(id, bundlePath, imagePath, logFile string) *exec.Cmd {
+	return r.withArgs(r.RuncBinary.RestoreCommand(id, bundlePath, imagePath, logFile))
+}
+
+func (r RuntimeBinary) withArgs(cmd *exec.Cmd) *exec.Cmd {
+	args := append([]string{cmd.Args[0]}, r.Args...)
+	cmd.Args = append(args, cmd.Args[1:]...)
+	return cmd
+}
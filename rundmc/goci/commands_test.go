@@ -29,6 +29,13 @@ var _ = Describe("Commands", func() {
 		})
 	})
 
+	Describe("CreateCommand", func() {
+		It("creates an *exec.Cmd to create a container from a bundle", func() {
+			cmd := goci.CreateCommand("/path/to/bundle", "my-bundle-id", "log.file", "pid.file")
+			Expect(cmd.Args).To(Equal([]string{"funC", "--debug", "--log", "log.file", "create", "--no-new-keyring", "--bundle", "/path/to/bundle", "--pid-file", "pid.file", "my-bundle-id"}))
+		})
+	})
+
 	Describe("ExecCommand", func() {
 		It("creates an *exec.Cmd to exec a process in a bundle", func() {
 			cmd := goci.ExecCommand("my-bundle-id", "my-process-json.json", "some-pid-file")
@@ -70,4 +77,74 @@ var _ = Describe("Commands", func() {
 			Expect(cmd.Args).To(Equal([]string{"funC", "--debug", "--log", "log.file", "delete", "my-bundle-id"}))
 		})
 	})
+
+	Describe("UpdateCommand", func() {
+		It("creates an *exec.Cmd to update the resources of the bundle", func() {
+			cmd := goci.UpdateCommand("my-bundle-id", "log.file")
+			Expect(cmd.Args).To(Equal([]string{"funC", "--debug", "--log", "log.file", "update", "-r", "-", "my-bundle-id"}))
+		})
+	})
+
+	Describe("PauseCommand", func() {
+		It("creates an *exec.Cmd to pause the bundle", func() {
+			cmd := goci.PauseCommand("my-bundle-id", "log.file")
+			Expect(cmd.Args).To(Equal([]string{"funC", "--debug", "--log", "log.file", "pause", "my-bundle-id"}))
+		})
+	})
+
+	Describe("ResumeCommand", func() {
+		It("creates an *exec.Cmd to resume the bundle", func() {
+			cmd := goci.ResumeCommand("my-bundle-id", "log.file")
+			Expect(cmd.Args).To(Equal([]string{"funC", "--debug", "--log", "log.file", "resume", "my-bundle-id"}))
+		})
+	})
+
+	Describe("CheckpointCommand", func() {
+		It("creates an *exec.Cmd to checkpoint the bundle", func() {
+			cmd := goci.CheckpointCommand("my-bundle-id", "/path/to/image", "log.file")
+			Expect(cmd.Args).To(Equal([]string{"funC", "--debug", "--log", "log.file", "checkpoint", "--image-path", "/path/to/image", "--empty-ns", "network", "my-bundle-id"}))
+		})
+	})
+
+	Describe("RestoreCommand", func() {
+		It("creates an *exec.Cmd to restore the bundle from a checkpoint", func() {
+			cmd := goci.RestoreCommand("my-bundle-id", "/path/to/bundle", "/path/to/image", "log.file")
+			Expect(cmd.Args).To(Equal([]string{"funC", "--debug", "--log", "log.file", "restore", "--detach", "--image-path", "/path/to/image", "--bundle", "/path/to/bundle", "my-bundle-id"}))
+		})
+	})
+
+	Describe("RuntimeBinary", func() {
+		var runtime goci.RuntimeBinary
+
+		BeforeEach(func() {
+			runtime = goci.RuntimeBinary{
+				RuncBinary: goci.RuncBinary("crun"),
+				Args:       []string{"--root", "/run/crun"},
+			}
+		})
+
+		It("passes its global arguments before the command", func() {
+			cmd := runtime.StateCommand("my-bundle-id", "log.file")
+			Expect(cmd.Path).To(HaveSuffix("crun"))
+			Expect(cmd.Args).To(Equal([]string{"crun", "--root", "/run/crun", "--debug", "--log", "log.file", "state", "my-bundle-id"}))
+		})
+
+		It("passes its global arguments to commands without options", func() {
+			cmd := runtime.EventsCommand("my-bundle-id")
+			Expect(cmd.Args).To(Equal([]string{"crun", "--root", "/run/crun", "events", "my-bundle-id"}))
+		})
+
+		It("keeps the working directory of the start command", func() {
+			cmd := runtime.StartCommand("my-bundle-path", "my-bundle-id", false, "mylog.file")
+			Expect(cmd.Args).To(Equal([]string{"crun", "--root", "/run/crun", "--debug", "--log", "mylog.file", "start", "my-bundle-id"}))
+			Expect(cmd.Dir).To(Equal("my-bundle-path"))
+		})
+
+		Context("without global arguments", func() {
+			It("behaves like the plain binary", func() {
+				cmd := goci.RuntimeBinary{RuncBinary: goci.RuncBinary("crun")}.KillCommand("my-bundle-id", "TERM", "log.file")
+				Expect(cmd.Args).To(Equal(goci.RuncBinary("crun").KillCommand("my-bundle-id", "TERM", "log.file").Args))
+			})
+		})
+	})
 })
@@ -26,10 +26,11 @@ func (b Bndl) Save(path string) error {
 }
 
 func save(value interface{}, path string) error {
-	w, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE, 0755)
+	w, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
 	if err != nil {
 		return fmt.Errorf("Failed to save bundle: %s", err)
 	}
+	defer w.Close()
 
 	return json.NewEncoder(w).Encode(value)
 }
@@ -46,6 +46,20 @@ var _ = Describe("Bundle Serialization", func() {
 			Expect(configJson).To(HaveKeyWithValue("ociVersion", Equal("abcd")))
 		})
 
+		Context("when the bundle has already been saved", func() {
+			It("replaces the previous contents of spec.json", func() {
+				bndle.Spec.Hostname = "a-fairly-long-hostname"
+				Expect(bndle.Save(tmp)).To(Succeed())
+
+				bndle.Spec.Hostname = ""
+				Expect(bndle.Save(tmp)).To(Succeed())
+
+				loadedBundle, err := (&goci.BndlLoader{}).Load(tmp)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(loadedBundle).To(Equal(bndle))
+			})
+		})
+
 		Context("when saving fails", func() {
 			It("returns an error", func() {
 				err := bndle.Save("non-existent-dir")
@@ -0,0 +1,89 @@
+package rundmc
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter . ReplayingAttacher
+
+type ReplayingAttacher interface {
+	AttachReplaying(log lager.Logger, handle, processID string, from gardener.OutputOffsets, io garden.ProcessIO) (garden.Process, error)
+}
+
+type outputHandler struct {
+	log      lager.Logger
+	attacher ReplayingAttacher
+}
+
+// NewOutputHandler streams one output stream of a process, replaying its
+// logged output from the offset query parameter before following it until the
+// process exits. The handle and process query parameters pick the process, and
+// the stream query parameter is either stdout (the default) or stderr.
+func NewOutputHandler(log lager.Logger, attacher ReplayingAttacher) http.Handler {
+	return &outputHandler{log: log, attacher: attacher}
+}
+
+func (h *outputHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	handle, processID := query.Get("handle"), query.Get("process")
+	if handle == "" || processID == "" {
+		http.Error(w, "handle and process are required", http.StatusBadRequest)
+		return
+	}
+
+	var offset int64
+	if o := query.Get("offset"); o != "" {
+		var err error
+		offset, err = strconv.ParseInt(o, 10, 64)
+		if err != nil || offset < 0 {
+			http.Error(w, "offset must be a non-negative integer", http.StatusBadRequest)
+			return
+		}
+	}
+
+	out := &flushingWriter{w: w}
+	var from gardener.OutputOffsets
+	var pio garden.ProcessIO
+	switch query.Get("stream") {
+	case "", "stdout":
+		from.Stdout = offset
+		pio.Stdout = out
+	case "stderr":
+		from.Stderr = offset
+		pio.Stderr = out
+	default:
+		http.Error(w, "stream must be stdout or stderr", http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	process, err := h.attacher.AttachReplaying(h.log, handle, processID, from, pio)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if _, err := process.Wait(); err != nil {
+		h.log.Error("wait-failed", err, lager.Data{"handle": handle, "process-id": processID})
+	}
+}
+
+type flushingWriter struct {
+	w io.Writer
+}
+
+func (f *flushingWriter) Write(p []byte) (int, error) {
+	n, err := f.w.Write(p)
+	if flusher, ok := f.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	return n, err
+}
@@ -0,0 +1,119 @@
+package rundmc_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/garden/gardenfakes"
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/rundmc"
+	fakes "code.cloudfoundry.org/guardian/rundmc/rundmcfakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OutputHandler", func() {
+	var (
+		fakeAttacher *fakes.FakeReplayingAttacher
+		fakeProcess  *gardenfakes.FakeProcess
+		recorder     *httptest.ResponseRecorder
+		path         string
+	)
+
+	BeforeEach(func() {
+		fakeProcess = new(gardenfakes.FakeProcess)
+		fakeAttacher = new(fakes.FakeReplayingAttacher)
+		fakeAttacher.AttachReplayingStub = func(_ lager.Logger, _, _ string, _ gardener.OutputOffsets, pio garden.ProcessIO) (garden.Process, error) {
+			if pio.Stdout != nil {
+				pio.Stdout.Write([]byte("some-stdout"))
+			}
+			if pio.Stderr != nil {
+				pio.Stderr.Write([]byte("some-stderr"))
+			}
+			return fakeProcess, nil
+		}
+
+		recorder = httptest.NewRecorder()
+		path = "/debug/output?handle=some-handle&process=some-process&offset=6"
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequest("GET", path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		rundmc.NewOutputHandler(lagertest.NewTestLogger("test"), fakeAttacher).ServeHTTP(recorder, req)
+	})
+
+	It("attaches to the process, replaying stdout from the offset", func() {
+		Expect(fakeAttacher.AttachReplayingCallCount()).To(Equal(1))
+		_, handle, processID, from, _ := fakeAttacher.AttachReplayingArgsForCall(0)
+		Expect(handle).To(Equal("some-handle"))
+		Expect(processID).To(Equal("some-process"))
+		Expect(from).To(Equal(gardener.OutputOffsets{Stdout: 6}))
+	})
+
+	It("streams the output until the process exits", func() {
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Body.String()).To(Equal("some-stdout"))
+		Expect(recorder.Flushed).To(BeTrue())
+		Expect(fakeProcess.WaitCallCount()).To(Equal(1))
+	})
+
+	Context("when stderr is asked for", func() {
+		BeforeEach(func() {
+			path = "/debug/output?handle=some-handle&process=some-process&stream=stderr&offset=6"
+		})
+
+		It("replays stderr from the offset", func() {
+			_, _, _, from, _ := fakeAttacher.AttachReplayingArgsForCall(0)
+			Expect(from).To(Equal(gardener.OutputOffsets{Stderr: 6}))
+			Expect(recorder.Body.String()).To(Equal("some-stderr"))
+		})
+	})
+
+	Context("when the process is not given", func() {
+		BeforeEach(func() {
+			path = "/debug/output?handle=some-handle"
+		})
+
+		It("responds with bad request", func() {
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+			Expect(fakeAttacher.AttachReplayingCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the offset is not a number", func() {
+		BeforeEach(func() {
+			path = "/debug/output?handle=some-handle&process=some-process&offset=potato"
+		})
+
+		It("responds with bad request", func() {
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when the stream is unknown", func() {
+		BeforeEach(func() {
+			path = "/debug/output?handle=some-handle&process=some-process&stream=stdin"
+		})
+
+		It("responds with bad request", func() {
+			Expect(recorder.Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+
+	Context("when attaching fails", func() {
+		BeforeEach(func() {
+			fakeAttacher.AttachReplayingReturns(nil, errors.New("not logged"))
+		})
+
+		It("responds with not found", func() {
+			Expect(recorder.Code).To(Equal(http.StatusNotFound))
+			Expect(recorder.Body.String()).To(ContainSubstring("not logged"))
+		})
+	})
+})
@@ -0,0 +1,55 @@
+package rundmc
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter . ContainerProcesses
+
+type ContainerProcesses interface {
+	Handles() ([]string, error)
+	Processes(log lager.Logger, handle string) ([]gardener.ProcessInfo, error)
+}
+
+type processesHandler struct {
+	log       lager.Logger
+	processes ContainerProcesses
+}
+
+// NewProcessesHandler serves the processes of containers as JSON, keyed by
+// handle. The handle query parameter, which may be repeated, narrows down the
+// response. Containers whose processes cannot be listed, e.g. because they
+// were destroyed meanwhile, are left out.
+func NewProcessesHandler(log lager.Logger, processes ContainerProcesses) http.Handler {
+	return &processesHandler{log: log, processes: processes}
+}
+
+func (h *processesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	handles := r.URL.Query()["handle"]
+	if len(handles) == 0 {
+		var err error
+		handles, err = h.processes.Handles()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	processes := make(map[string][]gardener.ProcessInfo, len(handles))
+	for _, handle := range handles {
+		list, err := h.processes.Processes(h.log, handle)
+		if err != nil {
+			h.log.Error("list-processes-failed", err, lager.Data{"handle": handle})
+			continue
+		}
+
+		processes[handle] = list
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(processes)
+}
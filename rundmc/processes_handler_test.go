@@ -0,0 +1,101 @@
+package rundmc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/rundmc"
+	fakes "code.cloudfoundry.org/guardian/rundmc/rundmcfakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProcessesHandler", func() {
+	var (
+		fakeProcesses *fakes.FakeContainerProcesses
+		recorder      *httptest.ResponseRecorder
+		path          string
+	)
+
+	BeforeEach(func() {
+		fakeProcesses = new(fakes.FakeContainerProcesses)
+		fakeProcesses.HandlesReturns([]string{"foo", "bar"}, nil)
+		fakeProcesses.ProcessesStub = func(_ lager.Logger, handle string) ([]gardener.ProcessInfo, error) {
+			return []gardener.ProcessInfo{{ID: handle + "-process", Args: []string{"sleep", "10"}}}, nil
+		}
+
+		recorder = httptest.NewRecorder()
+		path = "/debug/processes"
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequest("GET", path, nil)
+		Expect(err).NotTo(HaveOccurred())
+
+		rundmc.NewProcessesHandler(lagertest.NewTestLogger("test"), fakeProcesses).ServeHTTP(recorder, req)
+	})
+
+	decode := func() map[string][]gardener.ProcessInfo {
+		var processes map[string][]gardener.ProcessInfo
+		Expect(json.NewDecoder(recorder.Body).Decode(&processes)).To(Succeed())
+		return processes
+	}
+
+	It("serves the processes of every container as JSON", func() {
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+		Expect(recorder.Header().Get("Content-Type")).To(Equal("application/json"))
+
+		processes := decode()
+		Expect(processes).To(HaveLen(2))
+		Expect(processes["foo"][0].ID).To(Equal("foo-process"))
+		Expect(processes["bar"][0].Args).To(Equal([]string{"sleep", "10"}))
+	})
+
+	Context("when handles are given", func() {
+		BeforeEach(func() {
+			path = "/debug/processes?handle=bar"
+		})
+
+		It("only serves the processes of those containers", func() {
+			processes := decode()
+			Expect(processes).To(HaveLen(1))
+			Expect(processes).To(HaveKey("bar"))
+			Expect(fakeProcesses.HandlesCallCount()).To(Equal(0))
+		})
+	})
+
+	Context("when the processes of a container cannot be listed", func() {
+		BeforeEach(func() {
+			fakeProcesses.ProcessesStub = func(_ lager.Logger, handle string) ([]gardener.ProcessInfo, error) {
+				if handle == "foo" {
+					return nil, errors.New("no such container")
+				}
+
+				return []gardener.ProcessInfo{{ID: handle + "-process"}}, nil
+			}
+		})
+
+		It("serves the processes of the other containers", func() {
+			Expect(recorder.Code).To(Equal(http.StatusOK))
+
+			processes := decode()
+			Expect(processes).To(HaveLen(1))
+			Expect(processes["bar"][0].ID).To(Equal("bar-process"))
+		})
+	})
+
+	Context("when listing handles fails", func() {
+		BeforeEach(func() {
+			fakeProcesses.HandlesReturns(nil, errors.New("depot gone"))
+		})
+
+		It("responds with 500", func() {
+			Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+		})
+	})
+})
@@ -0,0 +1,117 @@
+// This file was generated by counterfeiter
+package rundmcfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/rundmc"
+	"code.cloudfoundry.org/lager"
+)
+
+type FakeContainerProcesses struct {
+	HandlesStub        func() ([]string, error)
+	handlesMutex       sync.RWMutex
+	handlesArgsForCall []struct{}
+	handlesReturns     struct {
+		result1 []string
+		result2 error
+	}
+	ProcessesStub        func(log lager.Logger, handle string) ([]gardener.ProcessInfo, error)
+	processesMutex       sync.RWMutex
+	processesArgsForCall []struct {
+		log    lager.Logger
+		handle string
+	}
+	processesReturns struct {
+		result1 []gardener.ProcessInfo
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeContainerProcesses) Handles() ([]string, error) {
+	fake.handlesMutex.Lock()
+	fake.handlesArgsForCall = append(fake.handlesArgsForCall, struct{}{})
+	fake.recordInvocation("Handles", []interface{}{})
+	fake.handlesMutex.Unlock()
+	if fake.HandlesStub != nil {
+		return fake.HandlesStub()
+	} else {
+		return fake.handlesReturns.result1, fake.handlesReturns.result2
+	}
+}
+
+func (fake *FakeContainerProcesses) HandlesCallCount() int {
+	fake.handlesMutex.RLock()
+	defer fake.handlesMutex.RUnlock()
+	return len(fake.handlesArgsForCall)
+}
+
+func (fake *FakeContainerProcesses) HandlesReturns(result1 []string, result2 error) {
+	fake.HandlesStub = nil
+	fake.handlesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainerProcesses) Processes(log lager.Logger, handle string) ([]gardener.ProcessInfo, error) {
+	fake.processesMutex.Lock()
+	fake.processesArgsForCall = append(fake.processesArgsForCall, struct {
+		log    lager.Logger
+		handle string
+	}{log, handle})
+	fake.recordInvocation("Processes", []interface{}{log, handle})
+	fake.processesMutex.Unlock()
+	if fake.ProcessesStub != nil {
+		return fake.ProcessesStub(log, handle)
+	} else {
+		return fake.processesReturns.result1, fake.processesReturns.result2
+	}
+}
+
+func (fake *FakeContainerProcesses) ProcessesCallCount() int {
+	fake.processesMutex.RLock()
+	defer fake.processesMutex.RUnlock()
+	return len(fake.processesArgsForCall)
+}
+
+func (fake *FakeContainerProcesses) ProcessesArgsForCall(i int) (lager.Logger, string) {
+	fake.processesMutex.RLock()
+	defer fake.processesMutex.RUnlock()
+	return fake.processesArgsForCall[i].log, fake.processesArgsForCall[i].handle
+}
+
+func (fake *FakeContainerProcesses) ProcessesReturns(result1 []gardener.ProcessInfo, result2 error) {
+	fake.ProcessesStub = nil
+	fake.processesReturns = struct {
+		result1 []gardener.ProcessInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeContainerProcesses) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.handlesMutex.RLock()
+	defer fake.handlesMutex.RUnlock()
+	fake.processesMutex.RLock()
+	defer fake.processesMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeContainerProcesses) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ rundmc.ContainerProcesses = new(FakeContainerProcesses)
@@ -0,0 +1,113 @@
+// This file was generated by counterfeiter
+package rundmcfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/guardian/rundmc"
+)
+
+type FakeEventHistories struct {
+	HandlesStub        func() ([]string, error)
+	handlesMutex       sync.RWMutex
+	handlesArgsForCall []struct{}
+	handlesReturns     struct {
+		result1 []string
+		result2 error
+	}
+	EventsStub        func(handle string, query rundmc.EventQuery) rundmc.EventHistory
+	eventsMutex       sync.RWMutex
+	eventsArgsForCall []struct {
+		handle string
+		query  rundmc.EventQuery
+	}
+	eventsReturns struct {
+		result1 rundmc.EventHistory
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeEventHistories) Handles() ([]string, error) {
+	fake.handlesMutex.Lock()
+	fake.handlesArgsForCall = append(fake.handlesArgsForCall, struct{}{})
+	fake.recordInvocation("Handles", []interface{}{})
+	fake.handlesMutex.Unlock()
+	if fake.HandlesStub != nil {
+		return fake.HandlesStub()
+	} else {
+		return fake.handlesReturns.result1, fake.handlesReturns.result2
+	}
+}
+
+func (fake *FakeEventHistories) HandlesCallCount() int {
+	fake.handlesMutex.RLock()
+	defer fake.handlesMutex.RUnlock()
+	return len(fake.handlesArgsForCall)
+}
+
+func (fake *FakeEventHistories) HandlesReturns(result1 []string, result2 error) {
+	fake.HandlesStub = nil
+	fake.handlesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeEventHistories) Events(handle string, query rundmc.EventQuery) rundmc.EventHistory {
+	fake.eventsMutex.Lock()
+	fake.eventsArgsForCall = append(fake.eventsArgsForCall, struct {
+		handle string
+		query  rundmc.EventQuery
+	}{handle, query})
+	fake.recordInvocation("Events", []interface{}{handle, query})
+	fake.eventsMutex.Unlock()
+	if fake.EventsStub != nil {
+		return fake.EventsStub(handle, query)
+	} else {
+		return fake.eventsReturns.result1
+	}
+}
+
+func (fake *FakeEventHistories) EventsCallCount() int {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return len(fake.eventsArgsForCall)
+}
+
+func (fake *FakeEventHistories) EventsArgsForCall(i int) (string, rundmc.EventQuery) {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return fake.eventsArgsForCall[i].handle, fake.eventsArgsForCall[i].query
+}
+
+func (fake *FakeEventHistories) EventsReturns(result1 rundmc.EventHistory) {
+	fake.EventsStub = nil
+	fake.eventsReturns = struct {
+		result1 rundmc.EventHistory
+	}{result1}
+}
+
+func (fake *FakeEventHistories) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.handlesMutex.RLock()
+	defer fake.handlesMutex.RUnlock()
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeEventHistories) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ rundmc.EventHistories = new(FakeEventHistories)
@@ -25,6 +25,15 @@ type FakeEventStore struct {
 	eventsReturns struct {
 		result1 []string
 	}
+	QueryStub        func(id string, query rundmc.EventQuery) rundmc.EventHistory
+	queryMutex       sync.RWMutex
+	queryArgsForCall []struct {
+		id    string
+		query rundmc.EventQuery
+	}
+	queryReturns struct {
+		result1 rundmc.EventHistory
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -96,6 +105,40 @@ func (fake *FakeEventStore) EventsReturns(result1 []string) {
 	}{result1}
 }
 
+func (fake *FakeEventStore) Query(id string, query rundmc.EventQuery) rundmc.EventHistory {
+	fake.queryMutex.Lock()
+	fake.queryArgsForCall = append(fake.queryArgsForCall, struct {
+		id    string
+		query rundmc.EventQuery
+	}{id, query})
+	fake.recordInvocation("Query", []interface{}{id, query})
+	fake.queryMutex.Unlock()
+	if fake.QueryStub != nil {
+		return fake.QueryStub(id, query)
+	} else {
+		return fake.queryReturns.result1
+	}
+}
+
+func (fake *FakeEventStore) QueryCallCount() int {
+	fake.queryMutex.RLock()
+	defer fake.queryMutex.RUnlock()
+	return len(fake.queryArgsForCall)
+}
+
+func (fake *FakeEventStore) QueryArgsForCall(i int) (string, rundmc.EventQuery) {
+	fake.queryMutex.RLock()
+	defer fake.queryMutex.RUnlock()
+	return fake.queryArgsForCall[i].id, fake.queryArgsForCall[i].query
+}
+
+func (fake *FakeEventStore) QueryReturns(result1 rundmc.EventHistory) {
+	fake.QueryStub = nil
+	fake.queryReturns = struct {
+		result1 rundmc.EventHistory
+	}{result1}
+}
+
 func (fake *FakeEventStore) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -103,6 +146,8 @@ func (fake *FakeEventStore) Invocations() map[string][][]interface{} {
 	defer fake.onEventMutex.RUnlock()
 	fake.eventsMutex.RLock()
 	defer fake.eventsMutex.RUnlock()
+	fake.queryMutex.RLock()
+	defer fake.queryMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,80 @@
+// This file was generated by counterfeiter
+package rundmcfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/guardian/rundmc"
+)
+
+type FakeInitProcessChecker struct {
+	IsInitProcessStub        func(id string, pid int) (bool, error)
+	isInitProcessMutex       sync.RWMutex
+	isInitProcessArgsForCall []struct {
+		id  string
+		pid int
+	}
+	isInitProcessReturns struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeInitProcessChecker) IsInitProcess(id string, pid int) (bool, error) {
+	fake.isInitProcessMutex.Lock()
+	fake.isInitProcessArgsForCall = append(fake.isInitProcessArgsForCall, struct {
+		id  string
+		pid int
+	}{id, pid})
+	fake.recordInvocation("IsInitProcess", []interface{}{id, pid})
+	fake.isInitProcessMutex.Unlock()
+	if fake.IsInitProcessStub != nil {
+		return fake.IsInitProcessStub(id, pid)
+	} else {
+		return fake.isInitProcessReturns.result1, fake.isInitProcessReturns.result2
+	}
+}
+
+func (fake *FakeInitProcessChecker) IsInitProcessCallCount() int {
+	fake.isInitProcessMutex.RLock()
+	defer fake.isInitProcessMutex.RUnlock()
+	return len(fake.isInitProcessArgsForCall)
+}
+
+func (fake *FakeInitProcessChecker) IsInitProcessArgsForCall(i int) (string, int) {
+	fake.isInitProcessMutex.RLock()
+	defer fake.isInitProcessMutex.RUnlock()
+	return fake.isInitProcessArgsForCall[i].id, fake.isInitProcessArgsForCall[i].pid
+}
+
+func (fake *FakeInitProcessChecker) IsInitProcessReturns(result1 bool, result2 error) {
+	fake.IsInitProcessStub = nil
+	fake.isInitProcessReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeInitProcessChecker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.isInitProcessMutex.RLock()
+	defer fake.isInitProcessMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeInitProcessChecker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ rundmc.InitProcessChecker = new(FakeInitProcessChecker)
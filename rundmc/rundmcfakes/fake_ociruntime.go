@@ -9,6 +9,7 @@ import (
 	"code.cloudfoundry.org/guardian/rundmc"
 	"code.cloudfoundry.org/guardian/rundmc/runrunc"
 	"code.cloudfoundry.org/lager"
+	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
 type FakeOCIRuntime struct {
@@ -23,12 +24,12 @@ type FakeOCIRuntime struct {
 	createReturns struct {
 		result1 error
 	}
-	ExecStub        func(log lager.Logger, id, bundlePath string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error)
+	ExecStub        func(log lager.Logger, bundlePath, id string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error)
 	execMutex       sync.RWMutex
 	execArgsForCall []struct {
 		log        lager.Logger
-		id         string
 		bundlePath string
+		id         string
 		spec       garden.ProcessSpec
 		io         garden.ProcessIO
 	}
@@ -36,33 +37,34 @@ type FakeOCIRuntime struct {
 		result1 garden.Process
 		result2 error
 	}
-	AttachStub        func(log lager.Logger, id, bundlePath, processId string, io garden.ProcessIO) (garden.Process, error)
+	AttachStub        func(log lager.Logger, bundlePath, id, processId string, replay *gardener.OutputOffsets, io garden.ProcessIO) (garden.Process, error)
 	attachMutex       sync.RWMutex
 	attachArgsForCall []struct {
 		log        lager.Logger
-		id         string
 		bundlePath string
+		id         string
 		processId  string
+		replay     *gardener.OutputOffsets
 		io         garden.ProcessIO
 	}
 	attachReturns struct {
 		result1 garden.Process
 		result2 error
 	}
-	KillStub        func(log lager.Logger, bundlePath string) error
+	KillStub        func(log lager.Logger, handle string) error
 	killMutex       sync.RWMutex
 	killArgsForCall []struct {
-		log        lager.Logger
-		bundlePath string
+		log    lager.Logger
+		handle string
 	}
 	killReturns struct {
 		result1 error
 	}
-	DeleteStub        func(log lager.Logger, bundlePath string) error
+	DeleteStub        func(log lager.Logger, handle string) error
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
-		log        lager.Logger
-		bundlePath string
+		log    lager.Logger
+		handle string
 	}
 	deleteReturns struct {
 		result1 error
@@ -97,6 +99,55 @@ type FakeOCIRuntime struct {
 	watchEventsReturns struct {
 		result1 error
 	}
+	UpdateResourcesStub        func(log lager.Logger, id string, resources specs.LinuxResources) error
+	updateResourcesMutex       sync.RWMutex
+	updateResourcesArgsForCall []struct {
+		log       lager.Logger
+		id        string
+		resources specs.LinuxResources
+	}
+	updateResourcesReturns struct {
+		result1 error
+	}
+	PauseStub        func(log lager.Logger, id string) error
+	pauseMutex       sync.RWMutex
+	pauseArgsForCall []struct {
+		log lager.Logger
+		id  string
+	}
+	pauseReturns struct {
+		result1 error
+	}
+	ResumeStub        func(log lager.Logger, id string) error
+	resumeMutex       sync.RWMutex
+	resumeArgsForCall []struct {
+		log lager.Logger
+		id  string
+	}
+	resumeReturns struct {
+		result1 error
+	}
+	CheckpointStub        func(log lager.Logger, id, imagePath string) error
+	checkpointMutex       sync.RWMutex
+	checkpointArgsForCall []struct {
+		log       lager.Logger
+		id        string
+		imagePath string
+	}
+	checkpointReturns struct {
+		result1 error
+	}
+	RestoreStub        func(log lager.Logger, bundlePath, id, imagePath string) error
+	restoreMutex       sync.RWMutex
+	restoreArgsForCall []struct {
+		log        lager.Logger
+		bundlePath string
+		id         string
+		imagePath  string
+	}
+	restoreReturns struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -137,19 +188,19 @@ func (fake *FakeOCIRuntime) CreateReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakeOCIRuntime) Exec(log lager.Logger, id string, bundlePath string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+func (fake *FakeOCIRuntime) Exec(log lager.Logger, bundlePath string, id string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
 	fake.execMutex.Lock()
 	fake.execArgsForCall = append(fake.execArgsForCall, struct {
 		log        lager.Logger
-		id         string
 		bundlePath string
+		id         string
 		spec       garden.ProcessSpec
 		io         garden.ProcessIO
-	}{log, id, bundlePath, spec, io})
-	fake.recordInvocation("Exec", []interface{}{log, id, bundlePath, spec, io})
+	}{log, bundlePath, id, spec, io})
+	fake.recordInvocation("Exec", []interface{}{log, bundlePath, id, spec, io})
 	fake.execMutex.Unlock()
 	if fake.ExecStub != nil {
-		return fake.ExecStub(log, id, bundlePath, spec, io)
+		return fake.ExecStub(log, bundlePath, id, spec, io)
 	} else {
 		return fake.execReturns.result1, fake.execReturns.result2
 	}
@@ -164,7 +215,7 @@ func (fake *FakeOCIRuntime) ExecCallCount() int {
 func (fake *FakeOCIRuntime) ExecArgsForCall(i int) (lager.Logger, string, string, garden.ProcessSpec, garden.ProcessIO) {
 	fake.execMutex.RLock()
 	defer fake.execMutex.RUnlock()
-	return fake.execArgsForCall[i].log, fake.execArgsForCall[i].id, fake.execArgsForCall[i].bundlePath, fake.execArgsForCall[i].spec, fake.execArgsForCall[i].io
+	return fake.execArgsForCall[i].log, fake.execArgsForCall[i].bundlePath, fake.execArgsForCall[i].id, fake.execArgsForCall[i].spec, fake.execArgsForCall[i].io
 }
 
 func (fake *FakeOCIRuntime) ExecReturns(result1 garden.Process, result2 error) {
@@ -175,19 +226,20 @@ func (fake *FakeOCIRuntime) ExecReturns(result1 garden.Process, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *FakeOCIRuntime) Attach(log lager.Logger, id string, bundlePath string, processId string, io garden.ProcessIO) (garden.Process, error) {
+func (fake *FakeOCIRuntime) Attach(log lager.Logger, bundlePath string, id string, processId string, replay *gardener.OutputOffsets, io garden.ProcessIO) (garden.Process, error) {
 	fake.attachMutex.Lock()
 	fake.attachArgsForCall = append(fake.attachArgsForCall, struct {
 		log        lager.Logger
-		id         string
 		bundlePath string
+		id         string
 		processId  string
+		replay     *gardener.OutputOffsets
 		io         garden.ProcessIO
-	}{log, id, bundlePath, processId, io})
-	fake.recordInvocation("Attach", []interface{}{log, id, bundlePath, processId, io})
+	}{log, bundlePath, id, processId, replay, io})
+	fake.recordInvocation("Attach", []interface{}{log, bundlePath, id, processId, replay, io})
 	fake.attachMutex.Unlock()
 	if fake.AttachStub != nil {
-		return fake.AttachStub(log, id, bundlePath, processId, io)
+		return fake.AttachStub(log, bundlePath, id, processId, replay, io)
 	} else {
 		return fake.attachReturns.result1, fake.attachReturns.result2
 	}
@@ -199,10 +251,10 @@ func (fake *FakeOCIRuntime) AttachCallCount() int {
 	return len(fake.attachArgsForCall)
 }
 
-func (fake *FakeOCIRuntime) AttachArgsForCall(i int) (lager.Logger, string, string, string, garden.ProcessIO) {
+func (fake *FakeOCIRuntime) AttachArgsForCall(i int) (lager.Logger, string, string, string, *gardener.OutputOffsets, garden.ProcessIO) {
 	fake.attachMutex.RLock()
 	defer fake.attachMutex.RUnlock()
-	return fake.attachArgsForCall[i].log, fake.attachArgsForCall[i].id, fake.attachArgsForCall[i].bundlePath, fake.attachArgsForCall[i].processId, fake.attachArgsForCall[i].io
+	return fake.attachArgsForCall[i].log, fake.attachArgsForCall[i].bundlePath, fake.attachArgsForCall[i].id, fake.attachArgsForCall[i].processId, fake.attachArgsForCall[i].replay, fake.attachArgsForCall[i].io
 }
 
 func (fake *FakeOCIRuntime) AttachReturns(result1 garden.Process, result2 error) {
@@ -213,16 +265,16 @@ func (fake *FakeOCIRuntime) AttachReturns(result1 garden.Process, result2 error)
 	}{result1, result2}
 }
 
-func (fake *FakeOCIRuntime) Kill(log lager.Logger, bundlePath string) error {
+func (fake *FakeOCIRuntime) Kill(log lager.Logger, handle string) error {
 	fake.killMutex.Lock()
 	fake.killArgsForCall = append(fake.killArgsForCall, struct {
-		log        lager.Logger
-		bundlePath string
-	}{log, bundlePath})
-	fake.recordInvocation("Kill", []interface{}{log, bundlePath})
+		log    lager.Logger
+		handle string
+	}{log, handle})
+	fake.recordInvocation("Kill", []interface{}{log, handle})
 	fake.killMutex.Unlock()
 	if fake.KillStub != nil {
-		return fake.KillStub(log, bundlePath)
+		return fake.KillStub(log, handle)
 	} else {
 		return fake.killReturns.result1
 	}
@@ -237,7 +289,7 @@ func (fake *FakeOCIRuntime) KillCallCount() int {
 func (fake *FakeOCIRuntime) KillArgsForCall(i int) (lager.Logger, string) {
 	fake.killMutex.RLock()
 	defer fake.killMutex.RUnlock()
-	return fake.killArgsForCall[i].log, fake.killArgsForCall[i].bundlePath
+	return fake.killArgsForCall[i].log, fake.killArgsForCall[i].handle
 }
 
 func (fake *FakeOCIRuntime) KillReturns(result1 error) {
@@ -247,16 +299,16 @@ func (fake *FakeOCIRuntime) KillReturns(result1 error) {
 	}{result1}
 }
 
-func (fake *FakeOCIRuntime) Delete(log lager.Logger, bundlePath string) error {
+func (fake *FakeOCIRuntime) Delete(log lager.Logger, handle string) error {
 	fake.deleteMutex.Lock()
 	fake.deleteArgsForCall = append(fake.deleteArgsForCall, struct {
-		log        lager.Logger
-		bundlePath string
-	}{log, bundlePath})
-	fake.recordInvocation("Delete", []interface{}{log, bundlePath})
+		log    lager.Logger
+		handle string
+	}{log, handle})
+	fake.recordInvocation("Delete", []interface{}{log, handle})
 	fake.deleteMutex.Unlock()
 	if fake.DeleteStub != nil {
-		return fake.DeleteStub(log, bundlePath)
+		return fake.DeleteStub(log, handle)
 	} else {
 		return fake.deleteReturns.result1
 	}
@@ -271,7 +323,7 @@ func (fake *FakeOCIRuntime) DeleteCallCount() int {
 func (fake *FakeOCIRuntime) DeleteArgsForCall(i int) (lager.Logger, string) {
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
-	return fake.deleteArgsForCall[i].log, fake.deleteArgsForCall[i].bundlePath
+	return fake.deleteArgsForCall[i].log, fake.deleteArgsForCall[i].handle
 }
 
 func (fake *FakeOCIRuntime) DeleteReturns(result1 error) {
@@ -386,6 +438,180 @@ func (fake *FakeOCIRuntime) WatchEventsReturns(result1 error) {
 	}{result1}
 }
 
+func (fake *FakeOCIRuntime) UpdateResources(log lager.Logger, id string, resources specs.LinuxResources) error {
+	fake.updateResourcesMutex.Lock()
+	fake.updateResourcesArgsForCall = append(fake.updateResourcesArgsForCall, struct {
+		log       lager.Logger
+		id        string
+		resources specs.LinuxResources
+	}{log, id, resources})
+	fake.recordInvocation("UpdateResources", []interface{}{log, id, resources})
+	fake.updateResourcesMutex.Unlock()
+	if fake.UpdateResourcesStub != nil {
+		return fake.UpdateResourcesStub(log, id, resources)
+	} else {
+		return fake.updateResourcesReturns.result1
+	}
+}
+
+func (fake *FakeOCIRuntime) UpdateResourcesCallCount() int {
+	fake.updateResourcesMutex.RLock()
+	defer fake.updateResourcesMutex.RUnlock()
+	return len(fake.updateResourcesArgsForCall)
+}
+
+func (fake *FakeOCIRuntime) UpdateResourcesArgsForCall(i int) (lager.Logger, string, specs.LinuxResources) {
+	fake.updateResourcesMutex.RLock()
+	defer fake.updateResourcesMutex.RUnlock()
+	return fake.updateResourcesArgsForCall[i].log, fake.updateResourcesArgsForCall[i].id, fake.updateResourcesArgsForCall[i].resources
+}
+
+func (fake *FakeOCIRuntime) UpdateResourcesReturns(result1 error) {
+	fake.UpdateResourcesStub = nil
+	fake.updateResourcesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOCIRuntime) Pause(log lager.Logger, id string) error {
+	fake.pauseMutex.Lock()
+	fake.pauseArgsForCall = append(fake.pauseArgsForCall, struct {
+		log lager.Logger
+		id  string
+	}{log, id})
+	fake.recordInvocation("Pause", []interface{}{log, id})
+	fake.pauseMutex.Unlock()
+	if fake.PauseStub != nil {
+		return fake.PauseStub(log, id)
+	} else {
+		return fake.pauseReturns.result1
+	}
+}
+
+func (fake *FakeOCIRuntime) PauseCallCount() int {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return len(fake.pauseArgsForCall)
+}
+
+func (fake *FakeOCIRuntime) PauseArgsForCall(i int) (lager.Logger, string) {
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	return fake.pauseArgsForCall[i].log, fake.pauseArgsForCall[i].id
+}
+
+func (fake *FakeOCIRuntime) PauseReturns(result1 error) {
+	fake.PauseStub = nil
+	fake.pauseReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOCIRuntime) Resume(log lager.Logger, id string) error {
+	fake.resumeMutex.Lock()
+	fake.resumeArgsForCall = append(fake.resumeArgsForCall, struct {
+		log lager.Logger
+		id  string
+	}{log, id})
+	fake.recordInvocation("Resume", []interface{}{log, id})
+	fake.resumeMutex.Unlock()
+	if fake.ResumeStub != nil {
+		return fake.ResumeStub(log, id)
+	} else {
+		return fake.resumeReturns.result1
+	}
+}
+
+func (fake *FakeOCIRuntime) ResumeCallCount() int {
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	return len(fake.resumeArgsForCall)
+}
+
+func (fake *FakeOCIRuntime) ResumeArgsForCall(i int) (lager.Logger, string) {
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	return fake.resumeArgsForCall[i].log, fake.resumeArgsForCall[i].id
+}
+
+func (fake *FakeOCIRuntime) ResumeReturns(result1 error) {
+	fake.ResumeStub = nil
+	fake.resumeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOCIRuntime) Checkpoint(log lager.Logger, id string, imagePath string) error {
+	fake.checkpointMutex.Lock()
+	fake.checkpointArgsForCall = append(fake.checkpointArgsForCall, struct {
+		log       lager.Logger
+		id        string
+		imagePath string
+	}{log, id, imagePath})
+	fake.recordInvocation("Checkpoint", []interface{}{log, id, imagePath})
+	fake.checkpointMutex.Unlock()
+	if fake.CheckpointStub != nil {
+		return fake.CheckpointStub(log, id, imagePath)
+	} else {
+		return fake.checkpointReturns.result1
+	}
+}
+
+func (fake *FakeOCIRuntime) CheckpointCallCount() int {
+	fake.checkpointMutex.RLock()
+	defer fake.checkpointMutex.RUnlock()
+	return len(fake.checkpointArgsForCall)
+}
+
+func (fake *FakeOCIRuntime) CheckpointArgsForCall(i int) (lager.Logger, string, string) {
+	fake.checkpointMutex.RLock()
+	defer fake.checkpointMutex.RUnlock()
+	return fake.checkpointArgsForCall[i].log, fake.checkpointArgsForCall[i].id, fake.checkpointArgsForCall[i].imagePath
+}
+
+func (fake *FakeOCIRuntime) CheckpointReturns(result1 error) {
+	fake.CheckpointStub = nil
+	fake.checkpointReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeOCIRuntime) Restore(log lager.Logger, bundlePath string, id string, imagePath string) error {
+	fake.restoreMutex.Lock()
+	fake.restoreArgsForCall = append(fake.restoreArgsForCall, struct {
+		log        lager.Logger
+		bundlePath string
+		id         string
+		imagePath  string
+	}{log, bundlePath, id, imagePath})
+	fake.recordInvocation("Restore", []interface{}{log, bundlePath, id, imagePath})
+	fake.restoreMutex.Unlock()
+	if fake.RestoreStub != nil {
+		return fake.RestoreStub(log, bundlePath, id, imagePath)
+	} else {
+		return fake.restoreReturns.result1
+	}
+}
+
+func (fake *FakeOCIRuntime) RestoreCallCount() int {
+	fake.restoreMutex.RLock()
+	defer fake.restoreMutex.RUnlock()
+	return len(fake.restoreArgsForCall)
+}
+
+func (fake *FakeOCIRuntime) RestoreArgsForCall(i int) (lager.Logger, string, string, string) {
+	fake.restoreMutex.RLock()
+	defer fake.restoreMutex.RUnlock()
+	return fake.restoreArgsForCall[i].log, fake.restoreArgsForCall[i].bundlePath, fake.restoreArgsForCall[i].id, fake.restoreArgsForCall[i].imagePath
+}
+
+func (fake *FakeOCIRuntime) RestoreReturns(result1 error) {
+	fake.RestoreStub = nil
+	fake.restoreReturns = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeOCIRuntime) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -405,6 +631,16 @@ func (fake *FakeOCIRuntime) Invocations() map[string][][]interface{} {
 	defer fake.statsMutex.RUnlock()
 	fake.watchEventsMutex.RLock()
 	defer fake.watchEventsMutex.RUnlock()
+	fake.updateResourcesMutex.RLock()
+	defer fake.updateResourcesMutex.RUnlock()
+	fake.pauseMutex.RLock()
+	defer fake.pauseMutex.RUnlock()
+	fake.resumeMutex.RLock()
+	defer fake.resumeMutex.RUnlock()
+	fake.checkpointMutex.RLock()
+	defer fake.checkpointMutex.RUnlock()
+	fake.restoreMutex.RLock()
+	defer fake.restoreMutex.RUnlock()
 	return fake.invocations
 }
 
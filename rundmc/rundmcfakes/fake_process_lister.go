@@ -0,0 +1,79 @@
+// This file was generated by counterfeiter
+package rundmcfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/rundmc"
+)
+
+type FakeProcessLister struct {
+	ListStub        func(processesPath string) ([]gardener.ProcessInfo, error)
+	listMutex       sync.RWMutex
+	listArgsForCall []struct {
+		processesPath string
+	}
+	listReturns struct {
+		result1 []gardener.ProcessInfo
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeProcessLister) List(processesPath string) ([]gardener.ProcessInfo, error) {
+	fake.listMutex.Lock()
+	fake.listArgsForCall = append(fake.listArgsForCall, struct {
+		processesPath string
+	}{processesPath})
+	fake.recordInvocation("List", []interface{}{processesPath})
+	fake.listMutex.Unlock()
+	if fake.ListStub != nil {
+		return fake.ListStub(processesPath)
+	} else {
+		return fake.listReturns.result1, fake.listReturns.result2
+	}
+}
+
+func (fake *FakeProcessLister) ListCallCount() int {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return len(fake.listArgsForCall)
+}
+
+func (fake *FakeProcessLister) ListArgsForCall(i int) string {
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return fake.listArgsForCall[i].processesPath
+}
+
+func (fake *FakeProcessLister) ListReturns(result1 []gardener.ProcessInfo, result2 error) {
+	fake.ListStub = nil
+	fake.listReturns = struct {
+		result1 []gardener.ProcessInfo
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeProcessLister) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.listMutex.RLock()
+	defer fake.listMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeProcessLister) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ rundmc.ProcessLister = new(FakeProcessLister)
@@ -0,0 +1,89 @@
+// This file was generated by counterfeiter
+package rundmcfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/rundmc"
+	"code.cloudfoundry.org/lager"
+)
+
+type FakeReplayingAttacher struct {
+	AttachReplayingStub        func(log lager.Logger, handle, processID string, from gardener.OutputOffsets, io garden.ProcessIO) (garden.Process, error)
+	attachReplayingMutex       sync.RWMutex
+	attachReplayingArgsForCall []struct {
+		log       lager.Logger
+		handle    string
+		processID string
+		from      gardener.OutputOffsets
+		io        garden.ProcessIO
+	}
+	attachReplayingReturns struct {
+		result1 garden.Process
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeReplayingAttacher) AttachReplaying(log lager.Logger, handle string, processID string, from gardener.OutputOffsets, io garden.ProcessIO) (garden.Process, error) {
+	fake.attachReplayingMutex.Lock()
+	fake.attachReplayingArgsForCall = append(fake.attachReplayingArgsForCall, struct {
+		log       lager.Logger
+		handle    string
+		processID string
+		from      gardener.OutputOffsets
+		io        garden.ProcessIO
+	}{log, handle, processID, from, io})
+	fake.recordInvocation("AttachReplaying", []interface{}{log, handle, processID, from, io})
+	fake.attachReplayingMutex.Unlock()
+	if fake.AttachReplayingStub != nil {
+		return fake.AttachReplayingStub(log, handle, processID, from, io)
+	} else {
+		return fake.attachReplayingReturns.result1, fake.attachReplayingReturns.result2
+	}
+}
+
+func (fake *FakeReplayingAttacher) AttachReplayingCallCount() int {
+	fake.attachReplayingMutex.RLock()
+	defer fake.attachReplayingMutex.RUnlock()
+	return len(fake.attachReplayingArgsForCall)
+}
+
+func (fake *FakeReplayingAttacher) AttachReplayingArgsForCall(i int) (lager.Logger, string, string, gardener.OutputOffsets, garden.ProcessIO) {
+	fake.attachReplayingMutex.RLock()
+	defer fake.attachReplayingMutex.RUnlock()
+	return fake.attachReplayingArgsForCall[i].log, fake.attachReplayingArgsForCall[i].handle, fake.attachReplayingArgsForCall[i].processID, fake.attachReplayingArgsForCall[i].from, fake.attachReplayingArgsForCall[i].io
+}
+
+func (fake *FakeReplayingAttacher) AttachReplayingReturns(result1 garden.Process, result2 error) {
+	fake.AttachReplayingStub = nil
+	fake.attachReplayingReturns = struct {
+		result1 garden.Process
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeReplayingAttacher) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.attachReplayingMutex.RLock()
+	defer fake.attachReplayingMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeReplayingAttacher) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ rundmc.ReplayingAttacher = new(FakeReplayingAttacher)
@@ -0,0 +1,121 @@
+// This file was generated by counterfeiter
+package rundmcfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/rundmc"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+type FakeResourceLimits struct {
+	MemoryStub        func(limits garden.MemoryLimits) specs.LinuxMemory
+	memoryMutex       sync.RWMutex
+	memoryArgsForCall []struct {
+		limits garden.MemoryLimits
+	}
+	memoryReturns struct {
+		result1 specs.LinuxMemory
+	}
+	CPUStub        func(limits garden.CPULimits) specs.LinuxCPU
+	cPUMutex       sync.RWMutex
+	cPUArgsForCall []struct {
+		limits garden.CPULimits
+	}
+	cPUReturns struct {
+		result1 specs.LinuxCPU
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeResourceLimits) Memory(limits garden.MemoryLimits) specs.LinuxMemory {
+	fake.memoryMutex.Lock()
+	fake.memoryArgsForCall = append(fake.memoryArgsForCall, struct {
+		limits garden.MemoryLimits
+	}{limits})
+	fake.recordInvocation("Memory", []interface{}{limits})
+	fake.memoryMutex.Unlock()
+	if fake.MemoryStub != nil {
+		return fake.MemoryStub(limits)
+	} else {
+		return fake.memoryReturns.result1
+	}
+}
+
+func (fake *FakeResourceLimits) MemoryCallCount() int {
+	fake.memoryMutex.RLock()
+	defer fake.memoryMutex.RUnlock()
+	return len(fake.memoryArgsForCall)
+}
+
+func (fake *FakeResourceLimits) MemoryArgsForCall(i int) garden.MemoryLimits {
+	fake.memoryMutex.RLock()
+	defer fake.memoryMutex.RUnlock()
+	return fake.memoryArgsForCall[i].limits
+}
+
+func (fake *FakeResourceLimits) MemoryReturns(result1 specs.LinuxMemory) {
+	fake.MemoryStub = nil
+	fake.memoryReturns = struct {
+		result1 specs.LinuxMemory
+	}{result1}
+}
+
+func (fake *FakeResourceLimits) CPU(limits garden.CPULimits) specs.LinuxCPU {
+	fake.cPUMutex.Lock()
+	fake.cPUArgsForCall = append(fake.cPUArgsForCall, struct {
+		limits garden.CPULimits
+	}{limits})
+	fake.recordInvocation("CPU", []interface{}{limits})
+	fake.cPUMutex.Unlock()
+	if fake.CPUStub != nil {
+		return fake.CPUStub(limits)
+	} else {
+		return fake.cPUReturns.result1
+	}
+}
+
+func (fake *FakeResourceLimits) CPUCallCount() int {
+	fake.cPUMutex.RLock()
+	defer fake.cPUMutex.RUnlock()
+	return len(fake.cPUArgsForCall)
+}
+
+func (fake *FakeResourceLimits) CPUArgsForCall(i int) garden.CPULimits {
+	fake.cPUMutex.RLock()
+	defer fake.cPUMutex.RUnlock()
+	return fake.cPUArgsForCall[i].limits
+}
+
+func (fake *FakeResourceLimits) CPUReturns(result1 specs.LinuxCPU) {
+	fake.CPUStub = nil
+	fake.cPUReturns = struct {
+		result1 specs.LinuxCPU
+	}{result1}
+}
+
+func (fake *FakeResourceLimits) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.memoryMutex.RLock()
+	defer fake.memoryMutex.RUnlock()
+	fake.cPUMutex.RLock()
+	defer fake.cPUMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeResourceLimits) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ rundmc.ResourceLimits = new(FakeResourceLimits)
@@ -21,6 +21,24 @@ type FakeStateStore struct {
 	isStoppedReturns struct {
 		result1 bool
 	}
+	StorePausedStub        func(handle string)
+	storePausedMutex       sync.RWMutex
+	storePausedArgsForCall []struct {
+		handle string
+	}
+	StoreResumedStub        func(handle string)
+	storeResumedMutex       sync.RWMutex
+	storeResumedArgsForCall []struct {
+		handle string
+	}
+	IsPausedStub        func(handle string) bool
+	isPausedMutex       sync.RWMutex
+	isPausedArgsForCall []struct {
+		handle string
+	}
+	isPausedReturns struct {
+		result1 bool
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -82,6 +100,87 @@ func (fake *FakeStateStore) IsStoppedReturns(result1 bool) {
 	}{result1}
 }
 
+func (fake *FakeStateStore) StorePaused(handle string) {
+	fake.storePausedMutex.Lock()
+	fake.storePausedArgsForCall = append(fake.storePausedArgsForCall, struct {
+		handle string
+	}{handle})
+	fake.recordInvocation("StorePaused", []interface{}{handle})
+	fake.storePausedMutex.Unlock()
+	if fake.StorePausedStub != nil {
+		fake.StorePausedStub(handle)
+	}
+}
+
+func (fake *FakeStateStore) StorePausedCallCount() int {
+	fake.storePausedMutex.RLock()
+	defer fake.storePausedMutex.RUnlock()
+	return len(fake.storePausedArgsForCall)
+}
+
+func (fake *FakeStateStore) StorePausedArgsForCall(i int) string {
+	fake.storePausedMutex.RLock()
+	defer fake.storePausedMutex.RUnlock()
+	return fake.storePausedArgsForCall[i].handle
+}
+
+func (fake *FakeStateStore) StoreResumed(handle string) {
+	fake.storeResumedMutex.Lock()
+	fake.storeResumedArgsForCall = append(fake.storeResumedArgsForCall, struct {
+		handle string
+	}{handle})
+	fake.recordInvocation("StoreResumed", []interface{}{handle})
+	fake.storeResumedMutex.Unlock()
+	if fake.StoreResumedStub != nil {
+		fake.StoreResumedStub(handle)
+	}
+}
+
+func (fake *FakeStateStore) StoreResumedCallCount() int {
+	fake.storeResumedMutex.RLock()
+	defer fake.storeResumedMutex.RUnlock()
+	return len(fake.storeResumedArgsForCall)
+}
+
+func (fake *FakeStateStore) StoreResumedArgsForCall(i int) string {
+	fake.storeResumedMutex.RLock()
+	defer fake.storeResumedMutex.RUnlock()
+	return fake.storeResumedArgsForCall[i].handle
+}
+
+func (fake *FakeStateStore) IsPaused(handle string) bool {
+	fake.isPausedMutex.Lock()
+	fake.isPausedArgsForCall = append(fake.isPausedArgsForCall, struct {
+		handle string
+	}{handle})
+	fake.recordInvocation("IsPaused", []interface{}{handle})
+	fake.isPausedMutex.Unlock()
+	if fake.IsPausedStub != nil {
+		return fake.IsPausedStub(handle)
+	} else {
+		return fake.isPausedReturns.result1
+	}
+}
+
+func (fake *FakeStateStore) IsPausedCallCount() int {
+	fake.isPausedMutex.RLock()
+	defer fake.isPausedMutex.RUnlock()
+	return len(fake.isPausedArgsForCall)
+}
+
+func (fake *FakeStateStore) IsPausedArgsForCall(i int) string {
+	fake.isPausedMutex.RLock()
+	defer fake.isPausedMutex.RUnlock()
+	return fake.isPausedArgsForCall[i].handle
+}
+
+func (fake *FakeStateStore) IsPausedReturns(result1 bool) {
+	fake.IsPausedStub = nil
+	fake.isPausedReturns = struct {
+		result1 bool
+	}{result1}
+}
+
 func (fake *FakeStateStore) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -89,6 +188,12 @@ func (fake *FakeStateStore) Invocations() map[string][][]interface{} {
 	defer fake.storeStoppedMutex.RUnlock()
 	fake.isStoppedMutex.RLock()
 	defer fake.isStoppedMutex.RUnlock()
+	fake.storePausedMutex.RLock()
+	defer fake.storePausedMutex.RUnlock()
+	fake.storeResumedMutex.RLock()
+	defer fake.storeResumedMutex.RUnlock()
+	fake.isPausedMutex.RLock()
+	defer fake.isPausedMutex.RUnlock()
 	return fake.invocations
 }
 
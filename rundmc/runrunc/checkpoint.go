@@ -0,0 +1,43 @@
+package runrunc
+
+import (
+	"os/exec"
+
+	"code.cloudfoundry.org/lager"
+)
+
+type Checkpointer struct {
+	runner RuncCmdRunner
+	runc   RuncBinary
+}
+
+func NewCheckpointer(runner RuncCmdRunner, runc RuncBinary) *Checkpointer {
+	return &Checkpointer{
+		runner: runner,
+		runc:   runc,
+	}
+}
+
+// Checkpoint dumps the container's processes to imagePath using 'runc checkpoint'
+func (c *Checkpointer) Checkpoint(log lager.Logger, handle, imagePath string) error {
+	log = log.Session("checkpoint", lager.Data{"handle": handle, "image-path": imagePath})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	return c.runner.RunAndLog(log, func(logFile string) *exec.Cmd {
+		return c.runc.CheckpointCommand(handle, imagePath, logFile)
+	})
+}
+
+// Restore recreates the container from the checkpoint in imagePath using 'runc restore'
+func (c *Checkpointer) Restore(log lager.Logger, bundlePath, handle, imagePath string) error {
+	log = log.Session("restore", lager.Data{"handle": handle, "bundle": bundlePath, "image-path": imagePath})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	return c.runner.RunAndLog(log, func(logFile string) *exec.Cmd {
+		return c.runc.RestoreCommand(handle, bundlePath, imagePath, logFile)
+	})
+}
@@ -0,0 +1,87 @@
+package runrunc_test
+
+import (
+	"errors"
+	"os/exec"
+
+	"code.cloudfoundry.org/guardian/rundmc/runrunc"
+	fakes "code.cloudfoundry.org/guardian/rundmc/runrunc/runruncfakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/gunk/command_runner/fake_command_runner"
+	. "github.com/cloudfoundry/gunk/command_runner/fake_command_runner/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Checkpoint", func() {
+	var (
+		commandRunner *fake_command_runner.FakeCommandRunner
+		runner        *fakes.FakeRuncCmdRunner
+		runcBinary    *fakes.FakeRuncBinary
+		logger        *lagertest.TestLogger
+
+		checkpointer *runrunc.Checkpointer
+	)
+
+	BeforeEach(func() {
+		runcBinary = new(fakes.FakeRuncBinary)
+		commandRunner = fake_command_runner.New()
+		runner = new(fakes.FakeRuncCmdRunner)
+		logger = lagertest.NewTestLogger("test")
+
+		checkpointer = runrunc.NewCheckpointer(runner, runcBinary)
+
+		runcBinary.CheckpointCommandStub = func(id, imagePath, logFile string) *exec.Cmd {
+			return exec.Command("funC", "--log", logFile, "checkpoint", "--image-path", imagePath, id)
+		}
+
+		runcBinary.RestoreCommandStub = func(id, bundlePath, imagePath, logFile string) *exec.Cmd {
+			return exec.Command("funC", "--log", logFile, "restore", "--image-path", imagePath, "--bundle", bundlePath, id)
+		}
+
+		runner.RunAndLogStub = func(_ lager.Logger, fn runrunc.LoggingCmd) error {
+			return commandRunner.Run(fn("potato.log"))
+		}
+	})
+
+	Describe("Checkpoint", func() {
+		It("runs 'runc checkpoint' with the image path using the logging runner", func() {
+			Expect(checkpointer.Checkpoint(logger, "some-container", "/some/image")).To(Succeed())
+			Expect(commandRunner).To(HaveExecutedSerially(fake_command_runner.CommandSpec{
+				Path: "funC",
+				Args: []string{"--log", "potato.log", "checkpoint", "--image-path", "/some/image", "some-container"},
+			}))
+		})
+
+		Context("when runc checkpoint fails", func() {
+			BeforeEach(func() {
+				runner.RunAndLogReturns(errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				Expect(checkpointer.Checkpoint(logger, "some-container", "/some/image")).To(MatchError("boom"))
+			})
+		})
+	})
+
+	Describe("Restore", func() {
+		It("runs 'runc restore' with the bundle and image paths using the logging runner", func() {
+			Expect(checkpointer.Restore(logger, "/some/bundle", "some-container", "/some/image")).To(Succeed())
+			Expect(commandRunner).To(HaveExecutedSerially(fake_command_runner.CommandSpec{
+				Path: "funC",
+				Args: []string{"--log", "potato.log", "restore", "--image-path", "/some/image", "--bundle", "/some/bundle", "some-container"},
+			}))
+		})
+
+		Context("when runc restore fails", func() {
+			BeforeEach(func() {
+				runner.RunAndLogReturns(errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				Expect(checkpointer.Restore(logger, "/some/bundle", "some-container", "/some/image")).To(MatchError("boom"))
+			})
+		})
+	})
+})
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	"os/exec"
 	"path/filepath"
 
 	"code.cloudfoundry.org/garden"
@@ -13,13 +12,13 @@ import (
 )
 
 type Creator struct {
-	runcPath      string
+	runc          RuncBinary
 	commandRunner command_runner.CommandRunner
 }
 
-func NewCreator(runcPath string, commandRunner command_runner.CommandRunner) *Creator {
+func NewCreator(runc RuncBinary, commandRunner command_runner.CommandRunner) *Creator {
 	return &Creator{
-		runcPath, commandRunner,
+		runc, commandRunner,
 	}
 }
 
@@ -31,10 +30,10 @@ func (c *Creator) Create(log lager.Logger, bundlePath, id string, _ garden.Proce
 	logFilePath := filepath.Join(bundlePath, "create.log")
 	pidFilePath := filepath.Join(bundlePath, "pidfile")
 
-	cmd := exec.Command(c.runcPath, "--debug", "--log", logFilePath, "create", "--no-new-keyring", "--bundle", bundlePath, "--pid-file", pidFilePath, id)
+	cmd := c.runc.CreateCommand(bundlePath, id, logFilePath, pidFilePath)
 
 	log.Info("creating", lager.Data{
-		"runc":        c.runcPath,
+		"runc":        cmd.Path,
 		"bundlePath":  bundlePath,
 		"id":          id,
 		"logPath":     logFilePath,
@@ -10,6 +10,7 @@ import (
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden-shed/rootfs_provider"
+	"code.cloudfoundry.org/guardian/gardener"
 	"code.cloudfoundry.org/guardian/rundmc/goci"
 	"code.cloudfoundry.org/lager"
 	"github.com/opencontainers/runc/libcontainer/user"
@@ -82,22 +83,26 @@ func (e *Execer) Exec(log lager.Logger, bundlePath, id string, spec garden.Proce
 	return e.runner.Run(log, preparedSpec, bundlePath, processesPath, id, spec.TTY, io)
 }
 
-// Attach attaches to an already running process by guid
-func (e *Execer) Attach(log lager.Logger, bundlePath, id, processID string, io garden.ProcessIO) (garden.Process, error) {
+// Attach attaches to an already running process by guid, first replaying its
+// logged output from the given offsets if replay is not nil
+func (e *Execer) Attach(log lager.Logger, bundlePath, id, processID string, replay *gardener.OutputOffsets, io garden.ProcessIO) (garden.Process, error) {
 	processesPath := path.Join(bundlePath, "processes")
-	return e.runner.Attach(log, processID, io, processesPath)
+	return e.runner.Attach(log, processID, replay, io, processesPath)
 }
 
 //go:generate counterfeiter . ExecRunner
 type ExecRunner interface {
 	Run(log lager.Logger, spec *PreparedSpec, bundlePath, processesPath, handle string, tty *garden.TTYSpec, io garden.ProcessIO) (garden.Process, error)
-	Attach(log lager.Logger, processID string, io garden.ProcessIO, processesPath string) (garden.Process, error)
+	Attach(log lager.Logger, processID string, replay *gardener.OutputOffsets, io garden.ProcessIO, processesPath string) (garden.Process, error)
 }
 
 type PreparedSpec struct {
 	specs.Process
 	HostUID int
 	HostGID int
+
+	// The name of the user the process was asked to run as
+	Username string
 }
 
 //go:generate counterfeiter . ExecPreparer
@@ -173,8 +178,9 @@ func (r *execPreparer) Prepare(log lager.Logger, bundlePath string, spec garden.
 	}
 
 	return &PreparedSpec{
-		HostUID: u.hostUid,
-		HostGID: u.hostGid,
+		HostUID:  u.hostUid,
+		HostGID:  u.hostGid,
+		Username: spec.User,
 		Process: specs.Process{
 			Args:        append([]string{spec.Path}, spec.Args...),
 			ConsoleSize: consoleBox,
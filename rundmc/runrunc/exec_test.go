@@ -216,6 +216,10 @@ var _ = Describe("ExecPreparer", func() {
 			It("passes a process.json with the correct user and group ids", func() {
 				Expect(spec.Process.User).To(Equal(specs.User{UID: 9, GID: 7, AdditionalGids: []uint32{}}))
 			})
+
+			It("records the name of the user", func() {
+				Expect(spec.Username).To(Equal("spiderman"))
+			})
 		})
 
 		Context("when the bundle can't be loaded", func() {
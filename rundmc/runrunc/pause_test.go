@@ -0,0 +1,87 @@
+package runrunc_test
+
+import (
+	"errors"
+	"os/exec"
+
+	"code.cloudfoundry.org/guardian/rundmc/runrunc"
+	fakes "code.cloudfoundry.org/guardian/rundmc/runrunc/runruncfakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/gunk/command_runner/fake_command_runner"
+	. "github.com/cloudfoundry/gunk/command_runner/fake_command_runner/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Pause", func() {
+	var (
+		commandRunner *fake_command_runner.FakeCommandRunner
+		runner        *fakes.FakeRuncCmdRunner
+		runcBinary    *fakes.FakeRuncBinary
+		logger        *lagertest.TestLogger
+
+		pauser *runrunc.Pauser
+	)
+
+	BeforeEach(func() {
+		runcBinary = new(fakes.FakeRuncBinary)
+		commandRunner = fake_command_runner.New()
+		runner = new(fakes.FakeRuncCmdRunner)
+		logger = lagertest.NewTestLogger("test")
+
+		pauser = runrunc.NewPauser(runner, runcBinary)
+
+		runcBinary.PauseCommandStub = func(id, logFile string) *exec.Cmd {
+			return exec.Command("funC", "--log", logFile, "pause", id)
+		}
+
+		runcBinary.ResumeCommandStub = func(id, logFile string) *exec.Cmd {
+			return exec.Command("funC", "--log", logFile, "resume", id)
+		}
+
+		runner.RunAndLogStub = func(_ lager.Logger, fn runrunc.LoggingCmd) error {
+			return commandRunner.Run(fn("potato.log"))
+		}
+	})
+
+	Describe("Pause", func() {
+		It("runs 'runc pause' using the logging runner", func() {
+			Expect(pauser.Pause(logger, "some-container")).To(Succeed())
+			Expect(commandRunner).To(HaveExecutedSerially(fake_command_runner.CommandSpec{
+				Path: "funC",
+				Args: []string{"--log", "potato.log", "pause", "some-container"},
+			}))
+		})
+
+		Context("when runc pause fails", func() {
+			BeforeEach(func() {
+				runner.RunAndLogReturns(errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				Expect(pauser.Pause(logger, "some-container")).To(MatchError("boom"))
+			})
+		})
+	})
+
+	Describe("Resume", func() {
+		It("runs 'runc resume' using the logging runner", func() {
+			Expect(pauser.Resume(logger, "some-container")).To(Succeed())
+			Expect(commandRunner).To(HaveExecutedSerially(fake_command_runner.CommandSpec{
+				Path: "funC",
+				Args: []string{"--log", "potato.log", "resume", "some-container"},
+			}))
+		})
+
+		Context("when runc resume fails", func() {
+			BeforeEach(func() {
+				runner.RunAndLogReturns(errors.New("boom"))
+			})
+
+			It("returns the error", func() {
+				Expect(pauser.Resume(logger, "some-container")).To(MatchError("boom"))
+			})
+		})
+	})
+})
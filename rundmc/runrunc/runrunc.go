@@ -21,21 +21,30 @@ type RunRunc struct {
 	*Stater
 	*Killer
 	*Deleter
+	*Updater
+	*Pauser
+	*Checkpointer
 }
 
 //go:generate counterfeiter . RuncBinary
 type RuncBinary interface {
+	CreateCommand(bundlePath, id, logFile, pidFilePath string) *exec.Cmd
 	ExecCommand(id, processJSONPath, pidFilePath string) *exec.Cmd
 	EventsCommand(id string) *exec.Cmd
 	StateCommand(id, logFile string) *exec.Cmd
 	StatsCommand(id, logFile string) *exec.Cmd
 	KillCommand(id, signal, logFile string) *exec.Cmd
 	DeleteCommand(id, logFile string) *exec.Cmd
+	UpdateCommand(id, logFile string) *exec.Cmd
+	PauseCommand(id, logFile string) *exec.Cmd
+	ResumeCommand(id, logFile string) *exec.Cmd
+	CheckpointCommand(id, imagePath, logFile string) *exec.Cmd
+	RestoreCommand(id, bundlePath, imagePath, logFile string) *exec.Cmd
 }
 
-func New(runner command_runner.CommandRunner, runcCmdRunner RuncCmdRunner, runc RuncBinary, dadooPath, runcPath string, execPreparer ExecPreparer, execRunner ExecRunner) *RunRunc {
+func New(runner command_runner.CommandRunner, runcCmdRunner RuncCmdRunner, runc RuncBinary, dadooPath string, execPreparer ExecPreparer, execRunner ExecRunner) *RunRunc {
 	return &RunRunc{
-		Creator: NewCreator(runcPath, runner),
+		Creator: NewCreator(runc, runner),
 		Execer:  NewExecer(execPreparer, execRunner),
 
 		OomWatcher: NewOomWatcher(runner, runc),
@@ -43,5 +52,9 @@ func New(runner command_runner.CommandRunner, runcCmdRunner RuncCmdRunner, runc
 		Stater:     NewStater(runcCmdRunner, runc),
 		Killer:     NewKiller(runcCmdRunner, runc),
 		Deleter:    NewDeleter(runcCmdRunner, runc),
+		Updater:    NewUpdater(runcCmdRunner, runc),
+		Pauser:     NewPauser(runcCmdRunner, runc),
+
+		Checkpointer: NewCheckpointer(runcCmdRunner, runc),
 	}
 }
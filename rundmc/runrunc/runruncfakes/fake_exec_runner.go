@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
 	"code.cloudfoundry.org/guardian/rundmc/runrunc"
 	"code.cloudfoundry.org/lager"
 )
@@ -25,11 +26,12 @@ type FakeExecRunner struct {
 		result1 garden.Process
 		result2 error
 	}
-	AttachStub        func(log lager.Logger, processID string, io garden.ProcessIO, processesPath string) (garden.Process, error)
+	AttachStub        func(log lager.Logger, processID string, replay *gardener.OutputOffsets, io garden.ProcessIO, processesPath string) (garden.Process, error)
 	attachMutex       sync.RWMutex
 	attachArgsForCall []struct {
 		log           lager.Logger
 		processID     string
+		replay        *gardener.OutputOffsets
 		io            garden.ProcessIO
 		processesPath string
 	}
@@ -80,18 +82,19 @@ func (fake *FakeExecRunner) RunReturns(result1 garden.Process, result2 error) {
 	}{result1, result2}
 }
 
-func (fake *FakeExecRunner) Attach(log lager.Logger, processID string, io garden.ProcessIO, processesPath string) (garden.Process, error) {
+func (fake *FakeExecRunner) Attach(log lager.Logger, processID string, replay *gardener.OutputOffsets, io garden.ProcessIO, processesPath string) (garden.Process, error) {
 	fake.attachMutex.Lock()
 	fake.attachArgsForCall = append(fake.attachArgsForCall, struct {
 		log           lager.Logger
 		processID     string
+		replay        *gardener.OutputOffsets
 		io            garden.ProcessIO
 		processesPath string
-	}{log, processID, io, processesPath})
-	fake.recordInvocation("Attach", []interface{}{log, processID, io, processesPath})
+	}{log, processID, replay, io, processesPath})
+	fake.recordInvocation("Attach", []interface{}{log, processID, replay, io, processesPath})
 	fake.attachMutex.Unlock()
 	if fake.AttachStub != nil {
-		return fake.AttachStub(log, processID, io, processesPath)
+		return fake.AttachStub(log, processID, replay, io, processesPath)
 	}
 	return fake.attachReturns.result1, fake.attachReturns.result2
 }
@@ -102,10 +105,10 @@ func (fake *FakeExecRunner) AttachCallCount() int {
 	return len(fake.attachArgsForCall)
 }
 
-func (fake *FakeExecRunner) AttachArgsForCall(i int) (lager.Logger, string, garden.ProcessIO, string) {
+func (fake *FakeExecRunner) AttachArgsForCall(i int) (lager.Logger, string, *gardener.OutputOffsets, garden.ProcessIO, string) {
 	fake.attachMutex.RLock()
 	defer fake.attachMutex.RUnlock()
-	return fake.attachArgsForCall[i].log, fake.attachArgsForCall[i].processID, fake.attachArgsForCall[i].io, fake.attachArgsForCall[i].processesPath
+	return fake.attachArgsForCall[i].log, fake.attachArgsForCall[i].processID, fake.attachArgsForCall[i].replay, fake.attachArgsForCall[i].io, fake.attachArgsForCall[i].processesPath
 }
 
 func (fake *FakeExecRunner) AttachReturns(result1 garden.Process, result2 error) {
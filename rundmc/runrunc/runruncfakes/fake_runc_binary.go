@@ -9,6 +9,17 @@ import (
 )
 
 type FakeRuncBinary struct {
+	CreateCommandStub        func(bundlePath, id, logFile, pidFilePath string) *exec.Cmd
+	createCommandMutex       sync.RWMutex
+	createCommandArgsForCall []struct {
+		bundlePath  string
+		id          string
+		logFile     string
+		pidFilePath string
+	}
+	createCommandReturns struct {
+		result1 *exec.Cmd
+	}
 	ExecCommandStub        func(id, processJSONPath, pidFilePath string) *exec.Cmd
 	execCommandMutex       sync.RWMutex
 	execCommandArgsForCall []struct {
@@ -64,10 +75,93 @@ type FakeRuncBinary struct {
 	deleteCommandReturns struct {
 		result1 *exec.Cmd
 	}
+	UpdateCommandStub        func(id, logFile string) *exec.Cmd
+	updateCommandMutex       sync.RWMutex
+	updateCommandArgsForCall []struct {
+		id      string
+		logFile string
+	}
+	updateCommandReturns struct {
+		result1 *exec.Cmd
+	}
+	PauseCommandStub        func(id, logFile string) *exec.Cmd
+	pauseCommandMutex       sync.RWMutex
+	pauseCommandArgsForCall []struct {
+		id      string
+		logFile string
+	}
+	pauseCommandReturns struct {
+		result1 *exec.Cmd
+	}
+	ResumeCommandStub        func(id, logFile string) *exec.Cmd
+	resumeCommandMutex       sync.RWMutex
+	resumeCommandArgsForCall []struct {
+		id      string
+		logFile string
+	}
+	resumeCommandReturns struct {
+		result1 *exec.Cmd
+	}
+	CheckpointCommandStub        func(id, imagePath, logFile string) *exec.Cmd
+	checkpointCommandMutex       sync.RWMutex
+	checkpointCommandArgsForCall []struct {
+		id        string
+		imagePath string
+		logFile   string
+	}
+	checkpointCommandReturns struct {
+		result1 *exec.Cmd
+	}
+	RestoreCommandStub        func(id, bundlePath, imagePath, logFile string) *exec.Cmd
+	restoreCommandMutex       sync.RWMutex
+	restoreCommandArgsForCall []struct {
+		id         string
+		bundlePath string
+		imagePath  string
+		logFile    string
+	}
+	restoreCommandReturns struct {
+		result1 *exec.Cmd
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeRuncBinary) CreateCommand(bundlePath string, id string, logFile string, pidFilePath string) *exec.Cmd {
+	fake.createCommandMutex.Lock()
+	fake.createCommandArgsForCall = append(fake.createCommandArgsForCall, struct {
+		bundlePath  string
+		id          string
+		logFile     string
+		pidFilePath string
+	}{bundlePath, id, logFile, pidFilePath})
+	fake.recordInvocation("CreateCommand", []interface{}{bundlePath, id, logFile, pidFilePath})
+	fake.createCommandMutex.Unlock()
+	if fake.CreateCommandStub != nil {
+		return fake.CreateCommandStub(bundlePath, id, logFile, pidFilePath)
+	}
+	return fake.createCommandReturns.result1
+}
+
+func (fake *FakeRuncBinary) CreateCommandCallCount() int {
+	fake.createCommandMutex.RLock()
+	defer fake.createCommandMutex.RUnlock()
+	return len(fake.createCommandArgsForCall)
+}
+
+func (fake *FakeRuncBinary) CreateCommandArgsForCall(i int) (string, string, string, string) {
+	fake.createCommandMutex.RLock()
+	defer fake.createCommandMutex.RUnlock()
+	return fake.createCommandArgsForCall[i].bundlePath, fake.createCommandArgsForCall[i].id, fake.createCommandArgsForCall[i].logFile, fake.createCommandArgsForCall[i].pidFilePath
+}
+
+func (fake *FakeRuncBinary) CreateCommandReturns(result1 *exec.Cmd) {
+	fake.CreateCommandStub = nil
+	fake.createCommandReturns = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
 func (fake *FakeRuncBinary) ExecCommand(id string, processJSONPath string, pidFilePath string) *exec.Cmd {
 	fake.execCommandMutex.Lock()
 	fake.execCommandArgsForCall = append(fake.execCommandArgsForCall, struct {
@@ -267,9 +361,179 @@ func (fake *FakeRuncBinary) DeleteCommandReturns(result1 *exec.Cmd) {
 	}{result1}
 }
 
+func (fake *FakeRuncBinary) UpdateCommand(id string, logFile string) *exec.Cmd {
+	fake.updateCommandMutex.Lock()
+	fake.updateCommandArgsForCall = append(fake.updateCommandArgsForCall, struct {
+		id      string
+		logFile string
+	}{id, logFile})
+	fake.recordInvocation("UpdateCommand", []interface{}{id, logFile})
+	fake.updateCommandMutex.Unlock()
+	if fake.UpdateCommandStub != nil {
+		return fake.UpdateCommandStub(id, logFile)
+	}
+	return fake.updateCommandReturns.result1
+}
+
+func (fake *FakeRuncBinary) UpdateCommandCallCount() int {
+	fake.updateCommandMutex.RLock()
+	defer fake.updateCommandMutex.RUnlock()
+	return len(fake.updateCommandArgsForCall)
+}
+
+func (fake *FakeRuncBinary) UpdateCommandArgsForCall(i int) (string, string) {
+	fake.updateCommandMutex.RLock()
+	defer fake.updateCommandMutex.RUnlock()
+	return fake.updateCommandArgsForCall[i].id, fake.updateCommandArgsForCall[i].logFile
+}
+
+func (fake *FakeRuncBinary) UpdateCommandReturns(result1 *exec.Cmd) {
+	fake.UpdateCommandStub = nil
+	fake.updateCommandReturns = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
+func (fake *FakeRuncBinary) PauseCommand(id string, logFile string) *exec.Cmd {
+	fake.pauseCommandMutex.Lock()
+	fake.pauseCommandArgsForCall = append(fake.pauseCommandArgsForCall, struct {
+		id      string
+		logFile string
+	}{id, logFile})
+	fake.recordInvocation("PauseCommand", []interface{}{id, logFile})
+	fake.pauseCommandMutex.Unlock()
+	if fake.PauseCommandStub != nil {
+		return fake.PauseCommandStub(id, logFile)
+	}
+	return fake.pauseCommandReturns.result1
+}
+
+func (fake *FakeRuncBinary) PauseCommandCallCount() int {
+	fake.pauseCommandMutex.RLock()
+	defer fake.pauseCommandMutex.RUnlock()
+	return len(fake.pauseCommandArgsForCall)
+}
+
+func (fake *FakeRuncBinary) PauseCommandArgsForCall(i int) (string, string) {
+	fake.pauseCommandMutex.RLock()
+	defer fake.pauseCommandMutex.RUnlock()
+	return fake.pauseCommandArgsForCall[i].id, fake.pauseCommandArgsForCall[i].logFile
+}
+
+func (fake *FakeRuncBinary) PauseCommandReturns(result1 *exec.Cmd) {
+	fake.PauseCommandStub = nil
+	fake.pauseCommandReturns = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
+func (fake *FakeRuncBinary) ResumeCommand(id string, logFile string) *exec.Cmd {
+	fake.resumeCommandMutex.Lock()
+	fake.resumeCommandArgsForCall = append(fake.resumeCommandArgsForCall, struct {
+		id      string
+		logFile string
+	}{id, logFile})
+	fake.recordInvocation("ResumeCommand", []interface{}{id, logFile})
+	fake.resumeCommandMutex.Unlock()
+	if fake.ResumeCommandStub != nil {
+		return fake.ResumeCommandStub(id, logFile)
+	}
+	return fake.resumeCommandReturns.result1
+}
+
+func (fake *FakeRuncBinary) ResumeCommandCallCount() int {
+	fake.resumeCommandMutex.RLock()
+	defer fake.resumeCommandMutex.RUnlock()
+	return len(fake.resumeCommandArgsForCall)
+}
+
+func (fake *FakeRuncBinary) ResumeCommandArgsForCall(i int) (string, string) {
+	fake.resumeCommandMutex.RLock()
+	defer fake.resumeCommandMutex.RUnlock()
+	return fake.resumeCommandArgsForCall[i].id, fake.resumeCommandArgsForCall[i].logFile
+}
+
+func (fake *FakeRuncBinary) ResumeCommandReturns(result1 *exec.Cmd) {
+	fake.ResumeCommandStub = nil
+	fake.resumeCommandReturns = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
+func (fake *FakeRuncBinary) CheckpointCommand(id string, imagePath string, logFile string) *exec.Cmd {
+	fake.checkpointCommandMutex.Lock()
+	fake.checkpointCommandArgsForCall = append(fake.checkpointCommandArgsForCall, struct {
+		id        string
+		imagePath string
+		logFile   string
+	}{id, imagePath, logFile})
+	fake.recordInvocation("CheckpointCommand", []interface{}{id, imagePath, logFile})
+	fake.checkpointCommandMutex.Unlock()
+	if fake.CheckpointCommandStub != nil {
+		return fake.CheckpointCommandStub(id, imagePath, logFile)
+	}
+	return fake.checkpointCommandReturns.result1
+}
+
+func (fake *FakeRuncBinary) CheckpointCommandCallCount() int {
+	fake.checkpointCommandMutex.RLock()
+	defer fake.checkpointCommandMutex.RUnlock()
+	return len(fake.checkpointCommandArgsForCall)
+}
+
+func (fake *FakeRuncBinary) CheckpointCommandArgsForCall(i int) (string, string, string) {
+	fake.checkpointCommandMutex.RLock()
+	defer fake.checkpointCommandMutex.RUnlock()
+	return fake.checkpointCommandArgsForCall[i].id, fake.checkpointCommandArgsForCall[i].imagePath, fake.checkpointCommandArgsForCall[i].logFile
+}
+
+func (fake *FakeRuncBinary) CheckpointCommandReturns(result1 *exec.Cmd) {
+	fake.CheckpointCommandStub = nil
+	fake.checkpointCommandReturns = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
+func (fake *FakeRuncBinary) RestoreCommand(id string, bundlePath string, imagePath string, logFile string) *exec.Cmd {
+	fake.restoreCommandMutex.Lock()
+	fake.restoreCommandArgsForCall = append(fake.restoreCommandArgsForCall, struct {
+		id         string
+		bundlePath string
+		imagePath  string
+		logFile    string
+	}{id, bundlePath, imagePath, logFile})
+	fake.recordInvocation("RestoreCommand", []interface{}{id, bundlePath, imagePath, logFile})
+	fake.restoreCommandMutex.Unlock()
+	if fake.RestoreCommandStub != nil {
+		return fake.RestoreCommandStub(id, bundlePath, imagePath, logFile)
+	}
+	return fake.restoreCommandReturns.result1
+}
+
+func (fake *FakeRuncBinary) RestoreCommandCallCount() int {
+	fake.restoreCommandMutex.RLock()
+	defer fake.restoreCommandMutex.RUnlock()
+	return len(fake.restoreCommandArgsForCall)
+}
+
+func (fake *FakeRuncBinary) RestoreCommandArgsForCall(i int) (string, string, string, string) {
+	fake.restoreCommandMutex.RLock()
+	defer fake.restoreCommandMutex.RUnlock()
+	return fake.restoreCommandArgsForCall[i].id, fake.restoreCommandArgsForCall[i].bundlePath, fake.restoreCommandArgsForCall[i].imagePath, fake.restoreCommandArgsForCall[i].logFile
+}
+
+func (fake *FakeRuncBinary) RestoreCommandReturns(result1 *exec.Cmd) {
+	fake.RestoreCommandStub = nil
+	fake.restoreCommandReturns = struct {
+		result1 *exec.Cmd
+	}{result1}
+}
+
 func (fake *FakeRuncBinary) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.createCommandMutex.RLock()
+	defer fake.createCommandMutex.RUnlock()
 	fake.execCommandMutex.RLock()
 	defer fake.execCommandMutex.RUnlock()
 	fake.eventsCommandMutex.RLock()
@@ -282,6 +546,16 @@ func (fake *FakeRuncBinary) Invocations() map[string][][]interface{} {
 	defer fake.killCommandMutex.RUnlock()
 	fake.deleteCommandMutex.RLock()
 	defer fake.deleteCommandMutex.RUnlock()
+	fake.updateCommandMutex.RLock()
+	defer fake.updateCommandMutex.RUnlock()
+	fake.pauseCommandMutex.RLock()
+	defer fake.pauseCommandMutex.RUnlock()
+	fake.resumeCommandMutex.RLock()
+	defer fake.resumeCommandMutex.RUnlock()
+	fake.checkpointCommandMutex.RLock()
+	defer fake.checkpointCommandMutex.RUnlock()
+	fake.restoreCommandMutex.RLock()
+	defer fake.restoreCommandMutex.RUnlock()
 	return fake.invocations
 }
 
@@ -0,0 +1,83 @@
+package runrunc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+type stateFile struct {
+	InitProcessPid   int             `json:"init_process_pid"`
+	InitProcessStart json.RawMessage `json:"init_process_start"`
+}
+
+// StateFile reads the state which runc keeps on disk for each container, which
+// is much cheaper than forking `runc state`
+type StateFile struct {
+	// Root is runc's state directory, e.g. /run/runc
+	Root string
+
+	// ProcRoot is where procfs is mounted, /proc when empty
+	ProcRoot string
+}
+
+// IsInitProcess reports whether pid is still the init process of the
+// container. It returns false once the init process has exited, even if its
+// pid has since been reused.
+func (s StateFile) IsInitProcess(id string, pid int) (bool, error) {
+	contents, err := ioutil.ReadFile(filepath.Join(s.Root, id, "state.json"))
+	if err != nil {
+		return false, err
+	}
+
+	var state stateFile
+	if err := json.Unmarshal(contents, &state); err != nil {
+		return false, fmt.Errorf("parse state.json: %s", err)
+	}
+
+	if state.InitProcessPid != pid {
+		return false, nil
+	}
+
+	startTime, err := s.startTime(pid)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return startTime == strings.Trim(string(state.InitProcessStart), `"`), nil
+}
+
+// startTime returns the starttime field of /proc/<pid>/stat, as recorded by
+// runc when the container was created
+func (s StateFile) startTime(pid int) (string, error) {
+	procRoot := s.ProcRoot
+	if procRoot == "" {
+		procRoot = "/proc"
+	}
+
+	stat, err := ioutil.ReadFile(filepath.Join(procRoot, strconv.Itoa(pid), "stat"))
+	if err != nil {
+		return "", err
+	}
+
+	// the command name may itself contain spaces and parentheses, so the
+	// fields are counted from the last closing parenthesis
+	end := strings.LastIndex(string(stat), ")")
+	if end < 0 {
+		return "", fmt.Errorf("malformed stat for pid %d", pid)
+	}
+
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 20 {
+		return "", fmt.Errorf("malformed stat for pid %d", pid)
+	}
+
+	return fields[19], nil
+}
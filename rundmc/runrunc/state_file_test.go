@@ -0,0 +1,86 @@
+package runrunc_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/guardian/rundmc/runrunc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StateFile", func() {
+	var (
+		root      string
+		procRoot  string
+		stateFile runrunc.StateFile
+	)
+
+	writeFile := func(path, contents string) {
+		Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(path, []byte(contents), 0644)).To(Succeed())
+	}
+
+	writeStat := func(pid, startTime string) {
+		writeFile(filepath.Join(procRoot, pid, "stat"),
+			pid+" (my (odd) cmd) S 1 1 1 0 -1 4194560 100 0 0 0 0 0 0 0 20 0 1 0 "+startTime+" 1000 100 18446744073709551615")
+	}
+
+	BeforeEach(func() {
+		var err error
+		root, err = ioutil.TempDir("", "runc-root")
+		Expect(err).NotTo(HaveOccurred())
+		procRoot, err = ioutil.TempDir("", "proc-root")
+		Expect(err).NotTo(HaveOccurred())
+
+		stateFile = runrunc.StateFile{Root: root, ProcRoot: procRoot}
+
+		writeFile(filepath.Join(root, "some-handle", "state.json"), `{"init_process_pid": 42, "init_process_start": 1234}`)
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(root)).To(Succeed())
+		Expect(os.RemoveAll(procRoot)).To(Succeed())
+	})
+
+	It("returns true when the pid is still the init process", func() {
+		writeStat("42", "1234")
+		Expect(stateFile.IsInitProcess("some-handle", 42)).To(BeTrue())
+	})
+
+	It("accepts start times recorded as strings by older versions of runc", func() {
+		writeFile(filepath.Join(root, "some-handle", "state.json"), `{"init_process_pid": 42, "init_process_start": "1234"}`)
+		writeStat("42", "1234")
+		Expect(stateFile.IsInitProcess("some-handle", 42)).To(BeTrue())
+	})
+
+	It("returns false when the container has a different init process", func() {
+		writeStat("43", "1234")
+		Expect(stateFile.IsInitProcess("some-handle", 43)).To(BeFalse())
+	})
+
+	It("returns false when the init process has exited", func() {
+		Expect(stateFile.IsInitProcess("some-handle", 42)).To(BeFalse())
+	})
+
+	It("returns false when the pid has been reused", func() {
+		writeStat("42", "9999")
+		Expect(stateFile.IsInitProcess("some-handle", 42)).To(BeFalse())
+	})
+
+	Context("when the state file does not exist", func() {
+		It("returns an error", func() {
+			_, err := stateFile.IsInitProcess("another-handle", 42)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("when the state file cannot be parsed", func() {
+		It("returns an error", func() {
+			writeFile(filepath.Join(root, "some-handle", "state.json"), `{`)
+			_, err := stateFile.IsInitProcess("some-handle", 42)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
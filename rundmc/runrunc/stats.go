@@ -26,7 +26,13 @@ type runcStats struct {
 			} `json:"usage"`
 		} `json:"cpu"`
 		MemoryStats struct {
-			Stats garden.ContainerMemoryStat `json:"raw"`
+			Raw   json.RawMessage `json:"raw"`
+			Usage struct {
+				Limit uint64 `json:"limit"`
+			} `json:"usage"`
+			SwapUsage struct {
+				Usage uint64 `json:"usage"`
+			} `json:"swap_usage"`
 		} `json:"memory"`
 	}
 }
@@ -58,8 +64,13 @@ func (r *Statser) Stats(log lager.Logger, id string) (gardener.ActualContainerMe
 		return gardener.ActualContainerMetrics{}, fmt.Errorf("decode stats: %s", err)
 	}
 
+	memoryStats, err := parseMemoryStats(data)
+	if err != nil {
+		return gardener.ActualContainerMetrics{}, fmt.Errorf("decode memory stats: %s", err)
+	}
+
 	stats := gardener.ActualContainerMetrics{
-		Memory: data.Data.MemoryStats.Stats,
+		Memory: memoryStats,
 		CPU: garden.ContainerCPUStat{
 			Usage:  data.Data.CPUStats.CPUUsage.Usage,
 			System: data.Data.CPUStats.CPUUsage.System,
@@ -71,3 +82,49 @@ func (r *Statser) Stats(log lager.Logger, id string) (gardener.ActualContainerMe
 
 	return stats, nil
 }
+
+// parseMemoryStats reads the raw memory.stat values reported by runc. On the
+// unified cgroup2 hierarchy the keys differ from cgroup v1 and the values are
+// already hierarchical, so they are mapped onto both the local and total
+// fields.
+func parseMemoryStats(data runcStats) (garden.ContainerMemoryStat, error) {
+	var stats garden.ContainerMemoryStat
+	if len(data.Data.MemoryStats.Raw) == 0 {
+		return stats, nil
+	}
+
+	if err := json.Unmarshal(data.Data.MemoryStats.Raw, &stats); err != nil {
+		return stats, err
+	}
+
+	var raw map[string]uint64
+	if err := json.Unmarshal(data.Data.MemoryStats.Raw, &raw); err != nil {
+		return stats, err
+	}
+
+	_, hasAnon := raw["anon"]
+	_, hasRss := raw["rss"]
+	if !hasAnon || hasRss {
+		return stats, nil
+	}
+
+	stats.Rss = raw["anon"]
+	stats.Cache = raw["file"]
+	stats.MappedFile = raw["file_mapped"]
+	stats.Swap = data.Data.MemoryStats.SwapUsage.Usage
+	stats.HierarchicalMemoryLimit = data.Data.MemoryStats.Usage.Limit
+
+	stats.TotalRss = stats.Rss
+	stats.TotalCache = stats.Cache
+	stats.TotalMappedFile = stats.MappedFile
+	stats.TotalSwap = stats.Swap
+	stats.TotalActiveAnon = stats.ActiveAnon
+	stats.TotalActiveFile = stats.ActiveFile
+	stats.TotalInactiveAnon = stats.InactiveAnon
+	stats.TotalInactiveFile = stats.InactiveFile
+	stats.TotalUnevictable = stats.Unevictable
+	stats.TotalPgfault = stats.Pgfault
+	stats.TotalPgmajfault = stats.Pgmajfault
+
+	return stats, nil
+}
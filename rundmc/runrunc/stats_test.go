@@ -160,6 +160,75 @@ var _ = Describe("Stats", func() {
 
 	})
 
+	Context("when runC reports stats from the unified cgroup2 hierarchy", func() {
+		BeforeEach(func() {
+			commandRunner.WhenRunning(fake_command_runner.CommandSpec{
+				Path: "funC-stats",
+			}, func(cmd *exec.Cmd) error {
+				cmd.Stdout.Write([]byte(`{
+					"type": "stats",
+					"data": {
+						"memory": {
+							"usage": {
+								"usage": 100,
+								"limit": 200
+							},
+							"swap_usage": {
+								"usage": 7
+							},
+							"raw": {
+								"anon": 1,
+								"file": 2,
+								"file_mapped": 3,
+								"active_anon": 4,
+								"active_file": 5,
+								"inactive_anon": 6,
+								"inactive_file": 1,
+								"unevictable": 8,
+								"pgfault": 9,
+								"pgmajfault": 10
+							}
+						}
+					}
+				}`))
+
+				return nil
+			})
+		})
+
+		It("maps the cgroup2 memory stats onto the local and total fields", func() {
+			stats, err := statser.Stats(logger, "some-handle")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(stats.Memory).To(Equal(garden.ContainerMemoryStat{
+				Rss:                     1,
+				TotalRss:                1,
+				Cache:                   2,
+				TotalCache:              2,
+				MappedFile:              3,
+				TotalMappedFile:         3,
+				ActiveAnon:              4,
+				TotalActiveAnon:         4,
+				ActiveFile:              5,
+				TotalActiveFile:         5,
+				InactiveAnon:            6,
+				TotalInactiveAnon:       6,
+				InactiveFile:            1,
+				TotalInactiveFile:       1,
+				Unevictable:             8,
+				TotalUnevictable:        8,
+				Pgfault:                 9,
+				TotalPgfault:            9,
+				Pgmajfault:              10,
+				TotalPgmajfault:         10,
+				Swap:                    7,
+				TotalSwap:               7,
+				HierarchicalMemoryLimit: 200,
+				TotalUsageTowardLimit:   2,
+			}))
+		})
+	})
+
 	Context("when runC reports invalid JSON", func() {
 		BeforeEach(func() {
 			commandRunner.WhenRunning(fake_command_runner.CommandSpec{
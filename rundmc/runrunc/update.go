@@ -0,0 +1,42 @@
+package runrunc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+type Updater struct {
+	runner RuncCmdRunner
+	runc   RuncBinary
+}
+
+func NewUpdater(runner RuncCmdRunner, runc RuncBinary) *Updater {
+	return &Updater{
+		runner: runner,
+		runc:   runc,
+	}
+}
+
+// UpdateResources updates the cgroup limits of a running container using 'runc update'
+func (u *Updater) UpdateResources(log lager.Logger, handle string, resources specs.LinuxResources) error {
+	log = log.Session("update-resources", lager.Data{"handle": handle})
+
+	log.Info("started")
+	defer log.Info("finished")
+
+	resourcesJSON, err := json.Marshal(resources)
+	if err != nil {
+		return fmt.Errorf("runc update: encode resources: %s", err)
+	}
+
+	return u.runner.RunAndLog(log, func(logFile string) *exec.Cmd {
+		cmd := u.runc.UpdateCommand(handle, logFile)
+		cmd.Stdin = bytes.NewReader(resourcesJSON)
+		return cmd
+	})
+}
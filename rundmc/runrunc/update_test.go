@@ -0,0 +1,80 @@
+package runrunc_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os/exec"
+
+	"code.cloudfoundry.org/guardian/rundmc/runrunc"
+	fakes "code.cloudfoundry.org/guardian/rundmc/runrunc/runruncfakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/cloudfoundry/gunk/command_runner/fake_command_runner"
+	. "github.com/cloudfoundry/gunk/command_runner/fake_command_runner/matchers"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/opencontainers/runtime-spec/specs-go"
+)
+
+var _ = Describe("Update", func() {
+	var (
+		commandRunner *fake_command_runner.FakeCommandRunner
+		runner        *fakes.FakeRuncCmdRunner
+		runcBinary    *fakes.FakeRuncBinary
+		logger        *lagertest.TestLogger
+
+		updater *runrunc.Updater
+	)
+
+	BeforeEach(func() {
+		runcBinary = new(fakes.FakeRuncBinary)
+		commandRunner = fake_command_runner.New()
+		runner = new(fakes.FakeRuncCmdRunner)
+		logger = lagertest.NewTestLogger("test")
+
+		updater = runrunc.NewUpdater(runner, runcBinary)
+
+		runcBinary.UpdateCommandStub = func(id, logFile string) *exec.Cmd {
+			return exec.Command("funC", "--log", logFile, "update", "-r", "-", id)
+		}
+
+		runner.RunAndLogStub = func(_ lager.Logger, fn runrunc.LoggingCmd) error {
+			return commandRunner.Run(fn("potato.log"))
+		}
+	})
+
+	It("runs 'runc update' using the logging runner", func() {
+		Expect(updater.UpdateResources(logger, "some-container", specs.LinuxResources{})).To(Succeed())
+		Expect(commandRunner).To(HaveExecutedSerially(fake_command_runner.CommandSpec{
+			Path: "funC",
+			Args: []string{"--log", "potato.log", "update", "-r", "-", "some-container"},
+		}))
+	})
+
+	It("passes the resources to runc as JSON on stdin", func() {
+		var resources specs.LinuxResources
+		commandRunner.WhenRunning(fake_command_runner.CommandSpec{
+			Path: "funC",
+		}, func(cmd *exec.Cmd) error {
+			return json.NewDecoder(cmd.Stdin).Decode(&resources)
+		})
+
+		limit := uint64(1024)
+		Expect(updater.UpdateResources(logger, "some-container", specs.LinuxResources{
+			Memory: &specs.LinuxMemory{Limit: &limit, Swap: &limit},
+		})).To(Succeed())
+
+		Expect(*resources.Memory.Limit).To(BeEquivalentTo(1024))
+		Expect(*resources.Memory.Swap).To(BeEquivalentTo(1024))
+	})
+
+	Context("when runc update fails", func() {
+		BeforeEach(func() {
+			runner.RunAndLogReturns(errors.New("boom"))
+		})
+
+		It("returns the error", func() {
+			Expect(updater.UpdateResources(logger, "some-container", specs.LinuxResources{})).To(MatchError("boom"))
+		})
+	})
+})
@@ -9,6 +9,9 @@ import (
 	"github.com/cloudfoundry/gunk/command_runner"
 )
 
+// OOMEvent is the event reported when a container runs out of memory.
+const OOMEvent = "Out of memory"
+
 //go:generate counterfeiter . EventsNotifier
 type EventsNotifier interface {
 	OnEvent(handle string, event string) error
@@ -71,7 +74,7 @@ func (r *OomWatcher) WatchEvents(log lager.Logger, handle string, eventsNotifier
 			"type": event.Type,
 		})
 		if event.Type == "oom" {
-			err := eventsNotifier.OnEvent(handle, "Out of memory")
+			err := eventsNotifier.OnEvent(handle, OOMEvent)
 			if err != nil {
 				log.Debug("failed-to-notify-oom-event", lager.Data{"event": event.Data})
 			}
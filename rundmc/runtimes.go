@@ -0,0 +1,252 @@
+package rundmc
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/rundmc/runrunc"
+	"code.cloudfoundry.org/lager"
+	specs "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+// RuntimeFile is the file, within a container's bundle, which records the
+// name of the OCI runtime the container was created with
+const RuntimeFile = "runtime"
+
+// RuntimeSelector is implemented by runtimes which can run different
+// containers with different OCI runtimes
+type RuntimeSelector interface {
+	Select(log lager.Logger, bundlePath, name string) error
+}
+
+// Runtimes is an OCIRuntime which dispatches each call to one of several named
+// runtimes, according to the name recorded in the container's bundle. This
+// means the choice survives a restart of the server.
+type Runtimes struct {
+	depot       Depot
+	defaultName string
+	runtimes    map[string]OCIRuntime
+}
+
+func NewRuntimes(depot Depot, defaultName string, runtimes map[string]OCIRuntime) *Runtimes {
+	return &Runtimes{
+		depot:       depot,
+		defaultName: defaultName,
+		runtimes:    runtimes,
+	}
+}
+
+// Select records the named runtime in the bundle, an empty name selects the
+// default runtime
+func (r *Runtimes) Select(log lager.Logger, bundlePath, name string) error {
+	if name == "" {
+		name = r.defaultName
+	}
+
+	if _, ok := r.runtimes[name]; !ok {
+		return fmt.Errorf("unknown runtime: %s", name)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(bundlePath, RuntimeFile), []byte(name), 0644); err != nil {
+		log.Error("write-runtime-file-failed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *Runtimes) Create(log lager.Logger, bundlePath, id string, io garden.ProcessIO) error {
+	runtime, err := r.forBundle(bundlePath)
+	if err != nil {
+		return err
+	}
+
+	return runtime.Create(log, bundlePath, id, io)
+}
+
+func (r *Runtimes) Exec(log lager.Logger, bundlePath, id string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+	runtime, err := r.forBundle(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return runtime.Exec(log, bundlePath, id, spec, io)
+}
+
+func (r *Runtimes) Attach(log lager.Logger, bundlePath, id, processId string, replay *gardener.OutputOffsets, io garden.ProcessIO) (garden.Process, error) {
+	runtime, err := r.forBundle(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+
+	return runtime.Attach(log, bundlePath, id, processId, replay, io)
+}
+
+func (r *Runtimes) Kill(log lager.Logger, handle string) error {
+	runtime, err := r.forHandle(log, handle)
+	if err != nil {
+		return err
+	}
+
+	return runtime.Kill(log, handle)
+}
+
+func (r *Runtimes) Delete(log lager.Logger, handle string) error {
+	runtime, err := r.forHandle(log, handle)
+	if err != nil {
+		return err
+	}
+
+	return runtime.Delete(log, handle)
+}
+
+func (r *Runtimes) State(log lager.Logger, id string) (runrunc.State, error) {
+	runtime, err := r.forHandle(log, id)
+	if err != nil {
+		return runrunc.State{}, err
+	}
+
+	return runtime.State(log, id)
+}
+
+func (r *Runtimes) Stats(log lager.Logger, id string) (gardener.ActualContainerMetrics, error) {
+	runtime, err := r.forHandle(log, id)
+	if err != nil {
+		return gardener.ActualContainerMetrics{}, err
+	}
+
+	return runtime.Stats(log, id)
+}
+
+func (r *Runtimes) WatchEvents(log lager.Logger, id string, eventsNotifier runrunc.EventsNotifier) error {
+	runtime, err := r.forHandle(log, id)
+	if err != nil {
+		return err
+	}
+
+	return runtime.WatchEvents(log, id, eventsNotifier)
+}
+
+func (r *Runtimes) UpdateResources(log lager.Logger, id string, resources specs.LinuxResources) error {
+	runtime, err := r.forHandle(log, id)
+	if err != nil {
+		return err
+	}
+
+	return runtime.UpdateResources(log, id, resources)
+}
+
+func (r *Runtimes) Pause(log lager.Logger, id string) error {
+	runtime, err := r.forHandle(log, id)
+	if err != nil {
+		return err
+	}
+
+	return runtime.Pause(log, id)
+}
+
+func (r *Runtimes) Resume(log lager.Logger, id string) error {
+	runtime, err := r.forHandle(log, id)
+	if err != nil {
+		return err
+	}
+
+	return runtime.Resume(log, id)
+}
+
+func (r *Runtimes) Checkpoint(log lager.Logger, id, imagePath string) error {
+	runtime, err := r.forHandle(log, id)
+	if err != nil {
+		return err
+	}
+
+	return runtime.Checkpoint(log, id, imagePath)
+}
+
+func (r *Runtimes) Restore(log lager.Logger, bundlePath, id, imagePath string) error {
+	runtime, err := r.forBundle(bundlePath)
+	if err != nil {
+		return err
+	}
+
+	return runtime.Restore(log, bundlePath, id, imagePath)
+}
+
+// CgroupPathResolver finds the cgroup paths of a container
+type CgroupPathResolver interface {
+	Resolve(cgroupName, subsystem string) (string, error)
+}
+
+// CgroupPathResolver resolves cgroup paths with the resolver for the runtime
+// each container was created with, since runtimes may keep their state under
+// different roots
+func (r *Runtimes) CgroupPathResolver(log lager.Logger, resolvers map[string]CgroupPathResolver) CgroupPathResolver {
+	return runtimeCgroupPathResolver{log: log, runtimes: r, resolvers: resolvers}
+}
+
+type runtimeCgroupPathResolver struct {
+	log       lager.Logger
+	runtimes  *Runtimes
+	resolvers map[string]CgroupPathResolver
+}
+
+func (r runtimeCgroupPathResolver) Resolve(handle, subsystem string) (string, error) {
+	bundlePath, err := r.runtimes.depot.Lookup(r.log, handle)
+	if err != nil {
+		return "", err
+	}
+
+	name, err := r.runtimes.nameForBundle(bundlePath)
+	if err != nil {
+		return "", err
+	}
+
+	resolver, ok := r.resolvers[name]
+	if !ok {
+		return "", fmt.Errorf("unknown runtime: %s", name)
+	}
+
+	return resolver.Resolve(handle, subsystem)
+}
+
+func (r *Runtimes) forHandle(log lager.Logger, handle string) (OCIRuntime, error) {
+	bundlePath, err := r.depot.Lookup(log, handle)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.forBundle(bundlePath)
+}
+
+// forBundle falls back to the default runtime for bundles created before the
+// runtime was recorded
+func (r *Runtimes) forBundle(bundlePath string) (OCIRuntime, error) {
+	name, err := r.nameForBundle(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+
+	runtime, ok := r.runtimes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown runtime: %s", name)
+	}
+
+	return runtime, nil
+}
+
+func (r *Runtimes) nameForBundle(bundlePath string) (string, error) {
+	contents, err := ioutil.ReadFile(filepath.Join(bundlePath, RuntimeFile))
+	if os.IsNotExist(err) {
+		return r.defaultName, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(string(contents)), nil
+}
@@ -0,0 +1,177 @@
+package rundmc_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/rundmc"
+	fakes "code.cloudfoundry.org/guardian/rundmc/rundmcfakes"
+	"code.cloudfoundry.org/guardian/rundmc/stopper/stopperfakes"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Runtimes", func() {
+	var (
+		fakeDepot  *fakes.FakeDepot
+		fakeRunc   *fakes.FakeOCIRuntime
+		fakeCrun   *fakes.FakeOCIRuntime
+		logger     lager.Logger
+		bundlePath string
+		runtimes   *rundmc.Runtimes
+	)
+
+	BeforeEach(func() {
+		var err error
+		bundlePath, err = ioutil.TempDir("", "runtimes-bundle")
+		Expect(err).NotTo(HaveOccurred())
+
+		fakeDepot = new(fakes.FakeDepot)
+		fakeDepot.LookupReturns(bundlePath, nil)
+
+		fakeRunc = new(fakes.FakeOCIRuntime)
+		fakeCrun = new(fakes.FakeOCIRuntime)
+		logger = lagertest.NewTestLogger("test")
+
+		runtimes = rundmc.NewRuntimes(fakeDepot, "runc", map[string]rundmc.OCIRuntime{
+			"runc": fakeRunc,
+			"crun": fakeCrun,
+		})
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(bundlePath)).To(Succeed())
+	})
+
+	Describe("Select", func() {
+		It("records the runtime in the bundle", func() {
+			Expect(runtimes.Select(logger, bundlePath, "crun")).To(Succeed())
+			Expect(ioutil.ReadFile(filepath.Join(bundlePath, rundmc.RuntimeFile))).To(Equal([]byte("crun")))
+		})
+
+		Context("when no runtime is named", func() {
+			It("records the default runtime", func() {
+				Expect(runtimes.Select(logger, bundlePath, "")).To(Succeed())
+				Expect(ioutil.ReadFile(filepath.Join(bundlePath, rundmc.RuntimeFile))).To(Equal([]byte("runc")))
+			})
+		})
+
+		Context("when the runtime is unknown", func() {
+			It("returns an error", func() {
+				Expect(runtimes.Select(logger, bundlePath, "banana")).To(MatchError("unknown runtime: banana"))
+			})
+		})
+	})
+
+	Context("when a runtime has been selected", func() {
+		BeforeEach(func() {
+			Expect(runtimes.Select(logger, bundlePath, "crun")).To(Succeed())
+		})
+
+		It("creates the container with the selected runtime", func() {
+			Expect(runtimes.Create(logger, bundlePath, "some-handle", garden.ProcessIO{})).To(Succeed())
+
+			Expect(fakeCrun.CreateCallCount()).To(Equal(1))
+			Expect(fakeRunc.CreateCallCount()).To(Equal(0))
+		})
+
+		It("execs, kills and deletes with the selected runtime", func() {
+			_, err := runtimes.Exec(logger, bundlePath, "some-handle", garden.ProcessSpec{}, garden.ProcessIO{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(runtimes.Kill(logger, "some-handle")).To(Succeed())
+			Expect(runtimes.Delete(logger, "some-handle")).To(Succeed())
+
+			Expect(fakeCrun.ExecCallCount()).To(Equal(1))
+			Expect(fakeCrun.KillCallCount()).To(Equal(1))
+			Expect(fakeCrun.DeleteCallCount()).To(Equal(1))
+		})
+
+		It("looks up the bundle to find the runtime for calls by handle", func() {
+			_, err := runtimes.State(logger, "some-handle")
+			Expect(err).NotTo(HaveOccurred())
+
+			_, handle := fakeDepot.LookupArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+			Expect(fakeCrun.StateCallCount()).To(Equal(1))
+			Expect(fakeRunc.StateCallCount()).To(Equal(0))
+		})
+
+		It("survives being recreated, e.g. after a restart", func() {
+			restarted := rundmc.NewRuntimes(fakeDepot, "runc", map[string]rundmc.OCIRuntime{
+				"runc": fakeRunc,
+				"crun": fakeCrun,
+			})
+
+			Expect(restarted.Pause(logger, "some-handle")).To(Succeed())
+			Expect(fakeCrun.PauseCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the bundle does not record a runtime", func() {
+		It("uses the default runtime", func() {
+			_, err := runtimes.State(logger, "some-handle")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeRunc.StateCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the bundle records a runtime which is no longer registered", func() {
+		It("returns an error", func() {
+			Expect(ioutil.WriteFile(filepath.Join(bundlePath, rundmc.RuntimeFile), []byte("gone"), 0644)).To(Succeed())
+			Expect(runtimes.Kill(logger, "some-handle")).To(MatchError("unknown runtime: gone"))
+		})
+	})
+
+	Describe("CgroupPathResolver", func() {
+		var (
+			runcResolver *stopperfakes.FakeCgroupPathResolver
+			crunResolver *stopperfakes.FakeCgroupPathResolver
+			resolver     rundmc.CgroupPathResolver
+		)
+
+		BeforeEach(func() {
+			runcResolver = new(stopperfakes.FakeCgroupPathResolver)
+			crunResolver = new(stopperfakes.FakeCgroupPathResolver)
+			crunResolver.ResolveReturns("/crun/cgroup", nil)
+
+			resolver = runtimes.CgroupPathResolver(logger, map[string]rundmc.CgroupPathResolver{
+				"runc": runcResolver,
+				"crun": crunResolver,
+			})
+		})
+
+		It("resolves with the resolver for the container's runtime", func() {
+			Expect(runtimes.Select(logger, bundlePath, "crun")).To(Succeed())
+
+			Expect(resolver.Resolve("some-handle", "freezer")).To(Equal("/crun/cgroup"))
+			_, handle := fakeDepot.LookupArgsForCall(0)
+			Expect(handle).To(Equal("some-handle"))
+
+			name, subsystem := crunResolver.ResolveArgsForCall(0)
+			Expect(name).To(Equal("some-handle"))
+			Expect(subsystem).To(Equal("freezer"))
+			Expect(runcResolver.ResolveCallCount()).To(Equal(0))
+		})
+
+		It("resolves with the default runtime's resolver when none was recorded", func() {
+			_, err := resolver.Resolve("some-handle", "freezer")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(runcResolver.ResolveCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the container cannot be found in the depot", func() {
+		It("returns the error", func() {
+			fakeDepot.LookupReturns("", errors.New("no such container"))
+
+			_, err := runtimes.State(logger, "some-handle")
+			Expect(err).To(MatchError("no such container"))
+		})
+	})
+})
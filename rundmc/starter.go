@@ -2,8 +2,10 @@ package rundmc
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"os/exec"
 	"path"
@@ -20,6 +22,16 @@ type Starter struct {
 
 const cgroupsHeader = "#subsys_name hierarchy num_cgroups enabled"
 
+type CgroupMode string
+
+const (
+	// CgroupModeLegacy mounts one cgroup v1 hierarchy per subsystem
+	CgroupModeLegacy CgroupMode = "legacy"
+
+	// CgroupModeUnified uses the single cgroup v2 hierarchy
+	CgroupModeUnified CgroupMode = "unified"
+)
+
 type CgroupsFormatError struct {
 	Content string
 }
@@ -61,13 +73,25 @@ func (s *CgroupStarter) mountCgroupsIfNeeded(logger lager.Logger) error {
 		return err
 	}
 
+	procSelfCgroups, err := ioutil.ReadAll(s.ProcSelfCgroups)
+	if err != nil {
+		return err
+	}
+
+	mode := DetectCgroupMode(bytes.NewReader(procSelfCgroups))
+	logger.Info("cgroup-mode", lager.Data{"mode": mode, "path": s.CgroupPath})
+
+	if mode == CgroupModeUnified {
+		return s.mountUnifiedHierarchy(logger, s.CgroupPath)
+	}
+
 	if !s.isMountPoint(s.CgroupPath) {
 		s.mountTmpfsOnCgroupPath(logger, s.CgroupPath)
 	} else {
 		logger.Info("cgroups-tmpfs-already-mounted", lager.Data{"path": s.CgroupPath})
 	}
 
-	subsystemGroupings, err := s.subsystemGroupings()
+	subsystemGroupings, err := s.subsystemGroupings(bytes.NewReader(procSelfCgroups))
 	if err != nil {
 		return err
 	}
@@ -118,10 +142,10 @@ func (s *CgroupStarter) mountTmpfsOnCgroupPath(log lager.Logger, path string) {
 	}
 }
 
-func (s *CgroupStarter) subsystemGroupings() (map[string]string, error) {
+func (s *CgroupStarter) subsystemGroupings(procSelfCgroups io.Reader) (map[string]string, error) {
 	groupings := map[string]string{}
 
-	scanner := bufio.NewScanner(s.ProcSelfCgroups)
+	scanner := bufio.NewScanner(procSelfCgroups)
 
 	for scanner.Scan() {
 		segs := strings.Split(scanner.Text(), ":")
@@ -138,6 +162,51 @@ func (s *CgroupStarter) subsystemGroupings() (map[string]string, error) {
 	return groupings, scanner.Err()
 }
 
+// DetectCgroupMode reports whether the process described by procSelfCgroup
+// (the contents of /proc/self/cgroup) is only in the unified cgroup v2
+// hierarchy. Hosts which also have v1 hierarchies mounted are treated as legacy.
+func DetectCgroupMode(procSelfCgroup io.Reader) CgroupMode {
+	scanner := bufio.NewScanner(procSelfCgroup)
+
+	unified := false
+	for scanner.Scan() {
+		if scanner.Text() == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(scanner.Text(), "0::") {
+			return CgroupModeLegacy
+		}
+
+		unified = true
+	}
+
+	if !unified || scanner.Err() != nil {
+		return CgroupModeLegacy
+	}
+
+	return CgroupModeUnified
+}
+
+func (s *CgroupStarter) mountUnifiedHierarchy(logger lager.Logger, cgroupPath string) error {
+	logger = logger.Session("mount-cgroup2", lager.Data{"path": cgroupPath})
+	logger.Info("started")
+
+	if !s.isMountPoint(cgroupPath) {
+		cmd := exec.Command("mount", "-n", "-t", "cgroup2", "cgroup2", cgroupPath)
+		cmd.Stderr = logging.Writer(logger.Session("mount-cgroup2-cmd"))
+		if err := s.CommandRunner.Run(cmd); err != nil {
+			return fmt.Errorf("mounting cgroup2 in '%s': %s", cgroupPath, err)
+		}
+	} else {
+		logger.Info("cgroup2-already-mounted")
+	}
+
+	logger.Info("finished")
+
+	return nil
+}
+
 func (s *CgroupStarter) mountCgroup(logger lager.Logger, cgroupPath, subsystems string) error {
 	logger = logger.Session("mount-cgroup", lager.Data{
 		"path":       cgroupPath,
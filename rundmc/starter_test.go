@@ -9,12 +9,12 @@ import (
 	"path"
 
 	"code.cloudfoundry.org/guardian/rundmc"
-	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 	"github.com/cloudfoundry/gunk/command_runner/fake_command_runner"
 	. "github.com/cloudfoundry/gunk/command_runner/fake_command_runner/matchers"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("CgroupStarter", func() {
@@ -23,7 +23,7 @@ var _ = Describe("CgroupStarter", func() {
 		starter         *rundmc.CgroupStarter
 		procCgroups     *FakeReadCloser
 		procSelfCgroups *FakeReadCloser
-		logger          lager.Logger
+		logger          *lagertest.TestLogger
 
 		tmpDir string
 	)
@@ -192,6 +192,111 @@ var _ = Describe("CgroupStarter", func() {
 		})
 	})
 
+	Context("when the host only has the unified cgroup2 hierarchy", func() {
+		BeforeEach(func() {
+			_, err := procCgroups.Write([]byte(
+				"#subsys_name\thierarchy\tnum_cgroups\tenabled\n" +
+					"devices\t0\t1\t1\n" +
+					"memory\t0\t1\t1\n",
+			))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = procSelfCgroups.Write([]byte("0::/init.scope\n"))
+			Expect(err).NotTo(HaveOccurred())
+
+			runner.WhenRunning(fake_command_runner.CommandSpec{
+				Path: "mountpoint",
+				Args: []string{"-q", path.Join(tmpDir, "cgroup") + "/"},
+			}, func(cmd *exec.Cmd) error {
+				return errors.New("not a mountpoint")
+			})
+		})
+
+		It("succeeds", func() {
+			Expect(starter.Start()).To(Succeed())
+		})
+
+		It("mounts cgroup2 on the cgroup path", func() {
+			starter.Start()
+
+			Expect(runner).To(HaveExecutedSerially(fake_command_runner.CommandSpec{
+				Path: "mount",
+				Args: []string{"-n", "-t", "cgroup2", "cgroup2", path.Join(tmpDir, "cgroup")},
+			}))
+		})
+
+		It("does not mount tmpfs or any per-subsystem hierarchies", func() {
+			starter.Start()
+
+			for _, cmd := range runner.ExecutedCommands() {
+				if cmd.Path == "mount" {
+					Expect(cmd.Args).To(ContainElement("cgroup2"))
+				}
+			}
+			Expect(path.Join(tmpDir, "cgroup", "devices")).NotTo(BeADirectory())
+		})
+
+		It("reports that it chose the unified mode", func() {
+			starter.Start()
+			Expect(logger).To(gbytes.Say(`"mode":"unified"`))
+		})
+
+		Context("when cgroup2 is already mounted", func() {
+			BeforeEach(func() {
+				runner.WhenRunning(fake_command_runner.CommandSpec{
+					Path: "mountpoint",
+					Args: []string{"-q", path.Join(tmpDir, "cgroup") + "/"},
+				}, func(cmd *exec.Cmd) error {
+					return nil
+				})
+			})
+
+			It("does not mount it again", func() {
+				starter.Start()
+
+				Expect(runner).NotTo(HaveExecutedSerially(fake_command_runner.CommandSpec{
+					Path: "mount",
+					Args: []string{"-n", "-t", "cgroup2", "cgroup2", path.Join(tmpDir, "cgroup")},
+				}))
+			})
+		})
+
+		Context("when mounting cgroup2 fails", func() {
+			BeforeEach(func() {
+				runner.WhenRunning(fake_command_runner.CommandSpec{
+					Path: "mount",
+				}, func(cmd *exec.Cmd) error {
+					return errors.New("mount-failed")
+				})
+			})
+
+			It("returns the error", func() {
+				Expect(starter.Start()).To(MatchError(ContainSubstring("mount-failed")))
+			})
+		})
+	})
+
+	Context("when the host has both v1 hierarchies and the unified hierarchy", func() {
+		BeforeEach(func() {
+			_, err := procCgroups.Write([]byte(
+				"#subsys_name\thierarchy\tnum_cgroups\tenabled\n" +
+					"devices\t1\t1\t1\n",
+			))
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = procSelfCgroups.Write([]byte(
+				"1:devices:/\n" +
+					"0::/init.scope\n",
+			))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("uses the legacy mode", func() {
+			Expect(starter.Start()).To(Succeed())
+			Expect(logger).To(gbytes.Say(`"mode":"legacy"`))
+		})
+	})
+
 	Context("when /proc/cgroups contains malformed entries", func() {
 		BeforeEach(func() {
 			_, err := procCgroups.Write([]byte(
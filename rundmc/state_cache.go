@@ -0,0 +1,129 @@
+package rundmc
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/rundmc/runrunc"
+	"code.cloudfoundry.org/lager"
+)
+
+//go:generate counterfeiter . InitProcessChecker
+
+type InitProcessChecker interface {
+	IsInitProcess(id string, pid int) (bool, error)
+}
+
+// StateCache is an OCIRuntime which remembers the state of each container, so
+// that frequent calls such as Info do not each fork the runtime. An entry is
+// only used while the checker confirms the container's init process is still
+// running, otherwise the runtime is asked again. Lifecycle operations and
+// events from the container forget its entry.
+type StateCache struct {
+	OCIRuntime
+	checker InitProcessChecker
+
+	mu     sync.Mutex
+	states map[string]runrunc.State
+}
+
+func NewStateCache(runtime OCIRuntime, checker InitProcessChecker) *StateCache {
+	return &StateCache{
+		OCIRuntime: runtime,
+		checker:    checker,
+		states:     make(map[string]runrunc.State),
+	}
+}
+
+func (c *StateCache) State(log lager.Logger, id string) (runrunc.State, error) {
+	if state, ok := c.cached(id); ok {
+		if current, err := c.checker.IsInitProcess(id, state.Pid); err == nil && current {
+			return state, nil
+		}
+
+		c.forget(id)
+	}
+
+	state, err := c.OCIRuntime.State(log, id)
+	if err != nil {
+		return runrunc.State{}, err
+	}
+
+	// a stopped container has no init process to check the entry against
+	if state.Status != runrunc.StoppedStatus && state.Pid != 0 {
+		c.mu.Lock()
+		c.states[id] = state
+		c.mu.Unlock()
+	}
+
+	return state, nil
+}
+
+func (c *StateCache) Create(log lager.Logger, bundlePath, id string, io garden.ProcessIO) error {
+	defer c.forget(id)
+	return c.OCIRuntime.Create(log, bundlePath, id, io)
+}
+
+func (c *StateCache) Exec(log lager.Logger, bundlePath, id string, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+	defer c.forget(id)
+	return c.OCIRuntime.Exec(log, bundlePath, id, spec, io)
+}
+
+func (c *StateCache) Kill(log lager.Logger, handle string) error {
+	defer c.forget(handle)
+	return c.OCIRuntime.Kill(log, handle)
+}
+
+func (c *StateCache) Delete(log lager.Logger, handle string) error {
+	defer c.forget(handle)
+	return c.OCIRuntime.Delete(log, handle)
+}
+
+func (c *StateCache) WatchEvents(log lager.Logger, id string, eventsNotifier runrunc.EventsNotifier) error {
+	return c.OCIRuntime.WatchEvents(log, id, &forgettingNotifier{eventsNotifier, c})
+}
+
+func (c *StateCache) Pause(log lager.Logger, id string) error {
+	defer c.forget(id)
+	return c.OCIRuntime.Pause(log, id)
+}
+
+func (c *StateCache) Resume(log lager.Logger, id string) error {
+	defer c.forget(id)
+	return c.OCIRuntime.Resume(log, id)
+}
+
+func (c *StateCache) Checkpoint(log lager.Logger, id, imagePath string) error {
+	defer c.forget(id)
+	return c.OCIRuntime.Checkpoint(log, id, imagePath)
+}
+
+func (c *StateCache) Restore(log lager.Logger, bundlePath, id, imagePath string) error {
+	defer c.forget(id)
+	return c.OCIRuntime.Restore(log, bundlePath, id, imagePath)
+}
+
+func (c *StateCache) cached(id string) (runrunc.State, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	state, ok := c.states[id]
+	return state, ok
+}
+
+func (c *StateCache) forget(id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.states, id)
+}
+
+type forgettingNotifier struct {
+	runrunc.EventsNotifier
+	cache *StateCache
+}
+
+func (n *forgettingNotifier) OnEvent(id, event string) error {
+	n.cache.forget(id)
+	return n.EventsNotifier.OnEvent(id, event)
+}
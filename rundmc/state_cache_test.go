@@ -0,0 +1,139 @@
+package rundmc_test
+
+import (
+	"errors"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/guardian/rundmc"
+	fakes "code.cloudfoundry.org/guardian/rundmc/rundmcfakes"
+	"code.cloudfoundry.org/guardian/rundmc/runrunc"
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StateCache", func() {
+	var (
+		fakeRuntime *fakes.FakeOCIRuntime
+		fakeChecker *fakes.FakeInitProcessChecker
+		logger      lager.Logger
+		cache       *rundmc.StateCache
+	)
+
+	BeforeEach(func() {
+		fakeRuntime = new(fakes.FakeOCIRuntime)
+		fakeChecker = new(fakes.FakeInitProcessChecker)
+		logger = lagertest.NewTestLogger("test")
+
+		fakeRuntime.StateReturns(runrunc.State{Pid: 42, Status: "running"}, nil)
+		fakeChecker.IsInitProcessReturns(true, nil)
+
+		cache = rundmc.NewStateCache(fakeRuntime, fakeChecker)
+	})
+
+	It("asks the runtime for the state the first time", func() {
+		Expect(cache.State(logger, "some-handle")).To(Equal(runrunc.State{Pid: 42, Status: "running"}))
+		Expect(fakeRuntime.StateCallCount()).To(Equal(1))
+	})
+
+	It("returns the cached state while the init process is still running", func() {
+		cache.State(logger, "some-handle")
+		Expect(cache.State(logger, "some-handle")).To(Equal(runrunc.State{Pid: 42, Status: "running"}))
+
+		Expect(fakeRuntime.StateCallCount()).To(Equal(1))
+
+		id, pid := fakeChecker.IsInitProcessArgsForCall(0)
+		Expect(id).To(Equal("some-handle"))
+		Expect(pid).To(Equal(42))
+	})
+
+	Context("when the init process is no longer running", func() {
+		It("falls back to the runtime", func() {
+			cache.State(logger, "some-handle")
+
+			fakeChecker.IsInitProcessReturns(false, nil)
+			fakeRuntime.StateReturns(runrunc.State{Pid: 42, Status: runrunc.StoppedStatus}, nil)
+
+			Expect(cache.State(logger, "some-handle")).To(Equal(runrunc.State{Pid: 42, Status: runrunc.StoppedStatus}))
+			Expect(fakeRuntime.StateCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the init process cannot be checked", func() {
+		It("falls back to the runtime", func() {
+			cache.State(logger, "some-handle")
+
+			fakeChecker.IsInitProcessReturns(false, errors.New("no state.json"))
+			cache.State(logger, "some-handle")
+
+			Expect(fakeRuntime.StateCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the container is stopped", func() {
+		It("does not cache the state", func() {
+			fakeRuntime.StateReturns(runrunc.State{Status: runrunc.StoppedStatus}, nil)
+
+			cache.State(logger, "some-handle")
+			cache.State(logger, "some-handle")
+
+			Expect(fakeRuntime.StateCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the runtime fails", func() {
+		It("returns the error and does not cache anything", func() {
+			fakeRuntime.StateReturns(runrunc.State{}, errors.New("boom"))
+
+			_, err := cache.State(logger, "some-handle")
+			Expect(err).To(MatchError("boom"))
+
+			cache.State(logger, "some-handle")
+			Expect(fakeRuntime.StateCallCount()).To(Equal(2))
+		})
+	})
+
+	DescribeTable("forgets the state after lifecycle operations",
+		func(operation func()) {
+			cache.State(logger, "some-handle")
+			operation()
+			cache.State(logger, "some-handle")
+
+			Expect(fakeRuntime.StateCallCount()).To(Equal(2))
+		},
+		Entry("Exec", func() {
+			cache.Exec(logger, "/depot/some-handle", "some-handle", garden.ProcessSpec{}, garden.ProcessIO{})
+		}),
+		Entry("Kill", func() { cache.Kill(logger, "some-handle") }),
+		Entry("Delete", func() { cache.Delete(logger, "some-handle") }),
+		Entry("Pause", func() { cache.Pause(logger, "some-handle") }),
+		Entry("Resume", func() { cache.Resume(logger, "some-handle") }),
+		Entry("Checkpoint", func() { cache.Checkpoint(logger, "some-handle", "/image") }),
+		Entry("Restore", func() { cache.Restore(logger, "/depot/some-handle", "some-handle", "/image") }),
+	)
+
+	It("forgets the state when the events watcher reports an event", func() {
+		fakeEvents := new(fakes.FakeEventStore)
+		fakeRuntime.WatchEventsStub = func(_ lager.Logger, id string, notifier runrunc.EventsNotifier) error {
+			return notifier.OnEvent(id, "Out of memory")
+		}
+
+		cache.State(logger, "some-handle")
+		Expect(cache.WatchEvents(logger, "some-handle", fakeEvents)).To(Succeed())
+		cache.State(logger, "some-handle")
+
+		Expect(fakeRuntime.StateCallCount()).To(Equal(2))
+
+		Expect(fakeEvents.OnEventCallCount()).To(Equal(1))
+		id, event := fakeEvents.OnEventArgsForCall(0)
+		Expect(id).To(Equal("some-handle"))
+		Expect(event).To(Equal("Out of memory"))
+	})
+
+	It("delegates other calls to the runtime", func() {
+		Expect(cache.Create(logger, "/depot/some-handle", "some-handle", garden.ProcessIO{})).To(Succeed())
+		Expect(fakeRuntime.CreateCallCount()).To(Equal(1))
+	})
+})
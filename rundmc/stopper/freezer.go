@@ -0,0 +1,115 @@
+package stopper
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	freezeAttempts = 100
+	freezeInterval = 10 * time.Millisecond
+)
+
+// CgroupFreezer freezes cgroups through the freezer subsystem on cgroup v1
+// (freezer.state) or the cgroup.freeze file on the unified cgroup2 hierarchy.
+type CgroupFreezer struct{}
+
+func (f CgroupFreezer) Freeze(cgroupPath string) error {
+	if isUnified(cgroupPath) {
+		return f.waitFor(cgroupPath, "cgroup.freeze", "1", func() (bool, error) {
+			events, err := ioutil.ReadFile(filepath.Join(cgroupPath, "cgroup.events"))
+			if err != nil {
+				return false, err
+			}
+
+			for _, line := range strings.Split(string(events), "\n") {
+				if strings.TrimSpace(line) == "frozen 1" {
+					return true, nil
+				}
+			}
+
+			return false, nil
+		})
+	}
+
+	return f.waitFor(cgroupPath, "freezer.state", "FROZEN", func() (bool, error) {
+		state, err := ioutil.ReadFile(filepath.Join(cgroupPath, "freezer.state"))
+		if err != nil {
+			return false, err
+		}
+
+		return strings.TrimSpace(string(state)) == "FROZEN", nil
+	})
+}
+
+func (f CgroupFreezer) Thaw(cgroupPath string) error {
+	if isUnified(cgroupPath) {
+		return writeControlFile(filepath.Join(cgroupPath, "cgroup.freeze"), "0")
+	}
+
+	return writeControlFile(filepath.Join(cgroupPath, "freezer.state"), "THAWED")
+}
+
+// Frozen reports whether the cgroup has been asked to freeze, e.g. because
+// the container was paused
+func (f CgroupFreezer) Frozen(cgroupPath string) (bool, error) {
+	if isUnified(cgroupPath) {
+		state, err := ioutil.ReadFile(filepath.Join(cgroupPath, "cgroup.freeze"))
+		if err != nil {
+			return false, err
+		}
+
+		return strings.TrimSpace(string(state)) == "1", nil
+	}
+
+	state, err := ioutil.ReadFile(filepath.Join(cgroupPath, "freezer.state"))
+	if err != nil {
+		return false, err
+	}
+
+	return strings.TrimSpace(string(state)) != "THAWED", nil
+}
+
+// waitFor writes the value to the file until frozen reports that the cgroup
+// is frozen, thawing it again if that does not happen in time.
+func (f CgroupFreezer) waitFor(cgroupPath, file, value string, frozen func() (bool, error)) error {
+	for i := 0; i < freezeAttempts; i++ {
+		if err := writeControlFile(filepath.Join(cgroupPath, file), value); err != nil {
+			return fmt.Errorf("freeze: %s", err)
+		}
+
+		done, err := frozen()
+		if err != nil {
+			return fmt.Errorf("freeze: %s", err)
+		}
+
+		if done {
+			return nil
+		}
+
+		time.Sleep(freezeInterval)
+	}
+
+	f.Thaw(cgroupPath)
+	return fmt.Errorf("freeze: cgroup '%s' did not freeze after %s", cgroupPath, freezeAttempts*freezeInterval)
+}
+
+func isUnified(cgroupPath string) bool {
+	_, err := os.Stat(filepath.Join(cgroupPath, "cgroup.freeze"))
+	return err == nil
+}
+
+func writeControlFile(path, value string) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_TRUNC, 0)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = file.WriteString(value)
+	return err
+}
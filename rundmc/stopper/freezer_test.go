@@ -0,0 +1,97 @@
+package stopper_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/guardian/rundmc/stopper"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("CgroupFreezer", func() {
+	var (
+		cgroupPath string
+		freezer    stopper.CgroupFreezer
+	)
+
+	BeforeEach(func() {
+		var err error
+		cgroupPath, err = ioutil.TempDir("", "freezer")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(cgroupPath)
+	})
+
+	readFile := func(name string) string {
+		content, err := ioutil.ReadFile(filepath.Join(cgroupPath, name))
+		Expect(err).NotTo(HaveOccurred())
+		return string(content)
+	}
+
+	Context("with a cgroup v1 freezer cgroup", func() {
+		BeforeEach(func() {
+			Expect(ioutil.WriteFile(filepath.Join(cgroupPath, "freezer.state"), []byte("THAWED"), 0600)).To(Succeed())
+		})
+
+		It("freezes it through freezer.state", func() {
+			Expect(freezer.Freeze(cgroupPath)).To(Succeed())
+			Expect(readFile("freezer.state")).To(Equal("FROZEN"))
+		})
+
+		It("thaws it through freezer.state", func() {
+			Expect(freezer.Freeze(cgroupPath)).To(Succeed())
+			Expect(freezer.Thaw(cgroupPath)).To(Succeed())
+			Expect(readFile("freezer.state")).To(Equal("THAWED"))
+		})
+
+		It("reports whether it is frozen", func() {
+			Expect(freezer.Frozen(cgroupPath)).To(BeFalse())
+			Expect(freezer.Freeze(cgroupPath)).To(Succeed())
+			Expect(freezer.Frozen(cgroupPath)).To(BeTrue())
+		})
+	})
+
+	Context("with a cgroup2 cgroup", func() {
+		BeforeEach(func() {
+			Expect(ioutil.WriteFile(filepath.Join(cgroupPath, "cgroup.freeze"), []byte("0"), 0600)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(cgroupPath, "cgroup.events"), []byte("populated 1\nfrozen 1\n"), 0600)).To(Succeed())
+		})
+
+		It("freezes it through cgroup.freeze", func() {
+			Expect(freezer.Freeze(cgroupPath)).To(Succeed())
+			Expect(readFile("cgroup.freeze")).To(Equal("1"))
+		})
+
+		It("thaws it through cgroup.freeze", func() {
+			Expect(freezer.Thaw(cgroupPath)).To(Succeed())
+			Expect(readFile("cgroup.freeze")).To(Equal("0"))
+		})
+
+		It("reports whether it is frozen", func() {
+			Expect(freezer.Frozen(cgroupPath)).To(BeFalse())
+			Expect(freezer.Freeze(cgroupPath)).To(Succeed())
+			Expect(freezer.Frozen(cgroupPath)).To(BeTrue())
+		})
+
+		Context("when the cgroup never reports that it is frozen", func() {
+			BeforeEach(func() {
+				Expect(ioutil.WriteFile(filepath.Join(cgroupPath, "cgroup.events"), []byte("populated 1\nfrozen 0\n"), 0600)).To(Succeed())
+			})
+
+			It("gives up, thaws it again and returns an error", func() {
+				Expect(freezer.Freeze(cgroupPath)).To(MatchError(ContainSubstring("did not freeze")))
+				Expect(readFile("cgroup.freeze")).To(Equal("0"))
+			})
+		})
+	})
+
+	Context("when the cgroup has no freezer", func() {
+		It("returns an error", func() {
+			Expect(freezer.Freeze(cgroupPath)).To(MatchError(ContainSubstring("freeze")))
+		})
+	})
+})
@@ -0,0 +1,69 @@
+package stopper
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// InitPidFunc returns the pid of a container's init process, e.g. from the
+// runtime's state command
+type InitPidFunc func(name string) (int, error)
+
+type procResolver struct {
+	procDir           string
+	cgroupsMountpoint string
+	initPid           InitPidFunc
+}
+
+// NewProcCgroupPathResolver returns a resolver for runtimes whose on-disk
+// state is not runc's, which finds the cgroups of the container's init
+// process in procDir (i.e. /proc) under the cgroups mounted by guardian
+func NewProcCgroupPathResolver(procDir, cgroupsMountpoint string, initPid InitPidFunc) *procResolver {
+	return &procResolver{
+		procDir:           procDir,
+		cgroupsMountpoint: cgroupsMountpoint,
+		initPid:           initPid,
+	}
+}
+
+func (r procResolver) Resolve(name, subsystem string) (string, error) {
+	pid, err := r.initPid(name)
+	if err != nil {
+		return "", err
+	}
+
+	contents, err := ioutil.ReadFile(filepath.Join(r.procDir, strconv.Itoa(pid), "cgroup"))
+	if err != nil {
+		return "", err
+	}
+
+	// each line is hierarchy-id:controllers:path, and the unified cgroup2
+	// hierarchy is the one with id 0 and no controllers
+	var unified string
+	for _, line := range strings.Split(string(contents), "\n") {
+		fields := strings.SplitN(line, ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		if fields[0] == "0" && fields[1] == "" {
+			unified = fields[2]
+			continue
+		}
+
+		for _, controller := range strings.Split(fields[1], ",") {
+			if controller == subsystem {
+				return filepath.Join(r.cgroupsMountpoint, subsystem, fields[2]), nil
+			}
+		}
+	}
+
+	if unified != "" {
+		return filepath.Join(r.cgroupsMountpoint, unified), nil
+	}
+
+	return "", fmt.Errorf("no %s cgroup found for container %s", subsystem, name)
+}
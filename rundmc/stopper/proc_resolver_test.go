@@ -0,0 +1,79 @@
+package stopper_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/guardian/rundmc/stopper"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ProcResolver", func() {
+	var (
+		procDir  string
+		resolver stopper.CgroupPathResolver
+	)
+
+	BeforeEach(func() {
+		var err error
+		procDir, err = ioutil.TempDir("", "fakeproc")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.MkdirAll(filepath.Join(procDir, "1234"), 0700)).To(Succeed())
+
+		resolver = stopper.NewProcCgroupPathResolver(procDir, "/cgroups", func(name string) (int, error) {
+			Expect(name).To(Equal("some-handle"))
+			return 1234, nil
+		})
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(procDir)
+	})
+
+	writeCgroups := func(contents string) {
+		Expect(ioutil.WriteFile(filepath.Join(procDir, "1234", "cgroup"), []byte(contents), 0600)).To(Succeed())
+	}
+
+	It("resolves the subsystem's cgroup of the container's init process under the mountpoint", func() {
+		writeCgroups("4:freezer:/garden/some-handle\n3:cpu,cpuacct:/garden/some-handle-cpu\n2:devices:/garden/some-handle\n")
+
+		path, err := resolver.Resolve("some-handle", "devices")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal("/cgroups/devices/garden/some-handle"))
+
+		path, err = resolver.Resolve("some-handle", "cpuacct")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal("/cgroups/cpuacct/garden/some-handle-cpu"))
+	})
+
+	It("resolves every subsystem to the same cgroup on the unified cgroup2 hierarchy", func() {
+		writeCgroups("0::/garden/some-handle\n")
+
+		path, err := resolver.Resolve("some-handle", "freezer")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal("/cgroups/garden/some-handle"))
+	})
+
+	It("returns an error when the subsystem is not found", func() {
+		writeCgroups("2:devices:/garden/some-handle\n")
+
+		_, err := resolver.Resolve("some-handle", "freezer")
+		Expect(err).To(MatchError(ContainSubstring("no freezer cgroup")))
+	})
+
+	Context("when the init pid cannot be found", func() {
+		BeforeEach(func() {
+			resolver = stopper.NewProcCgroupPathResolver(procDir, "/cgroups", func(string) (int, error) {
+				return 0, errors.New("no-such-container")
+			})
+		})
+
+		It("returns the error", func() {
+			_, err := resolver.Resolve("some-handle", "devices")
+			Expect(err).To(MatchError("no-such-container"))
+		})
+	})
+})
@@ -31,5 +31,11 @@ func (r resolver) Resolve(name, subsystem string) (string, error) {
 		return "", err
 	}
 
-	return s.CgroupPaths["devices"], nil
+	if path, ok := s.CgroupPaths[subsystem]; ok {
+		return path, nil
+	}
+
+	// on the unified cgroup2 hierarchy runc records a single path under an
+	// empty key, which is shared by all subsystems
+	return s.CgroupPaths[""], nil
 }
@@ -48,6 +48,26 @@ var _ = Describe("Resolver", func() {
 		})
 	})
 
+	Context("with a state.json from the unified cgroup2 hierarchy", func() {
+		BeforeEach(func() {
+			stateJson, err := os.Create(filepath.Join(fakeStateDir, "some-handle", "state.json"))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(json.NewEncoder(stateJson).Encode(map[string]interface{}{
+				"cgroup_paths": map[string]string{
+					"": "i-am-the-unified-cgroup-path",
+				},
+			})).To(Succeed())
+			Expect(stateJson.Close()).To(Succeed())
+		})
+
+		It("resolves every subsystem to the unified cgroup path", func() {
+			path, err := stopper.NewRuncStateCgroupPathResolver(fakeStateDir).Resolve("some-handle", "devices")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(path).To(Equal("i-am-the-unified-cgroup-path"))
+		})
+	})
+
 	Context("with invalid state.json", func() {
 		BeforeEach(func() {
 			stateJson, err := os.Create(filepath.Join(fakeStateDir, "some-handle", "state.json"))
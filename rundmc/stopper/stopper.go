@@ -5,6 +5,7 @@ import "syscall"
 //go:generate counterfeiter . Killer
 //go:generate counterfeiter . CgroupPathResolver
 //go:generate counterfeiter . Retrier
+//go:generate counterfeiter . Freezer
 
 type Killer interface {
 	Kill(signal syscall.Signal, pid ...int)
@@ -18,13 +19,25 @@ type Retrier interface {
 	Run(work func() error) error
 }
 
+type Freezer interface {
+	Freeze(cgroupPath string) error
+	Thaw(cgroupPath string) error
+	Frozen(cgroupPath string) (bool, error)
+}
+
 type CgroupStopper struct {
 	killer             Killer
 	retrier            Retrier
 	cgroupPathResolver CgroupPathResolver
+	freezer            Freezer
 }
 
-func New(cgroupPathResolver CgroupPathResolver, killer Killer, retrier Retrier) *CgroupStopper {
+// New returns a stopper which signals the processes in a cgroup until they
+// have all exited. When a freezer is given, the cgroup is frozen while it is
+// signalled so that no process can fork out of the way of the signal; the
+// retry loop still runs afterwards as a fallback. A nil freezer only uses the
+// retry loop.
+func New(cgroupPathResolver CgroupPathResolver, killer Killer, retrier Retrier, freezer Freezer) *CgroupStopper {
 	if killer == nil {
 		killer = DefaultKiller{}
 	}
@@ -33,5 +46,6 @@ func New(cgroupPathResolver CgroupPathResolver, killer Killer, retrier Retrier)
 		killer:             killer,
 		cgroupPathResolver: cgroupPathResolver,
 		retrier:            retrier,
+		freezer:            freezer,
 	}
 }
@@ -22,11 +22,13 @@ func (stopper *CgroupStopper) StopAll(log lager.Logger, cgroupName string, excep
 	}
 
 	if !kill {
+		stopper.signalFrozen(log, cgroupName, syscall.SIGTERM, devicesSubsystemPath, exceptions)
 		stopper.retrier.Run(func() error {
 			return stopper.killAllRemaining(syscall.SIGTERM, devicesSubsystemPath, exceptions)
 		})
 	}
 
+	stopper.signalFrozen(log, cgroupName, syscall.SIGKILL, devicesSubsystemPath, exceptions)
 	stopper.retrier.Run(func() error {
 		return stopper.killAllRemaining(syscall.SIGKILL, devicesSubsystemPath, exceptions)
 	})
@@ -34,6 +36,47 @@ func (stopper *CgroupStopper) StopAll(log lager.Logger, cgroupName string, excep
 	return nil // we killed, so everything must die
 }
 
+// signalFrozen signals every process in the cgroup while it is frozen, and
+// then leaves the cgroup frozen or thawed as it found it. Any failure is
+// logged and left to the retry loop.
+func (stopper *CgroupStopper) signalFrozen(log lager.Logger, cgroupName string, signal syscall.Signal, cgroupPath string, exceptions []int) {
+	if stopper.freezer == nil {
+		return
+	}
+
+	log = log.Session("signal-frozen", lager.Data{"signal": signal.String()})
+
+	freezerPath, err := stopper.cgroupPathResolver.Resolve(cgroupName, "freezer")
+	if err != nil {
+		log.Error("resolve-freezer-failed-falling-back", err)
+		return
+	}
+
+	alreadyFrozen, err := stopper.freezer.Frozen(freezerPath)
+	if err != nil {
+		log.Error("read-freezer-state-failed-falling-back", err)
+		return
+	}
+
+	if alreadyFrozen {
+		stopper.killAllRemaining(signal, cgroupPath, exceptions)
+		return
+	}
+
+	if err := stopper.freezer.Freeze(freezerPath); err != nil {
+		log.Error("freeze-failed-falling-back", err)
+		return
+	}
+
+	defer func() {
+		if err := stopper.freezer.Thaw(freezerPath); err != nil {
+			log.Error("thaw-failed", err)
+		}
+	}()
+
+	stopper.killAllRemaining(signal, cgroupPath, exceptions)
+}
+
 func (stopper *CgroupStopper) killAllRemaining(signal syscall.Signal, cgroupPath string, exceptions []int) error {
 	pidsInCgroup, err := cgroups.GetAllPids(cgroupPath)
 	if err != nil {
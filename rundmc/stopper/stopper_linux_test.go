@@ -1,6 +1,7 @@
 package stopper_test
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
@@ -48,7 +49,7 @@ var _ = Describe("CgroupStopper", func() {
 			return fn()
 		}
 
-		subject = stopper.New(fakeCgroupResolver, fakeKiller, fakeRetrier)
+		subject = stopper.New(fakeCgroupResolver, fakeKiller, fakeRetrier, nil)
 	})
 
 	AfterEach(func() {
@@ -137,6 +138,136 @@ var _ = Describe("CgroupStopper", func() {
 			})
 		})
 	})
+
+	Context("when a freezer is given", func() {
+		var (
+			fakeFreezer *fakes.FakeFreezer
+			frozen      bool
+			frozenKills []bool
+		)
+
+		BeforeEach(func() {
+			frozen = false
+			frozenKills = nil
+
+			fakeFreezer = new(fakes.FakeFreezer)
+			fakeFreezer.FreezeStub = func(path string) error {
+				frozen = true
+				return nil
+			}
+			fakeFreezer.ThawStub = func(path string) error {
+				frozen = false
+				return nil
+			}
+			fakeKiller.KillStub = func(signal syscall.Signal, pids ...int) {
+				frozenKills = append(frozenKills, frozen)
+			}
+
+			subject = stopper.New(fakeCgroupResolver, fakeKiller, fakeRetrier, fakeFreezer)
+		})
+
+		It("freezes the freezer cgroup of the container", func() {
+			Expect(subject.StopAll(lagertest.NewTestLogger("test"), "foo", nil, true)).To(Succeed())
+
+			Expect(fakeFreezer.FreezeCallCount()).To(Equal(1))
+			Expect(fakeFreezer.FreezeArgsForCall(0)).To(Equal(filepath.Join(fakeCgroupDir, "foo", "freezer")))
+		})
+
+		It("signals every process while the cgroup is frozen, then thaws it", func() {
+			Expect(subject.StopAll(lagertest.NewTestLogger("test"), "foo", []int{3}, true)).To(Succeed())
+
+			Expect(fakeKiller).To(HaveKilled(0, syscall.SIGKILL, 1, 5, 9))
+			Expect(frozenKills[0]).To(BeTrue())
+
+			Expect(fakeFreezer.ThawCallCount()).To(Equal(1))
+			Expect(fakeFreezer.ThawArgsForCall(0)).To(Equal(filepath.Join(fakeCgroupDir, "foo", "freezer")))
+			Expect(frozen).To(BeFalse())
+		})
+
+		It("still runs the retry loop as a fallback once thawed", func() {
+			Expect(subject.StopAll(lagertest.NewTestLogger("test"), "foo", nil, true)).To(Succeed())
+
+			Expect(fakeRetrier.RunCallCount()).To(Equal(1))
+			Expect(fakeKiller).To(HaveKilled(1, syscall.SIGKILL, 1, 3, 5, 9))
+			Expect(frozenKills[1]).To(BeFalse())
+		})
+
+		Context("when the kill flag is false", func() {
+			It("sends a frozen TERM before the frozen KILL", func() {
+				Expect(subject.StopAll(lagertest.NewTestLogger("test"), "foo", nil, false)).To(Succeed())
+
+				Expect(fakeFreezer.FreezeCallCount()).To(Equal(2))
+				Expect(fakeKiller).To(HaveKilled(0, syscall.SIGTERM, 1, 3, 5, 9))
+				Expect(frozenKills[0]).To(BeTrue())
+				Expect(fakeKiller).To(HaveKilled(2, syscall.SIGKILL, 1, 3, 5, 9))
+				Expect(frozenKills[2]).To(BeTrue())
+			})
+		})
+
+		Context("when the cgroup is already frozen", func() {
+			BeforeEach(func() {
+				frozen = true
+				fakeFreezer.FrozenStub = func(path string) (bool, error) {
+					return frozen, nil
+				}
+			})
+
+			It("signals the processes and leaves it frozen", func() {
+				Expect(subject.StopAll(lagertest.NewTestLogger("test"), "foo", nil, true)).To(Succeed())
+
+				Expect(fakeKiller).To(HaveKilled(0, syscall.SIGKILL, 1, 3, 5, 9))
+				Expect(fakeFreezer.FreezeCallCount()).To(Equal(0))
+				Expect(fakeFreezer.ThawCallCount()).To(Equal(0))
+				Expect(frozen).To(BeTrue())
+			})
+		})
+
+		Context("when reading the freezer state fails", func() {
+			BeforeEach(func() {
+				fakeFreezer.FrozenReturns(false, errors.New("no-freezer-state"))
+			})
+
+			It("falls back to the retry loop without freezing", func() {
+				Expect(subject.StopAll(lagertest.NewTestLogger("test"), "foo", nil, true)).To(Succeed())
+
+				Expect(fakeFreezer.FreezeCallCount()).To(Equal(0))
+				Expect(fakeFreezer.ThawCallCount()).To(Equal(0))
+				Expect(fakeKiller).To(HaveKilled(0, syscall.SIGKILL, 1, 3, 5, 9))
+			})
+		})
+
+		Context("when freezing fails", func() {
+			BeforeEach(func() {
+				fakeFreezer.FreezeReturns(errors.New("no-freezer"))
+			})
+
+			It("falls back to the retry loop without thawing", func() {
+				Expect(subject.StopAll(lagertest.NewTestLogger("test"), "foo", nil, true)).To(Succeed())
+
+				Expect(fakeFreezer.ThawCallCount()).To(Equal(0))
+				Expect(fakeKiller.KillCallCount()).To(Equal(1))
+				Expect(fakeKiller).To(HaveKilled(0, syscall.SIGKILL, 1, 3, 5, 9))
+			})
+		})
+
+		Context("when resolving the freezer cgroup fails", func() {
+			BeforeEach(func() {
+				fakeCgroupResolver.ResolveStub = func(name string, subsystem string) (string, error) {
+					if subsystem == "freezer" {
+						return "", errors.New("no-freezer-path")
+					}
+					return filepath.Join(fakeCgroupDir, name, subsystem), nil
+				}
+			})
+
+			It("falls back to the retry loop", func() {
+				Expect(subject.StopAll(lagertest.NewTestLogger("test"), "foo", nil, true)).To(Succeed())
+
+				Expect(fakeFreezer.FreezeCallCount()).To(Equal(0))
+				Expect(fakeKiller).To(HaveKilled(0, syscall.SIGKILL, 1, 3, 5, 9))
+			})
+		})
+	})
 })
 
 type haveKilledMatcher struct {
@@ -0,0 +1,164 @@
+// This file was generated by counterfeiter
+package stopperfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/guardian/rundmc/stopper"
+)
+
+type FakeFreezer struct {
+	FreezeStub        func(cgroupPath string) error
+	freezeMutex       sync.RWMutex
+	freezeArgsForCall []struct {
+		cgroupPath string
+	}
+	freezeReturns struct {
+		result1 error
+	}
+	ThawStub        func(cgroupPath string) error
+	thawMutex       sync.RWMutex
+	thawArgsForCall []struct {
+		cgroupPath string
+	}
+	thawReturns struct {
+		result1 error
+	}
+	FrozenStub        func(cgroupPath string) (bool, error)
+	frozenMutex       sync.RWMutex
+	frozenArgsForCall []struct {
+		cgroupPath string
+	}
+	frozenReturns struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeFreezer) Freeze(cgroupPath string) error {
+	fake.freezeMutex.Lock()
+	fake.freezeArgsForCall = append(fake.freezeArgsForCall, struct {
+		cgroupPath string
+	}{cgroupPath})
+	fake.recordInvocation("Freeze", []interface{}{cgroupPath})
+	fake.freezeMutex.Unlock()
+	if fake.FreezeStub != nil {
+		return fake.FreezeStub(cgroupPath)
+	} else {
+		return fake.freezeReturns.result1
+	}
+}
+
+func (fake *FakeFreezer) FreezeCallCount() int {
+	fake.freezeMutex.RLock()
+	defer fake.freezeMutex.RUnlock()
+	return len(fake.freezeArgsForCall)
+}
+
+func (fake *FakeFreezer) FreezeArgsForCall(i int) string {
+	fake.freezeMutex.RLock()
+	defer fake.freezeMutex.RUnlock()
+	return fake.freezeArgsForCall[i].cgroupPath
+}
+
+func (fake *FakeFreezer) FreezeReturns(result1 error) {
+	fake.FreezeStub = nil
+	fake.freezeReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFreezer) Thaw(cgroupPath string) error {
+	fake.thawMutex.Lock()
+	fake.thawArgsForCall = append(fake.thawArgsForCall, struct {
+		cgroupPath string
+	}{cgroupPath})
+	fake.recordInvocation("Thaw", []interface{}{cgroupPath})
+	fake.thawMutex.Unlock()
+	if fake.ThawStub != nil {
+		return fake.ThawStub(cgroupPath)
+	} else {
+		return fake.thawReturns.result1
+	}
+}
+
+func (fake *FakeFreezer) ThawCallCount() int {
+	fake.thawMutex.RLock()
+	defer fake.thawMutex.RUnlock()
+	return len(fake.thawArgsForCall)
+}
+
+func (fake *FakeFreezer) ThawArgsForCall(i int) string {
+	fake.thawMutex.RLock()
+	defer fake.thawMutex.RUnlock()
+	return fake.thawArgsForCall[i].cgroupPath
+}
+
+func (fake *FakeFreezer) ThawReturns(result1 error) {
+	fake.ThawStub = nil
+	fake.thawReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeFreezer) Frozen(cgroupPath string) (bool, error) {
+	fake.frozenMutex.Lock()
+	fake.frozenArgsForCall = append(fake.frozenArgsForCall, struct {
+		cgroupPath string
+	}{cgroupPath})
+	fake.recordInvocation("Frozen", []interface{}{cgroupPath})
+	fake.frozenMutex.Unlock()
+	if fake.FrozenStub != nil {
+		return fake.FrozenStub(cgroupPath)
+	} else {
+		return fake.frozenReturns.result1, fake.frozenReturns.result2
+	}
+}
+
+func (fake *FakeFreezer) FrozenCallCount() int {
+	fake.frozenMutex.RLock()
+	defer fake.frozenMutex.RUnlock()
+	return len(fake.frozenArgsForCall)
+}
+
+func (fake *FakeFreezer) FrozenArgsForCall(i int) string {
+	fake.frozenMutex.RLock()
+	defer fake.frozenMutex.RUnlock()
+	return fake.frozenArgsForCall[i].cgroupPath
+}
+
+func (fake *FakeFreezer) FrozenReturns(result1 bool, result2 error) {
+	fake.FrozenStub = nil
+	fake.frozenReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFreezer) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.freezeMutex.RLock()
+	defer fake.freezeMutex.RUnlock()
+	fake.thawMutex.RLock()
+	defer fake.thawMutex.RUnlock()
+	fake.frozenMutex.RLock()
+	defer fake.frozenMutex.RUnlock()
+	return fake.invocations
+}
+
+func (fake *FakeFreezer) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ stopper.Freezer = new(FakeFreezer)
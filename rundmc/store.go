@@ -1,8 +1,15 @@
 package rundmc
 
 import (
+	"encoding/json"
+	"fmt"
 	"strings"
 	"sync"
+	"time"
+
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/rundmc/runrunc"
+	"github.com/pivotal-golang/clock"
 )
 
 //go:generate counterfeiter . Properties
@@ -12,32 +19,152 @@ type Properties interface {
 	Get(handle string, key string) (string, bool)
 }
 
+// EventHistorySize is the number of events kept for each container. Older
+// events are dropped from the history once it is full.
+const EventHistorySize = 100
+
+const eventsKey = "rundmc.events"
+
+// EventSourceRunc identifies events reported by `runc events`.
+const EventSourceRunc = "runc"
+
+type Event struct {
+	Type   string    `json:"type"`
+	Time   time.Time `json:"time"`
+	Source string    `json:"source"`
+	Detail string    `json:"detail,omitempty"`
+}
+
+// EventHistory is the bounded event history of a container. Total counts
+// every event ever recorded, including those dropped from Events.
+type EventHistory struct {
+	Total  uint64  `json:"total"`
+	Events []Event `json:"events"`
+}
+
+// EventQuery selects events from a history. Zero-valued fields match
+// everything and Limit keeps only the most recent matching events.
+type EventQuery struct {
+	Type   string
+	Source string
+	Since  time.Time
+	Limit  int
+}
+
 type events struct {
-	props Properties
-	mu    sync.Mutex
+	props     Properties
+	clock     clock.Clock
+	publisher gardener.EventPublisher
+	mu        sync.Mutex
 }
 
-func NewEventStore(props Properties) *events {
+func NewEventStore(props Properties, clock clock.Clock, publisher gardener.EventPublisher) *events {
 	return &events{
-		props: props,
+		props:     props,
+		clock:     clock,
+		publisher: publisher,
 	}
 }
 
 func (e *events) OnEvent(handle, event string) error {
+	if err := e.Record(handle, Event{Type: event, Source: EventSourceRunc}); err != nil {
+		return err
+	}
+
+	if event == runrunc.OOMEvent {
+		e.publisher.Publish(gardener.Event{Type: gardener.EventOOM, Handle: handle})
+	}
+
+	return nil
+}
+
+func (e *events) Record(handle string, event Event) error {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	events := append(e.Events(handle), event)
-	e.props.Set(handle, "rundmc.events", strings.Join(events, ","))
+	if event.Time.IsZero() {
+		event.Time = e.clock.Now()
+	}
+
+	history := e.load(handle)
+	history.Total++
+	history.Events = append(history.Events, event)
+	if len(history.Events) > EventHistorySize {
+		history.Events = history.Events[len(history.Events)-EventHistorySize:]
+	}
+
+	value, err := json.Marshal(history)
+	if err != nil {
+		return fmt.Errorf("encode events: %s", err)
+	}
+
+	e.props.Set(handle, eventsKey, string(value))
 	return nil
 }
 
 func (e *events) Events(handle string) []string {
-	if value, ok := e.props.Get(handle, "rundmc.events"); ok {
-		return strings.Split(value, ",")
+	var events []string
+	for _, event := range e.History(handle).Events {
+		events = append(events, event.Type)
 	}
 
-	return nil
+	return events
+}
+
+func (e *events) History(handle string) EventHistory {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.load(handle)
+}
+
+func (e *events) Query(handle string, query EventQuery) EventHistory {
+	history := e.History(handle)
+
+	var matches []Event
+	for _, event := range history.Events {
+		if query.Type != "" && event.Type != query.Type {
+			continue
+		}
+
+		if query.Source != "" && event.Source != query.Source {
+			continue
+		}
+
+		if !query.Since.IsZero() && event.Time.Before(query.Since) {
+			continue
+		}
+
+		matches = append(matches, event)
+	}
+
+	if query.Limit > 0 && len(matches) > query.Limit {
+		matches = matches[len(matches)-query.Limit:]
+	}
+
+	history.Events = matches
+	return history
+}
+
+func (e *events) load(handle string) EventHistory {
+	value, ok := e.props.Get(handle, eventsKey)
+	if !ok || value == "" {
+		return EventHistory{}
+	}
+
+	var history EventHistory
+	if err := json.Unmarshal([]byte(value), &history); err == nil {
+		return history
+	}
+
+	// events recorded before the history was structured are stored as a
+	// comma-separated list of event types
+	for _, event := range strings.Split(value, ",") {
+		history.Events = append(history.Events, Event{Type: event, Source: EventSourceRunc})
+	}
+	history.Total = uint64(len(history.Events))
+
+	return history
 }
 
 type states struct {
@@ -62,3 +189,20 @@ func (s *states) IsStopped(handle string) bool {
 
 	return value == "stopped"
 }
+
+func (s *states) StorePaused(handle string) {
+	s.props.Set(handle, "rundmc.paused", "true")
+}
+
+func (s *states) StoreResumed(handle string) {
+	s.props.Set(handle, "rundmc.paused", "false")
+}
+
+func (s *states) IsPaused(handle string) bool {
+	value, ok := s.props.Get(handle, "rundmc.paused")
+	if !ok {
+		return false
+	}
+
+	return value == "true"
+}
@@ -2,69 +2,156 @@ package rundmc_test
 
 import (
 	"fmt"
+	"time"
 
+	"code.cloudfoundry.org/guardian/gardener"
+	"code.cloudfoundry.org/guardian/gardener/gardenerfakes"
 	"code.cloudfoundry.org/guardian/rundmc"
 	fakes "code.cloudfoundry.org/guardian/rundmc/rundmcfakes"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/pivotal-golang/clock/fakeclock"
 )
 
 var _ = Describe("Event Store", func() {
 	var (
-		props *fakes.FakeProperties
+		props          *fakes.FakeProperties
+		fakeClock      *fakeclock.FakeClock
+		eventPublisher *gardenerfakes.FakeEventPublisher
+		stored         map[string]string
+		events         rundmc.EventStore
 	)
 
 	BeforeEach(func() {
+		stored = make(map[string]string)
 		props = new(fakes.FakeProperties)
+		props.SetStub = func(handle, key, value string) {
+			stored[handle+"/"+key] = value
+		}
+		props.GetStub = func(handle, key string) (string, bool) {
+			value, ok := stored[handle+"/"+key]
+			return value, ok
+		}
+
+		fakeClock = fakeclock.NewFakeClock(time.Unix(123, 0))
+		eventPublisher = new(gardenerfakes.FakeEventPublisher)
+		events = rundmc.NewEventStore(props, fakeClock, eventPublisher)
 	})
 
 	It("stashes events on the property manager under the 'rundmc.events' key", func() {
-		events := rundmc.NewEventStore(props)
-		events.OnEvent("foo", "bar")
+		Expect(events.OnEvent("foo", "bar")).To(Succeed())
 
 		Expect(props.SetCallCount()).To(Equal(1))
-
-		handle, key, value := props.SetArgsForCall(0)
+		handle, key, _ := props.SetArgsForCall(0)
 		Expect(handle).To(Equal("foo"))
 		Expect(key).To(Equal("rundmc.events"))
-		Expect(value).To(Equal("bar"))
 	})
 
-	It("stashes further events on the same property using a CSV for the value", func() {
-		props.GetReturns("bar", true)
+	It("records the time and source of each event", func() {
+		Expect(events.OnEvent("foo", "bar")).To(Succeed())
+		fakeClock.Increment(time.Minute)
+		Expect(events.OnEvent("foo", "baz")).To(Succeed())
 
-		events := rundmc.NewEventStore(props)
-		events.OnEvent("foo", "baz")
+		history := events.Query("foo", rundmc.EventQuery{})
+		Expect(history.Total).To(BeEquivalentTo(2))
+		Expect(history.Events).To(HaveLen(2))
 
-		Expect(props.SetCallCount()).To(Equal(1))
-		handle, key, value := props.SetArgsForCall(0)
-		Expect(handle).To(Equal("foo"))
-		Expect(key).To(Equal("rundmc.events"))
-		Expect(value).To(Equal("bar,baz"))
+		Expect(history.Events[0].Type).To(Equal("bar"))
+		Expect(history.Events[0].Source).To(Equal("runc"))
+		Expect(history.Events[0].Time).To(BeTemporally("==", time.Unix(123, 0)))
+
+		Expect(history.Events[1].Type).To(Equal("baz"))
+		Expect(history.Events[1].Source).To(Equal("runc"))
+		Expect(history.Events[1].Time).To(BeTemporally("==", time.Unix(183, 0)))
 	})
 
-	It("retrieves events from the property manager", func() {
-		props.GetStub = func(handle, key string) (string, bool) {
-			return fmt.Sprintf("%s,%s", handle, key), true
+	It("publishes out of memory events", func() {
+		Expect(events.OnEvent("foo", "Out of memory")).To(Succeed())
+
+		Expect(eventPublisher.PublishCallCount()).To(Equal(1))
+		Expect(eventPublisher.PublishArgsForCall(0)).To(Equal(gardener.Event{Type: gardener.EventOOM, Handle: "foo"}))
+	})
+
+	It("does not publish other events", func() {
+		Expect(events.OnEvent("foo", "potato")).To(Succeed())
+		Expect(eventPublisher.PublishCallCount()).To(Equal(0))
+	})
+
+	It("keeps events containing commas intact", func() {
+		Expect(events.OnEvent("foo", "bar,baz")).To(Succeed())
+		Expect(events.Events("foo")).To(Equal([]string{"bar,baz"}))
+	})
+
+	It("keeps events for each container separately", func() {
+		Expect(events.OnEvent("foo", "bar")).To(Succeed())
+		Expect(events.OnEvent("other", "baz")).To(Succeed())
+
+		Expect(events.Events("foo")).To(Equal([]string{"bar"}))
+		Expect(events.Events("other")).To(Equal([]string{"baz"}))
+	})
+
+	It("drops the oldest events once the history is full, but still counts them", func() {
+		for i := 0; i < rundmc.EventHistorySize+5; i++ {
+			Expect(events.OnEvent("foo", fmt.Sprintf("event-%d", i))).To(Succeed())
 		}
 
-		events := rundmc.NewEventStore(props)
-		Expect(events.Events("some-container")).To(Equal([]string{
-			"some-container", "rundmc.events",
-		}))
+		history := events.Query("foo", rundmc.EventQuery{})
+		Expect(history.Total).To(BeEquivalentTo(rundmc.EventHistorySize + 5))
+		Expect(history.Events).To(HaveLen(rundmc.EventHistorySize))
+		Expect(history.Events[0].Type).To(Equal("event-5"))
+	})
+
+	It("reads events stored as a comma-separated list by older versions", func() {
+		stored["foo/rundmc.events"] = "bar,baz"
+
+		Expect(events.Events("foo")).To(Equal([]string{"bar", "baz"}))
+		Expect(events.OnEvent("foo", "qux")).To(Succeed())
+		Expect(events.Events("foo")).To(Equal([]string{"bar", "baz", "qux"}))
 	})
 
 	It("returns no events when the property hasn't been set or cant be retrieved", func() {
 		props.GetReturns("bar", false)
-
-		events := rundmc.NewEventStore(props)
 		Expect(events.Events("some-container")).To(HaveLen(0))
 	})
 
 	It("returns no events when the property is empty", func() {
-		events := rundmc.NewEventStore(props)
+		stored["some-container/rundmc.events"] = ""
 		Expect(events.Events("some-container")).To(HaveLen(0))
 	})
+
+	Describe("Query", func() {
+		BeforeEach(func() {
+			Expect(events.OnEvent("foo", "Out of memory")).To(Succeed())
+			fakeClock.Increment(time.Minute)
+			Expect(events.OnEvent("foo", "potato")).To(Succeed())
+			fakeClock.Increment(time.Minute)
+			Expect(events.OnEvent("foo", "Out of memory")).To(Succeed())
+		})
+
+		It("filters by type", func() {
+			history := events.Query("foo", rundmc.EventQuery{Type: "Out of memory"})
+			Expect(history.Total).To(BeEquivalentTo(3))
+			Expect(history.Events).To(HaveLen(2))
+		})
+
+		It("filters by source", func() {
+			Expect(events.Query("foo", rundmc.EventQuery{Source: "runc"}).Events).To(HaveLen(3))
+			Expect(events.Query("foo", rundmc.EventQuery{Source: "potato"}).Events).To(BeEmpty())
+		})
+
+		It("filters out events recorded before since", func() {
+			history := events.Query("foo", rundmc.EventQuery{Since: time.Unix(183, 0)})
+			Expect(history.Events).To(HaveLen(2))
+			Expect(history.Events[0].Type).To(Equal("potato"))
+		})
+
+		It("keeps only the most recent events up to the limit", func() {
+			history := events.Query("foo", rundmc.EventQuery{Limit: 1})
+			Expect(history.Events).To(HaveLen(1))
+			Expect(history.Events[0].Type).To(Equal("Out of memory"))
+			Expect(history.Events[0].Time).To(BeTemporally("==", time.Unix(243, 0)))
+		})
+	})
 })
 
 var _ = Describe("States Store", func() {
@@ -132,4 +219,72 @@ var _ = Describe("States Store", func() {
 			})
 		})
 	})
+	It("stashes the paused state on the property manager under the 'rundmc.paused' key", func() {
+		states := rundmc.NewStateStore(props)
+		states.StorePaused("foo")
+
+		Expect(props.SetCallCount()).To(Equal(1))
+
+		handle, key, value := props.SetArgsForCall(0)
+		Expect(handle).To(Equal("foo"))
+		Expect(key).To(Equal("rundmc.paused"))
+		Expect(value).To(Equal("true"))
+	})
+
+	It("clears the paused state when the container is resumed", func() {
+		states := rundmc.NewStateStore(props)
+		states.StoreResumed("foo")
+
+		Expect(props.SetCallCount()).To(Equal(1))
+
+		handle, key, value := props.SetArgsForCall(0)
+		Expect(handle).To(Equal("foo"))
+		Expect(key).To(Equal("rundmc.paused"))
+		Expect(value).To(Equal("false"))
+	})
+
+	Describe("IsPaused", func() {
+		var (
+			state map[string]string
+		)
+
+		BeforeEach(func() {
+			state = make(map[string]string)
+
+			props.GetStub = func(handle, key string) (string, bool) {
+				Expect(handle).To(Equal("some-handle"))
+				v, ok := state[key]
+				return v, ok
+			}
+		})
+
+		Context("when the rundmc.paused has the value 'true'", func() {
+			BeforeEach(func() {
+				state["rundmc.paused"] = "true"
+			})
+
+			It("returns true", func() {
+				states := rundmc.NewStateStore(props)
+				Expect(states.IsPaused("some-handle")).To(BeTrue())
+			})
+		})
+
+		Context("when the rundmc.paused has the value 'false'", func() {
+			BeforeEach(func() {
+				state["rundmc.paused"] = "false"
+			})
+
+			It("returns false", func() {
+				states := rundmc.NewStateStore(props)
+				Expect(states.IsPaused("some-handle")).To(BeFalse())
+			})
+		})
+
+		Context("when the rundmc.paused has no value", func() {
+			It("returns false", func() {
+				states := rundmc.NewStateStore(props)
+				Expect(states.IsPaused("some-handle")).To(BeFalse())
+			})
+		})
+	})
 })